@@ -25,7 +25,6 @@ import (
 	abciTypes "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/log"
-	tmTypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/ixofoundation/ixo-cosmos/x/bonds"
@@ -78,6 +77,10 @@ var (
 		gov.ModuleName:                   {supply.Burner},
 		bonds.BondsMintBurnAccount:       {supply.Minter, supply.Burner},
 		bonds.BatchesIntermediaryAccount: nil,
+		bonds.BondsReserveAccount:        nil,
+		bonds.BondsFeesAccount:           nil,
+		bonds.BondsVestingAccount:        nil,
+		bonds.BondsFundingPoolAccount:    nil,
 	}
 )
 
@@ -178,10 +181,12 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 	app.didKeeper = did.NewKeeper(app.cdc, keys[did.StoreKey])
 	app.paramsKeepr = params.NewKeeper(app.cdc, keys[params.StoreKey])
 	app.feesKeeper = fees.NewKeeper(app.cdc, app.paramsKeepr)
-	app.projectKeeper = project.NewKeeper(app.cdc, keys[project.StoreKey], app.accountKeeper, app.feesKeeper)
+	app.projectKeeper = project.NewKeeper(app.cdc, keys[project.StoreKey], app.accountKeeper,
+		app.didKeeper, app.feesKeeper)
 	app.nodeKeeper = node.NewKeeper(app.cdc, app.paramsKeepr)
 	app.contractKeeper = contracts.NewKeeper(app.cdc, app.paramsKeepr)
-	app.bondsKeeper = bonds.NewKeeper(app.bankKeeper, app.supplyKeeper, app.accountKeeper, app.stakingKeeper, keys[bonds.StoreKey], app.cdc)
+	app.bondsKeeper = bonds.NewKeeper(app.bankKeeper, app.supplyKeeper, app.accountKeeper,
+		app.stakingKeeper, app.didKeeper, keys[bonds.StoreKey], app.cdc)
 
 	newEthClient, cErr := ixo.NewEthClient(app.contractKeeper)
 	if cErr != nil {
@@ -214,7 +219,7 @@ func NewIxoApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bo
 	)
 
 	app.mm.SetOrderBeginBlockers(mint.ModuleName, distribution.ModuleName, slashing.ModuleName, bonds.ModuleName)
-	app.mm.SetOrderEndBlockers(gov.ModuleName, staking.ModuleName, bonds.ModuleName)
+	app.mm.SetOrderEndBlockers(gov.ModuleName, staking.ModuleName, bonds.ModuleName, project.ModuleName)
 
 	app.mm.SetOrderInitGenesis(genaccounts.ModuleName, distribution.ModuleName,
 		staking.ModuleName, auth.ModuleName, bank.ModuleName, slashing.ModuleName,
@@ -271,23 +276,9 @@ func (app *ixoApp) ModuleAccountAddrs() map[string]bool {
 	return modAccAddrs
 }
 
-func (app *ixoApp) ExportAppStateAndValidators(forZeroHeight bool, jailWhiteList []string) (appState json.RawMessage,
-	validators []tmTypes.GenesisValidator, err error) {
-
-	ctx := app.NewContext(true, abciTypes.Header{Height: app.LastBlockHeight()})
-	genState := app.mm.ExportGenesis(ctx)
-	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	validators = staking.WriteValidators(ctx, app.stakingKeeper)
-
-	return appState, validators, nil
-}
-
 func NewIxoAnteHandler(app *ixoApp) sdk.AnteHandler {
-	cosmosAnteHandler := auth.NewAnteHandler(app.accountKeeper, app.supplyKeeper, auth.DefaultSigVerificationGasConsumer)
+	cosmosAnteHandler := bonds.NewTransferPolicyDecorator(app.bondsKeeper,
+		auth.NewAnteHandler(app.accountKeeper, app.supplyKeeper, auth.DefaultSigVerificationGasConsumer))
 	didAnteHandler := did.NewAnteHandler(app.didKeeper)
 	projectAnteHandler := project.NewAnteHandler(app.projectKeeper, app.didKeeper)
 
@@ -19,6 +19,7 @@ import (
 	
 	"github.com/ixofoundation/ixo-cosmos/app"
 	ixoClient "github.com/ixofoundation/ixo-cosmos/client"
+	bondsRest "github.com/ixofoundation/ixo-cosmos/x/bonds/client/rest"
 )
 
 func main() {
@@ -133,4 +134,5 @@ func registerRoutes(rs *lcd.RestServer) {
 	client.RegisterRoutes(rs.CliCtx, rs.Mux)
 	ixoClient.RegisterQueryTxRoutes(rs.CliCtx, rs.Mux)
 	app.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
+	bondsRest.RegisterFeedRoutes(rs.CliCtx, rs.Mux)
 }
@@ -8,12 +8,23 @@ import (
 //noinspection GoUnusedConst
 const (
 	QueryBonds          = keeper.QueryBonds
+	QueryBondsDetailed  = keeper.QueryBondsDetailed
 	QueryBond           = keeper.QueryBond
 	QueryCurrentPrice   = keeper.QueryCurrentPrice
 	QueryCurrentReserve = keeper.QueryCurrentReserve
 	QueryCustomPrice    = keeper.QueryCustomPrice
 	QueryBuyPrice       = keeper.QueryBuyPrice
 	QuerySellReturn     = keeper.QuerySellReturn
+	QueryState          = keeper.QueryState
+	QueryPoolBalances   = keeper.QueryPoolBalances
+	QueryRestingOrders  = keeper.QueryRestingOrders
+	QueryPriceHistory   = keeper.QueryPriceHistory
+	QueryBondProposals  = keeper.QueryBondProposals
+	QueryBondProposal   = keeper.QueryBondProposal
+	QueryAccruedFees    = keeper.QueryAccruedFees
+	QuerySimulateBatch  = keeper.QuerySimulateBatch
+	QueryVesting        = keeper.QueryVesting
+	QueryOrders         = keeper.QueryOrders
 
 	DefaultCodeSpace = types.DefaultCodespace
 
@@ -24,7 +35,6 @@ const (
 	CodeBondAlreadyExists                    = types.CodeBondAlreadyExists
 	CodeBondDoesNotAllowSelling              = types.CodeBondDoesNotAllowSelling
 	CodeDidNotEditAnything                   = types.CodeDidNotEditAnything
-	CodeReserveAddrCannotBeFeeAddr           = types.CodeInvalidBond
 	CodeUnrecognizedFunctionType             = types.CodeUnrecognizedFunctionType
 	CodeInvalidFunctionParameter             = types.CodeInvalidFunctionParameter
 	CodeFunctionNotAvailableForFunctionType  = types.CodeFunctionNotAvailableForFunctionType
@@ -42,14 +52,84 @@ const (
 	CodeOrderQuantityLimitExceeded           = types.CodeOrderLimitExceeded
 	CodeSanityRateViolated                   = types.CodeSanityRateViolated
 	CodeFeeTooLarge                          = types.CodeFeeTooLarge
+	CodeUnrecognizedBondState                = types.CodeUnrecognizedBondState
+	CodeInvalidStateForAction                = types.CodeInvalidStateForAction
+	CodeInvalidStateTransition               = types.CodeInvalidStateTransition
+	CodeAddressNotWhitelisted                = types.CodeAddressNotWhitelisted
+	CodeOrderExpired                         = types.CodeOrderExpired
+	CodeMinReturnsNotMet                     = types.CodeMinReturnsNotMet
+	CodeBudgetTooSmall                       = types.CodeBudgetTooSmall
+	CodeCommitRevealSwaps                    = types.CodeCommitRevealSwaps
+	CodeSwapCommitNotFound                   = types.CodeSwapCommitNotFound
+	CodeSwapCommitInvalid                    = types.CodeSwapCommitInvalid
+	CodeProposalNotFound                     = types.CodeProposalNotFound
+	CodeProposalInvalid                      = types.CodeProposalInvalid
+	CodeNoVotingPower                        = types.CodeNoVotingPower
+	CodeProposalNotApplied                   = types.CodeProposalNotApplied
+	CodeInvalidFeeRecipients                 = types.CodeInvalidFeeRecipients
+	CodeNoAccruedFees                        = types.CodeNoAccruedFees
+	CodeInvalidVestingConfig                 = types.CodeInvalidVestingConfig
+	CodeNoVestedTokens                       = types.CodeNoVestedTokens
+	CodeInvalidTransferPolicy                = types.CodeInvalidTransferPolicy
+	CodeTransferNotAllowed                   = types.CodeTransferNotAllowed
+
+	PowerFunction       = types.PowerFunction
+	SigmoidFunction     = types.SigmoidFunction
+	ExponentialFunction = types.ExponentialFunction
+	LogarithmicFunction = types.LogarithmicFunction
+	AugmentedFunction   = types.AugmentedFunction
+	SwapperFunction     = types.SwapperFunction
+
+	WeightedSwapperFunction         = types.WeightedSwapperFunction
+	MinWeightedSwapperReserveTokens = types.MinWeightedSwapperReserveTokens
+	MaxWeightedSwapperReserveTokens = types.MaxWeightedSwapperReserveTokens
+
+	TRUE             = types.TRUE
+	FALSE            = types.FALSE
+	DoNotModifyField = types.DoNotModifyField
+
+	HatchState   = types.HatchState
+	OpenState    = types.OpenState
+	ClosedState  = types.ClosedState
+	PausedState  = types.PausedState
+	SettledState = types.SettledState
+
+	OpenTransferPolicy               = types.OpenTransferPolicy
+	AllowListTransferPolicy          = types.AllowListTransferPolicy
+	CredentialRequiredTransferPolicy = types.CredentialRequiredTransferPolicy
 
 	BondsMintBurnAccount       = types.BondsMintBurnAccount
 	BatchesIntermediaryAccount = types.BatchesIntermediaryAccount
+	BondsReserveAccount        = types.BondsReserveAccount
+	BondsFeesAccount           = types.BondsFeesAccount
+	BondsVestingAccount        = types.BondsVestingAccount
+	BondsFundingPoolAccount    = types.BondsFundingPoolAccount
 
 	ModuleName   = types.ModuleName
 	StoreKey     = types.StoreKey
 	QuerierRoute = types.QuerierRoute
 	RouterKey    = types.RouterKey
+
+	PriceHistoryLength = types.PriceHistoryLength
+
+	VotingProposalStatus      = types.VotingProposalStatus
+	PassedProposalStatus      = types.PassedProposalStatus
+	RejectedProposalStatus    = types.RejectedProposalStatus
+	FailedProposalStatus      = types.FailedProposalStatus
+	YesVoteOption             = types.YesVoteOption
+	NoVoteOption              = types.NoVoteOption
+	AbstainVoteOption         = types.AbstainVoteOption
+	MinProposalVotingPeriod   = types.MinProposalVotingPeriod
+	MaxActiveProposalsPerBond = types.MaxActiveProposalsPerBond
+
+	MaxFeeRecipients = types.MaxFeeRecipients
+
+	FulfilledOrderStatus = types.FulfilledOrderStatus
+	CancelledOrderStatus = types.CancelledOrderStatus
+	RestingOrderStatus   = types.RestingOrderStatus
+	PendingOrderStatus   = types.PendingOrderStatus
+
+	DefaultOrdersQueryLimit = types.DefaultOrdersQueryLimit
 )
 
 //noinspection GoUnusedGlobalVariable,GoNameStartsWithPackageName
@@ -62,39 +142,84 @@ var (
 	NewQuerier         = keeper.NewQuerier
 	RegisterCodec      = types.RegisterCodec
 
-	ErrArgumentCannotBeEmpty                = types.ErrArgumentCannotBeEmpty
-	ErrArgumentCannotBeNegative             = types.ErrArgumentCannotBeNegative
-	ErrFunctionParameterMissingOrNonInteger = types.ErrFunctionParameterMissingOrNonInteger
-	ErrArgumentMissingOrNonFloat            = types.ErrArgumentMissingOrNonFloat
-	ErrArgumentMissingOrNonInteger          = types.ErrArgumentMissingOrNonInteger
-	ErrArgumentMissingOrNonUInteger         = types.ErrArgumentMissingOrNonUInteger
-	ErrArgumentMissingOrNonBoolean          = types.ErrArgumentMissingOrNonBoolean
-	ErrIncorrectNumberOfReserveTokens       = types.ErrIncorrectNumberOfReserveTokens
-	ErrIncorrectNumberOfFunctionParameters  = types.ErrIncorrectNumberOfFunctionParameters
-	ErrBondDoesNotExist                     = types.ErrBondDoesNotExist
-	ErrBondAlreadyExists                    = types.ErrBondAlreadyExists
-	ErrBondDoesNotAllowSelling              = types.ErrBondDoesNotAllowSelling
-	ErrDidNotEditAnything                   = types.ErrDidNotEditAnything
-	ErrReserveAddrCannotBeFeeAddr           = types.ErrReserveAddrCannotBeFeeAddr
-	ErrUnrecognizedFunctionType             = types.ErrUnrecognizedFunctionType
-	ErrInvalidFunctionParameter             = types.ErrInvalidFunctionParameter
-	ErrFunctionNotAvailableForFunctionType  = types.ErrFunctionNotAvailableForFunctionType
-	ErrFunctionRequiresNonZeroCurrentSupply = types.ErrFunctionRequiresNonZeroCurrentSupply
-	ErrTokenIsNotAValidReserveToken         = types.ErrTokenIsNotAValidReserveToken
-	ErrBondTokenCannotAlsoBeReserveToken    = types.ErrBondTokenCannotAlsoBeReserveToken
-	ErrBondTokenCannotBeStakingToken        = types.ErrBondTokenCannotBeStakingToken
-	ErrFromAndToCannotBeTheSameToken        = types.ErrFromAndToCannotBeTheSameToken
-	ErrReserveDenomsMismatch                = types.ErrReserveDenomsMismatch
-	ErrDuplicateReserveToken                = types.ErrDuplicateReserveToken
-	ErrInvalidCoinDenomination              = types.ErrInvalidCoinDenomination
-	ErrCannotMintMoreThanMaxSupply          = types.ErrCannotMintMoreThanMaxSupply
-	ErrCannotBurnMoreThanSupply             = types.ErrCannotBurnMoreThanSupply
-	ErrMaxPriceExceeded                     = types.ErrMaxPriceExceeded
-	ErrSwapAmountTooSmallToGiveAnyReturn    = types.ErrSwapAmountTooSmallToGiveAnyReturn
-	ErrSwapAmountCausesReserveDepletion     = types.ErrSwapAmountCausesReserveDepletion
-	ErrOrderQuantityLimitExceeded           = types.ErrOrderQuantityLimitExceeded
-	ErrValuesViolateSanityRate              = types.ErrValuesViolateSanityRate
-	ErrFeesCannotBeOrExceed100Percent       = types.ErrFeesCannotBeOrExceed100Percent
+	ErrArgumentCannotBeEmpty                    = types.ErrArgumentCannotBeEmpty
+	ErrArgumentCannotBeNegative                 = types.ErrArgumentCannotBeNegative
+	ErrFunctionParameterMissingOrNonInteger     = types.ErrFunctionParameterMissingOrNonInteger
+	ErrFunctionParameterMissingOrNonFloat       = types.ErrFunctionParameterMissingOrNonFloat
+	ErrArgumentMissingOrNonFloat                = types.ErrArgumentMissingOrNonFloat
+	ErrArgumentMissingOrNonInteger              = types.ErrArgumentMissingOrNonInteger
+	ErrArgumentMissingOrNonUInteger             = types.ErrArgumentMissingOrNonUInteger
+	ErrArgumentMissingOrNonBoolean              = types.ErrArgumentMissingOrNonBoolean
+	ErrIncorrectNumberOfReserveTokens           = types.ErrIncorrectNumberOfReserveTokens
+	ErrNumberOfReserveTokensOutOfRange          = types.ErrNumberOfReserveTokensOutOfRange
+	ErrWeightsDoNotMatchReserveTokens           = types.ErrWeightsDoNotMatchReserveTokens
+	ErrIncorrectNumberOfFunctionParameters      = types.ErrIncorrectNumberOfFunctionParameters
+	ErrBondDoesNotExist                         = types.ErrBondDoesNotExist
+	ErrBondAlreadyExists                        = types.ErrBondAlreadyExists
+	ErrBondDoesNotAllowSelling                  = types.ErrBondDoesNotAllowSelling
+	ErrDidNotEditAnything                       = types.ErrDidNotEditAnything
+	ErrUnrecognizedFunctionType                 = types.ErrUnrecognizedFunctionType
+	ErrInvalidFunctionParameter                 = types.ErrInvalidFunctionParameter
+	ErrFunctionParameterTooLargeForMaxSupply    = types.ErrFunctionParameterTooLargeForMaxSupply
+	ErrFunctionCannotBeCalculatedAtMaxSupply    = types.ErrFunctionCannotBeCalculatedAtMaxSupply
+	ErrFunctionNotAvailableForFunctionType      = types.ErrFunctionNotAvailableForFunctionType
+	ErrFunctionRequiresNonZeroCurrentSupply     = types.ErrFunctionRequiresNonZeroCurrentSupply
+	ErrTokenIsNotAValidReserveToken             = types.ErrTokenIsNotAValidReserveToken
+	ErrBondTokenCannotAlsoBeReserveToken        = types.ErrBondTokenCannotAlsoBeReserveToken
+	ErrBondTokenCannotBeStakingToken            = types.ErrBondTokenCannotBeStakingToken
+	ErrFromAndToCannotBeTheSameToken            = types.ErrFromAndToCannotBeTheSameToken
+	ErrReserveDenomsMismatch                    = types.ErrReserveDenomsMismatch
+	ErrDuplicateReserveToken                    = types.ErrDuplicateReserveToken
+	ErrInvalidCoinDenomination                  = types.ErrInvalidCoinDenomination
+	ErrCannotMintMoreThanMaxSupply              = types.ErrCannotMintMoreThanMaxSupply
+	ErrCannotMintMoreThanHatchSupply            = types.ErrCannotMintMoreThanHatchSupply
+	ErrCannotBurnMoreThanSupply                 = types.ErrCannotBurnMoreThanSupply
+	ErrMaxPriceExceeded                         = types.ErrMaxPriceExceeded
+	ErrSwapAmountTooSmallToGiveAnyReturn        = types.ErrSwapAmountTooSmallToGiveAnyReturn
+	ErrSwapAmountCausesReserveDepletion         = types.ErrSwapAmountCausesReserveDepletion
+	ErrOrderQuantityLimitExceeded               = types.ErrOrderQuantityLimitExceeded
+	ErrValuesViolateSanityRate                  = types.ErrValuesViolateSanityRate
+	ErrFeesCannotBeOrExceed100Percent           = types.ErrFeesCannotBeOrExceed100Percent
+	ErrUnrecognizedBondState                    = types.ErrUnrecognizedBondState
+	ErrInvalidStateForAction                    = types.ErrInvalidStateForAction
+	ErrInvalidStateTransition                   = types.ErrInvalidStateTransition
+	ErrOrderCancelledByBondClosure              = types.ErrOrderCancelledByBondClosure
+	ErrOrderCancelledByZeroHeightExport         = types.ErrOrderCancelledByZeroHeightExport
+	ErrCannotChangeStateWithPendingBuys         = types.ErrCannotChangeStateWithPendingBuys
+	ErrAddressNotWhitelisted                    = types.ErrAddressNotWhitelisted
+	ErrOrderAlreadyExpired                      = types.ErrOrderAlreadyExpired
+	ErrOrderExpired                             = types.ErrOrderExpired
+	ErrMinReturnsNotMet                         = types.ErrMinReturnsNotMet
+	ErrBudgetTooSmallToBuyAnyTokens             = types.ErrBudgetTooSmallToBuyAnyTokens
+	ErrSwapsRequireCommitReveal                 = types.ErrSwapsRequireCommitReveal
+	ErrCommitRevealSwapsNotEnabled              = types.ErrCommitRevealSwapsNotEnabled
+	ErrSwapCommitNotFound                       = types.ErrSwapCommitNotFound
+	ErrInvalidSwapCommitHash                    = types.ErrInvalidSwapCommitHash
+	ErrSwapCommitAlreadyExists                  = types.ErrSwapCommitAlreadyExists
+	ErrSwapExceedsCommitDeposit                 = types.ErrSwapExceedsCommitDeposit
+	ErrMaxSupplyBelowCurrentSupply              = types.ErrMaxSupplyBelowCurrentSupply
+	ErrBondProposalNotFound                     = types.ErrBondProposalNotFound
+	ErrBondProposalNotVoting                    = types.ErrBondProposalNotVoting
+	ErrInvalidVoteOption                        = types.ErrInvalidVoteOption
+	ErrVotingPeriodTooShort                     = types.ErrVotingPeriodTooShort
+	ErrNoVotingPower                            = types.ErrNoVotingPower
+	ErrProposerVotingPowerTooLow                = types.ErrProposerVotingPowerTooLow
+	ErrTooManyActiveProposals                   = types.ErrTooManyActiveProposals
+	ErrReserveDoesNotCoverChangedCurve          = types.ErrReserveDoesNotCoverChangedCurve
+	ErrBondParamChangesCannotBeApplied          = types.ErrBondParamChangesCannotBeApplied
+	ErrTooManyFeeRecipients                     = types.ErrTooManyFeeRecipients
+	ErrDuplicateFeeRecipient                    = types.ErrDuplicateFeeRecipient
+	ErrFeeRecipientPercentagesDoNotAddUpTo100   = types.ErrFeeRecipientPercentagesDoNotAddUpTo100
+	ErrNoAccruedFees                            = types.ErrNoAccruedFees
+	ErrFundingPoolEmpty                         = types.ErrFundingPoolEmpty
+	ErrNotFundingPoolRecipient                  = types.ErrNotFundingPoolRecipient
+	ErrSharedReserveCannotBeSplit               = types.ErrSharedReserveCannotBeSplit
+	ErrVestingCliffExceedsDuration              = types.ErrVestingCliffExceedsDuration
+	ErrVestingSupplyDenomDoesNotMatchTokenDenom = types.ErrVestingSupplyDenomDoesNotMatchTokenDenom
+	ErrVestingSupplyExceedsMaxSupply            = types.ErrVestingSupplyExceedsMaxSupply
+	ErrNoVestedTokens                           = types.ErrNoVestedTokens
+	ErrUnrecognizedTransferPolicy               = types.ErrUnrecognizedTransferPolicy
+	ErrTransferNotAllowed                       = types.ErrTransferNotAllowed
 
 	NewGenesisState     = types.NewGenesisState
 	DefaultGenesisState = types.DefaultGenesisState
@@ -102,30 +227,82 @@ var (
 
 	SquareRootDec       = types.SquareRootDec
 	SquareRootInt       = types.SquareRootInt
+	ExpDec              = types.ExpDec
+	LnDec               = types.LnDec
+	PowerDec            = types.PowerDec
 	RoundReservePrice   = types.RoundReservePrice
 	RoundReserveReturn  = types.RoundReserveReturn
 	RoundFee            = types.RoundFee
 	RoundReservePrices  = types.RoundReservePrices
 	RoundReserveReturns = types.RoundReserveReturns
 
-	NewFunctionParam = types.NewFunctionParam
-	NewBond          = types.NewBond
-	NewBatch         = types.NewBatch
-	NewBaseOrder     = types.NewBaseOrder
-	NewBuyOrder      = types.NewBuyOrder
-	NewSellOrder     = types.NewSellOrder
-	NewSwapOrder     = types.NewSwapOrder
-	NewMsgCreateBond = types.NewMsgCreateBond
-	NewMsgEditBond   = types.NewMsgEditBond
-	NewMsgBuy        = types.NewMsgBuy
-	NewMsgSell       = types.NewMsgSell
-	NewMsgSwap       = types.NewMsgSwap
+	NewFunctionParam            = types.NewFunctionParam
+	NewBond                     = types.NewBond
+	NewBatch                    = types.NewBatch
+	NewBondReserve              = types.NewBondReserve
+	NewBondFundingPool          = types.NewBondFundingPool
+	NewProposalVote             = types.NewProposalVote
+	NewBondPriceHistory         = types.NewBondPriceHistory
+	NewBaseOrder                = types.NewBaseOrder
+	NewBuyOrder                 = types.NewBuyOrder
+	NewSellOrder                = types.NewSellOrder
+	NewSwapOrder                = types.NewSwapOrder
+	NewSwapCommit               = types.NewSwapCommit
+	NewPriceHistoryEntry        = types.NewPriceHistoryEntry
+	NewBondParamChanges         = types.NewBondParamChanges
+	NewBondProposal             = types.NewBondProposal
+	NewBondProposalVote         = types.NewBondProposalVote
+	NewTallyResult              = types.NewTallyResult
+	NewFeeRecipient             = types.NewFeeRecipient
+	NewBondFeeAccrual           = types.NewBondFeeAccrual
+	NewVestingConfig            = types.NewVestingConfig
+	NoVestingConfig             = types.NoVestingConfig
+	NewVestingSchedule          = types.NewVestingSchedule
+	NewAccountVestingSchedules  = types.NewAccountVestingSchedules
+	GetSwapCommitHash           = types.GetSwapCommitHash
+	NewMsgCreateBond            = types.NewMsgCreateBond
+	NewMsgEditBond              = types.NewMsgEditBond
+	NewMsgBuy                   = types.NewMsgBuy
+	NewMsgBuyWithBudget         = types.NewMsgBuyWithBudget
+	NewMsgSell                  = types.NewMsgSell
+	NewMsgSwap                  = types.NewMsgSwap
+	NewMsgCommitSwap            = types.NewMsgCommitSwap
+	NewMsgRevealSwap            = types.NewMsgRevealSwap
+	NewMsgUpdateBondState       = types.NewMsgUpdateBondState
+	NewMsgPauseBond             = types.NewMsgPauseBond
+	NewMsgResumeBond            = types.NewMsgResumeBond
+	NewMsgCloseBond             = types.NewMsgCloseBond
+	NewMsgSubmitBondProposal    = types.NewMsgSubmitBondProposal
+	NewMsgVoteBondProposal      = types.NewMsgVoteBondProposal
+	NewMsgWithdrawBondFees      = types.NewMsgWithdrawBondFees
+	NewMsgWithdrawFundingPool   = types.NewMsgWithdrawFundingPool
+	NewMsgClaimVested           = types.NewMsgClaimVested
+	NewQuerySimulateBatchParams = types.NewQuerySimulateBatchParams
+	NewOrderRecord              = types.NewOrderRecord
+	NewQueryOrdersParams        = types.NewQueryOrdersParams
 
 	// variable aliases
 	ModuleCdc            = types.ModuleCdc
 	BondsKeyPrefix       = types.BondsKeyPrefix
 	BatchesKeyPrefix     = types.BatchesKeyPrefix
 	LastBatchesKeyPrefix = types.LastBatchesKeyPrefix
+
+	ReserveBalancesKeyPrefix     = types.ReserveBalancesKeyPrefix
+	PriceHistoryKeyPrefix        = types.PriceHistoryKeyPrefix
+	PriceHistoryCounterKeyPrefix = types.PriceHistoryCounterKeyPrefix
+
+	ProposalsKeyPrefix        = types.ProposalsKeyPrefix
+	ProposalVotesKeyPrefix    = types.ProposalVotesKeyPrefix
+	VotingPowersKeyPrefix     = types.VotingPowersKeyPrefix
+	NextProposalIdKey         = types.NextProposalIdKey
+	ActiveProposalsKeyPrefix  = types.ActiveProposalsKeyPrefix
+	ProposalQuorum            = types.ProposalQuorum
+	ProposalThreshold         = types.ProposalThreshold
+	MinProposerVotingPower    = types.MinProposerVotingPower
+	AccruedFeesKeyPrefix      = types.AccruedFeesKeyPrefix
+	VestingSchedulesKeyPrefix = types.VestingSchedulesKeyPrefix
+
+	MaxExpDecExponent = types.MaxExpDecExponent
 )
 
 type (
@@ -133,23 +310,65 @@ type (
 	CodeType     = types.CodeType
 	GenesisState = types.GenesisState
 
-	MsgCreateBond = types.MsgCreateBond
-	MsgEditBond   = types.MsgEditBond
-	MsgBuy        = types.MsgBuy
-	MsgSell       = types.MsgSell
-	MsgSwap       = types.MsgSwap
-
-	FunctionParam  = types.FunctionParam
-	FunctionParams = types.FunctionParams
-	Bond           = types.Bond
-	Batch          = types.Batch
-	Order          = types.BaseOrder
-	BuyOrder       = types.BuyOrder
-	SellOrder      = types.SellOrder
-	SwapOrder      = types.SwapOrder
-
-	QueryResBonds      = types.QueryBonds
-	QueryResBuyPrice   = types.QueryBuyPrice
-	QueryResSellReturn = types.QuerySellReturn
-	QueryResSwapReturn = types.QuerySwapReturn
+	MsgCreateBond          = types.MsgCreateBond
+	MsgEditBond            = types.MsgEditBond
+	MsgBuy                 = types.MsgBuy
+	MsgBuyWithBudget       = types.MsgBuyWithBudget
+	MsgSell                = types.MsgSell
+	MsgSwap                = types.MsgSwap
+	MsgCommitSwap          = types.MsgCommitSwap
+	MsgRevealSwap          = types.MsgRevealSwap
+	MsgUpdateBondState     = types.MsgUpdateBondState
+	MsgPauseBond           = types.MsgPauseBond
+	MsgResumeBond          = types.MsgResumeBond
+	MsgCloseBond           = types.MsgCloseBond
+	MsgSubmitBondProposal  = types.MsgSubmitBondProposal
+	MsgVoteBondProposal    = types.MsgVoteBondProposal
+	MsgWithdrawBondFees    = types.MsgWithdrawBondFees
+	MsgWithdrawFundingPool = types.MsgWithdrawFundingPool
+	MsgClaimVested         = types.MsgClaimVested
+
+	FunctionParam    = types.FunctionParam
+	FunctionParams   = types.FunctionParams
+	Bond             = types.Bond
+	Batch            = types.Batch
+	BondReserve      = types.BondReserve
+	BondFundingPool  = types.BondFundingPool
+	ProposalVote     = types.ProposalVote
+	BondPriceHistory = types.BondPriceHistory
+	Order            = types.BaseOrder
+	BuyOrder         = types.BuyOrder
+	SellOrder        = types.SellOrder
+	SwapOrder        = types.SwapOrder
+	SwapCommit       = types.SwapCommit
+
+	PriceHistoryEntry       = types.PriceHistoryEntry
+	BondParamChanges        = types.BondParamChanges
+	BondProposal            = types.BondProposal
+	BondProposalVote        = types.BondProposalVote
+	TallyResult             = types.TallyResult
+	FeeRecipient            = types.FeeRecipient
+	FeeRecipients           = types.FeeRecipients
+	BondFeeAccrual          = types.BondFeeAccrual
+	VestingConfig           = types.VestingConfig
+	VestingSchedule         = types.VestingSchedule
+	AccountVestingSchedules = types.AccountVestingSchedules
+
+	QueryResBonds            = types.QueryBonds
+	QueryResBuyPrice         = types.QueryBuyPrice
+	QueryResSellReturn       = types.QuerySellReturn
+	QueryResSwapReturn       = types.QuerySwapReturn
+	QueryResBondState        = types.QueryBondState
+	QueryResPoolBalances     = types.QueryPoolBalances
+	QueryResRestingOrders    = types.QueryRestingOrders
+	QueryResBondsDetailed    = types.QueryBondsDetailed
+	QueryResPriceHistory     = types.QueryPriceHistory
+	QueryResBondProposals    = types.QueryBondProposals
+	QueryResSimulateBatch    = types.QuerySimulateBatch
+	QueryResVesting          = types.QueryVesting
+	QuerySimulateBatchParams = types.QuerySimulateBatchParams
+	SimulatedOrder           = types.SimulatedOrder
+	OrderRecord              = types.OrderRecord
+	QueryResOrders           = types.QueryOrders
+	QueryOrdersParams        = types.QueryOrdersParams
 )
@@ -0,0 +1,39 @@
+package bonds
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// NewTransferPolicyDecorator wraps an ante handler so that bank sends of bond
+// tokens to addresses that are not allowed to hold them under the bond's
+// transfer policy are rejected. The wrapped ante handler is run first, so
+// that fees are still deducted for rejected transactions.
+func NewTransferPolicyDecorator(keeper Keeper, next sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx, res, abort = next(ctx, tx, simulate)
+		if abort {
+			return newCtx, res, abort
+		}
+
+		for _, msg := range tx.GetMsgs() {
+			var err sdk.Error
+			switch msg := msg.(type) {
+			case bank.MsgSend:
+				err = keeper.CheckTransfersAllowed(newCtx, msg.ToAddress, msg.Amount)
+			case bank.MsgMultiSend:
+				for _, out := range msg.Outputs {
+					err = keeper.CheckTransfersAllowed(newCtx, out.Address, out.Coins)
+					if err != nil {
+						break
+					}
+				}
+			}
+			if err != nil {
+				return newCtx, err.Result(), true
+			}
+		}
+
+		return newCtx, res, false
+	}
+}
@@ -0,0 +1,95 @@
+package bonds
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/keeper"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func TestTransferPolicyDecorator(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	sender := types.ValidCreatorAddress
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+
+	nextCalled := false
+	next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		nextCalled = true
+		return ctx, sdk.Result{}, false
+	}
+	anteHandler := NewTransferPolicyDecorator(k, next)
+
+	setBond := func(token, transferPolicy string, transferAllowList []sdk.AccAddress) {
+		msg := types.ValidCreateBondMsg
+		bond := types.NewBond(token, msg.Name, msg.Description, msg.Creator,
+			msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
+			msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
+			msg.FeeRecipients, sdk.NewInt64Coin(token, 1000000), msg.OrderQuantityLimits,
+			msg.SanityRate, msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
+			msg.BatchBlocks, msg.HatchWhitelist, msg.CommitRevealSwaps,
+			types.NoVestingConfig(token), transferPolicy, transferAllowList)
+		k.SetBond(ctx, token, bond)
+	}
+	send := func(to sdk.AccAddress, token string) sdk.Result {
+		nextCalled = false
+		coins := sdk.NewCoins(sdk.NewInt64Coin(token, 10))
+		msg := bank.MsgSend{FromAddress: sender, ToAddress: to, Amount: coins}
+		tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, nil, "")
+		_, res, abort := anteHandler(ctx, tx, false)
+		require.True(t, nextCalled)
+		require.Equal(t, !res.IsOK(), abort)
+		return res
+	}
+
+	// Tokens that are not bond tokens and tokens of open bonds can be sent to
+	// any address
+	res := send(buyer, types.ValidReserveToken)
+	require.True(t, res.IsOK(), res.Log)
+	setBond("opn", types.OpenTransferPolicy, nil)
+	res = send(buyer, "opn")
+	require.True(t, res.IsOK(), res.Log)
+
+	// Tokens of allow_list bonds can only be sent to the allow-list
+	setBond("lst", types.AllowListTransferPolicy, []sdk.AccAddress{other})
+	res = send(buyer, "lst")
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+	res = send(other, "lst")
+	require.True(t, res.IsOK(), res.Log)
+
+	// Tokens of credential_required bonds can only be sent to addresses that
+	// are linked to a DID with a KYC-validated credential
+	setBond("kyc", types.CredentialRequiredTransferPolicy, nil)
+	res = send(buyer, "kyc")
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+	addLinkedDid(t, ctx, k, other, "did:ixo:other", false)
+	res = send(other, "kyc")
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+	addLinkedDid(t, ctx, k, buyer, "did:ixo:buyer", true)
+	res = send(buyer, "kyc")
+	require.True(t, res.IsOK(), res.Log)
+
+	// Every output of a multi-send has to be allowed to hold the tokens
+	coins := sdk.NewCoins(sdk.NewInt64Coin("kyc", 10))
+	multiSend := func(outputs ...sdk.AccAddress) sdk.Result {
+		var outs []bank.Output
+		for _, out := range outputs {
+			outs = append(outs, bank.NewOutput(out, coins))
+		}
+		inputs := []bank.Input{bank.NewInput(sender, sdk.NewCoins(
+			sdk.NewInt64Coin("kyc", int64(10*len(outs)))))}
+		msg := bank.MsgMultiSend{Inputs: inputs, Outputs: outs}
+		tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, nil, "")
+		_, res, _ := anteHandler(ctx, tx, false)
+		return res
+	}
+	res = multiSend(buyer)
+	require.True(t, res.IsOK(), res.Log)
+	res = multiSend(buyer, other)
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+}
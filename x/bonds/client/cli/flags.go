@@ -12,10 +12,10 @@ const (
 	FlagFunctionType           = "function-type"
 	FlagFunctionParameters     = "function-parameters"
 	FlagReserveTokens          = "reserve-tokens"
-	FlagReserveAddress         = "reserve-address"
 	FlagTxFeePercentage        = "tx-fee-percentage"
 	FlagExitFeePercentage      = "exit-fee-percentage"
 	FlagFeeAddress             = "fee-address"
+	FlagFeeRecipients          = "fee-recipients"
 	FlagMaxSupply              = "max-supply"
 	FlagOrderQuantityLimits    = "order-quantity-limits"
 	FlagSanityRate             = "sanity-rate"
@@ -23,12 +23,31 @@ const (
 	FlagAllowSells             = "allow-sells"
 	FlagSigners                = "signers"
 	FlagBatchBlocks            = "batch-blocks"
+	FlagHatchWhitelist         = "hatch-whitelist"
+	FlagCommitRevealSwaps      = "commit-reveal-swaps"
+	FlagVestingCliffBlocks     = "vesting-cliff-blocks"
+	FlagVestingDurationBlocks  = "vesting-duration-blocks"
+	FlagVestingUntilSupply     = "vesting-applies-until-supply"
+	FlagTransferPolicy         = "transfer-policy"
+	FlagTransferAllowList      = "transfer-allow-list"
+	FlagGoodTillBlock          = "good-till-block"
+	FlagMinReturns             = "min-returns"
+	FlagDetailed               = "detailed"
+	FlagFromHeight             = "from"
+	FlagToHeight               = "to"
+	FlagVotingPeriod           = "voting-period"
+	FlagOrders                 = "orders"
+	FlagPage                   = "page"
+	FlagLimit                  = "limit"
 )
 
 var (
 	fsBondGeneral = flag.NewFlagSet("", flag.ContinueOnError)
 	fsBondCreate  = flag.NewFlagSet("", flag.ContinueOnError)
 	fsBondEdit    = flag.NewFlagSet("", flag.ContinueOnError)
+	fsBondOrder   = flag.NewFlagSet("", flag.ContinueOnError)
+	fsMinReturns  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsProposal    = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -41,20 +60,39 @@ func init() {
 	fsBondCreate.String(FlagFunctionType, "", "The type of function that the bond will be")
 	fsBondCreate.String(FlagFunctionParameters, "", "The parameters that will define the function")
 	fsBondCreate.String(FlagReserveTokens, "", "The token(s) that will serve as the reserve token(s)")
-	fsBondCreate.String(FlagReserveAddress, "", "The address that will serve as the reserve")
 	fsBondCreate.String(FlagTxFeePercentage, "", "The percentage fee charged on buys and sells")
 	fsBondCreate.String(FlagExitFeePercentage, "", "The percentage fee charged on sells")
 	fsBondCreate.String(FlagFeeAddress, "", "The address that will hold any charged fees")
+	fsBondCreate.String(FlagFeeRecipients, "", "The addresses and percentages that the charged fees are split between (optional)")
 	fsBondCreate.String(FlagMaxSupply, "", "The maximum supply that can be achieved")
 	fsBondCreate.String(FlagOrderQuantityLimits, "", "The max number of tokens bought/sold/swapped per order")
 	fsBondCreate.String(FlagSanityRate, "", "For swappers, this is the typical t1 per t2 rate")
 	fsBondCreate.String(FlagSanityMarginPercentage, "", "For swappers, this is the acceptable deviation from the sanity rate")
 	fsBondCreate.String(FlagAllowSells, "", "Whether or not sells will be allowed")
 	fsBondCreate.String(FlagBatchBlocks, "", "The duration in terms of blocks of each orders batch")
+	fsBondCreate.String(FlagHatchWhitelist, "", "For augmented functions, the addresses allowed to buy during the hatch")
+	fsBondCreate.String(FlagCommitRevealSwaps, types.FALSE, "For swappers, whether swaps have to be committed and then revealed")
+	fsBondCreate.String(FlagVestingCliffBlocks, "", "The number of blocks before any bought tokens vest (optional)")
+	fsBondCreate.String(FlagVestingDurationBlocks, "", "The number of blocks over which bought tokens vest linearly (optional)")
+	fsBondCreate.String(FlagVestingUntilSupply, "", "The supply from which bought tokens stop vesting (optional)")
+	fsBondCreate.String(FlagTransferPolicy, types.OpenTransferPolicy, "Who can hold the bond's tokens (open, allow_list or credential_required)")
+	fsBondCreate.String(FlagTransferAllowList, "", "For the allow_list transfer policy, the addresses allowed to hold the bond's tokens")
 
 	fsBondEdit.String(FlagName, types.DoNotModifyField, "The bond's name")
 	fsBondEdit.String(FlagDescription, types.DoNotModifyField, "The bond's description")
 	fsBondEdit.String(FlagOrderQuantityLimits, types.DoNotModifyField, "The max number of tokens bought/sold/swapped per order")
 	fsBondEdit.String(FlagSanityRate, types.DoNotModifyField, "For swappers, this is the typical t1 per t2 rate")
 	fsBondEdit.String(FlagSanityMarginPercentage, types.DoNotModifyField, "For swappers, this is the acceptable deviation from the sanity rate")
+
+	fsProposal.String(FlagDescription, "", "The proposal's description")
+	fsProposal.String(FlagFunctionParameters, types.DoNotModifyField, "The parameters that will define the function")
+	fsProposal.String(FlagTxFeePercentage, types.DoNotModifyField, "The percentage fee charged on buys and sells")
+	fsProposal.String(FlagExitFeePercentage, types.DoNotModifyField, "The percentage fee charged on sells")
+	fsProposal.String(FlagFeeAddress, types.DoNotModifyField, "The address that will hold any charged fees")
+	fsProposal.String(FlagMaxSupply, types.DoNotModifyField, "The maximum supply that can be achieved")
+	fsProposal.String(FlagVotingPeriod, "", "The duration in terms of blocks of the voting period")
+
+	fsBondOrder.String(FlagGoodTillBlock, "", "The block until which an unfulfilled order rests (optional)")
+
+	fsMinReturns.String(FlagMinReturns, "", "The minimum returns in reserve tokens below which the order is cancelled (optional)")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"io/ioutil"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -31,19 +32,48 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdBuyPrice(storeKey, cdc),
 		GetCmdSellReturn(storeKey, cdc),
 		GetCmdSwapReturn(storeKey, cdc),
+		GetCmdState(storeKey, cdc),
+		GetCmdPoolBalances(storeKey, cdc),
+		GetCmdRestingOrders(storeKey, cdc),
+		GetCmdPriceHistory(storeKey, cdc),
+		GetCmdBondProposals(storeKey, cdc),
+		GetCmdBondProposal(storeKey, cdc),
+		GetCmdAccruedFees(storeKey, cdc),
+		GetCmdSimulateBatch(storeKey, cdc),
+		GetCmdVesting(storeKey, cdc),
+		GetCmdOrders(storeKey, cdc),
 	)...)
 
 	return bondsQueryCmd
 }
 
 func GetCmdBonds(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "bonds-list",
 		Short: "List of all bonds",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
+			detailed, err := cmd.Flags().GetBool(FlagDetailed)
+			if err != nil {
+				return err
+			}
+
+			if detailed {
+				res, _, err := cliCtx.QueryWithData(
+					fmt.Sprintf("custom/%s/bonds_detailed",
+						queryRoute), nil)
+				if err != nil {
+					fmt.Printf("%s", err.Error())
+					return nil
+				}
+
+				var out types.QueryBondsDetailed
+				cdc.MustUnmarshalJSON(res, &out)
+				return cliCtx.PrintOutput(out)
+			}
+
 			res, _, err := cliCtx.QueryWithData(
 				fmt.Sprintf("custom/%s/bonds",
 					queryRoute), nil)
@@ -57,6 +87,10 @@ func GetCmdBonds(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			return cliCtx.PrintOutput(out)
 		},
 	}
+
+	cmd.Flags().Bool(FlagDetailed, false, "Include the state and current supply of each bond")
+
+	return cmd
 }
 
 func GetCmdBond(queryRoute string, cdc *codec.Codec) *cobra.Command {
@@ -399,3 +433,431 @@ func GetCmdSwapReturn(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func GetCmdState(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "state [bond-token]",
+		Example: "state abc",
+		Short:   "Query the bond's current state (phase) and hatch supply",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/state/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBondState
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdPoolBalances(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "pool-balances [bond-token]",
+		Example: "pool-balances abc",
+		Short:   "Query balances of the reserve and funding pool (fee address)",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/pool_balances/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryPoolBalances
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdRestingOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "resting-orders [address]",
+		Example: "resting-orders ixo1...",
+		Short:   "Query the address' good-till-block orders resting across all bonds",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			address := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/resting_orders/%s",
+					queryRoute, address), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryRestingOrders
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdPriceHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "price-history [bond-token]",
+		Example: "price-history abc --from 100 --to 200",
+		Short:   "Query the prices, volumes, supply and reserve recorded for a bond's recent batches",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			fromHeight, err := cmd.Flags().GetInt64(FlagFromHeight)
+			if err != nil {
+				return err
+			}
+
+			toHeight, err := cmd.Flags().GetInt64(FlagToHeight)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/price_history/%s/%d/%d",
+					queryRoute, bondToken, fromHeight, toHeight), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryPriceHistory
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagFromHeight, 0, "The height from which to include entries (inclusive)")
+	cmd.Flags().Int64(FlagToHeight, 0, "The height up to which to include entries (inclusive, 0 for latest)")
+
+	return cmd
+}
+
+func GetCmdBondProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "bond-proposals [bond-token]",
+		Example: "bond-proposals abc",
+		Short:   "Query the parameter change proposals submitted for a bond",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_proposals/%s",
+					queryRoute, bondToken), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryBondProposals
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdBondProposal(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "bond-proposal [proposal-id]",
+		Example: "bond-proposal 1",
+		Short:   "Query a bond parameter change proposal and its tally",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			proposalId := args[0]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/bond_proposal/%s",
+					queryRoute, proposalId), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.BondProposal
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdAccruedFees(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "accrued-fees [bond-token] [address]",
+		Example: "accrued-fees abc ixo1...",
+		Short:   "Query the bond fees that have accrued to a fee recipient",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			address := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/accrued_fees/%s/%s",
+					queryRoute, bondToken, address), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out sdk.Coins
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdSimulateBatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "simulate-batch [bond-token]",
+		Example: "" +
+			"simulate-batch abc\n" +
+			"simulate-batch abc --orders=orders.json",
+		Short: "Simulate the outcome of a bond's current batch",
+		Long: "Simulate the outcome of a bond's current batch, optionally with extra\n" +
+			"hypothetical orders, without changing any state. The orders file holds\n" +
+			"the buys, sells and swaps to add to the batch, for example:\n\n" +
+			"{\n" +
+			"  \"buys\": [{\"amount\": {\"denom\": \"abc\", \"amount\": \"10\"},\n" +
+			"            \"max_prices\": [{\"denom\": \"res\", \"amount\": \"1000\"}]}],\n" +
+			"  \"sells\": [{\"amount\": {\"denom\": \"abc\", \"amount\": \"5\"}}]\n" +
+			"}",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			ordersFile, err := cmd.Flags().GetString(FlagOrders)
+			if err != nil {
+				return err
+			}
+
+			// Parse hypothetical orders (if any)
+			var params types.QuerySimulateBatchParams
+			if ordersFile != "" {
+				contents, err := ioutil.ReadFile(ordersFile)
+				if err != nil {
+					return err
+				}
+				err = cdc.UnmarshalJSON(contents, &params)
+				if err != nil {
+					return err
+				}
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/simulate_batch/%s",
+					queryRoute, bondToken), bz)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QuerySimulateBatch
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagOrders, "", "JSON file with the hypothetical orders to add to the batch (optional)")
+	return cmd
+}
+
+func GetCmdVesting(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:     "vesting [bond-token] [address]",
+		Example: "vesting abc ixo1...",
+		Short:   "Query an address's vesting schedules and claimable vested tokens",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bondToken := args[0]
+			address := args[1]
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/vesting/%s/%s",
+					queryRoute, bondToken, address), nil)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryVesting
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetCmdOrders(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "orders [address]",
+		Example: "" +
+			"orders ixo1...\n" +
+			"orders ixo1... --token=abc --page=2 --limit=10",
+		Short: "Query the history of an address's orders and how they were filled",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			address := args[0]
+
+			token, err := cmd.Flags().GetString(FlagToken)
+			if err != nil {
+				return err
+			}
+			page, err := cmd.Flags().GetInt(FlagPage)
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetInt(FlagLimit)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cdc.MarshalJSON(types.NewQueryOrdersParams(token, page, limit))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(
+				fmt.Sprintf("custom/%s/orders/%s",
+					queryRoute, address), bz)
+			if err != nil {
+				fmt.Printf("%s", err.Error())
+				return nil
+			}
+
+			var out types.QueryOrders
+			err = cdc.UnmarshalJSON(res, &out)
+			if err != nil {
+				return err
+			}
+
+			output, err := cdc.MarshalJSONIndent(out, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagToken, "", "Only include orders for this bond token")
+	cmd.Flags().Int(FlagPage, 1, "The page of orders to query")
+	cmd.Flags().Int(FlagLimit, types.DefaultOrdersQueryLimit, "The number of orders per page")
+
+	return cmd
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"strconv"
 )
 
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
@@ -27,8 +28,20 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdCreateBond(cdc),
 		GetCmdEditBond(cdc),
 		GetCmdBuy(cdc),
+		GetCmdBuyWithBudget(cdc),
 		GetCmdSell(cdc),
 		GetCmdSwap(cdc),
+		GetCmdCommitSwap(cdc),
+		GetCmdRevealSwap(cdc),
+		GetCmdUpdateBondState(cdc),
+		GetCmdPauseBond(cdc),
+		GetCmdResumeBond(cdc),
+		GetCmdCloseBond(cdc),
+		GetCmdSubmitBondProposal(cdc),
+		GetCmdVoteBondProposal(cdc),
+		GetCmdWithdrawBondFees(cdc),
+		GetCmdWithdrawFundingPool(cdc),
+		GetCmdClaimVested(cdc),
 	)...)
 
 	return bondsTxCmd
@@ -45,10 +58,10 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			_functionType := viper.GetString(FlagFunctionType)
 			_functionParameters := viper.GetString(FlagFunctionParameters)
 			_reserveTokens := viper.GetString(FlagReserveTokens)
-			_reserveAddress := viper.GetString(FlagReserveAddress)
 			_txFeePercentage := viper.GetString(FlagTxFeePercentage)
 			_exitFeePercentage := viper.GetString(FlagExitFeePercentage)
 			_feeAddress := viper.GetString(FlagFeeAddress)
+			_feeRecipients := viper.GetString(FlagFeeRecipients)
 			_maxSupply := viper.GetString(FlagMaxSupply)
 			_orderQuantityLimits := viper.GetString(FlagOrderQuantityLimits)
 			_sanityRate := viper.GetString(FlagSanityRate)
@@ -56,6 +69,13 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 			_allowSells := viper.GetString(FlagAllowSells)
 			_signers := viper.GetString(FlagSigners)
 			_batchBlocks := viper.GetString(FlagBatchBlocks)
+			_hatchWhitelist := viper.GetString(FlagHatchWhitelist)
+			_commitRevealSwaps := viper.GetString(FlagCommitRevealSwaps)
+			_vestingCliffBlocks := viper.GetString(FlagVestingCliffBlocks)
+			_vestingDurationBlocks := viper.GetString(FlagVestingDurationBlocks)
+			_vestingUntilSupply := viper.GetString(FlagVestingUntilSupply)
+			_transferPolicy := viper.GetString(FlagTransferPolicy)
+			_transferAllowList := viper.GetString(FlagTransferAllowList)
 
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -72,11 +92,6 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf(err.Error())
 			}
 
-			reserveAddress, err := sdk.AccAddressFromBech32(_reserveAddress)
-			if err != nil {
-				return err
-			}
-
 			txFeePercentage, err := sdk.NewDecFromStr(_txFeePercentage)
 			if err != nil {
 				return fmt.Errorf(types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "tx fee percentage").Error())
@@ -96,6 +111,12 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			// Parse fee recipients
+			feeRecipients, err := client2.ParseFeeRecipients(_feeRecipients)
+			if err != nil {
+				return err
+			}
+
 			maxSupply, err := client2.ParseMaxSupply(_maxSupply, _token)
 			if err != nil {
 				return err
@@ -124,12 +145,32 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf(err.Error())
 			}
 
+			// Parse hatch whitelist
+			hatchWhitelist, err := client2.ParseHatchWhitelist(_hatchWhitelist)
+			if err != nil {
+				return err
+			}
+
+			// Parse vesting
+			vesting, err := client2.ParseVestingConfig(_vestingCliffBlocks,
+				_vestingDurationBlocks, _vestingUntilSupply, _token)
+			if err != nil {
+				return err
+			}
+
+			// Parse transfer allow-list
+			transferAllowList, err := client2.ParseTransferAllowList(_transferAllowList)
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgCreateBond(_token, _name, _description,
 				cliCtx.GetFromAddress(), _functionType, functionParams,
-				reserveTokens, reserveAddress, txFeePercentage,
-				exitFeePercentage, feeAddress, maxSupply, orderQuantityLimits,
-				sanityRate, sanityMarginPercentage, _allowSells, signers,
-				batchBlocks)
+				reserveTokens, txFeePercentage, exitFeePercentage, feeAddress,
+				feeRecipients, maxSupply, orderQuantityLimits, sanityRate,
+				sanityMarginPercentage, _allowSells, signers, batchBlocks,
+				hatchWhitelist, _commitRevealSwaps, vesting, _transferPolicy,
+				transferAllowList)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -144,7 +185,6 @@ func GetCmdCreateBond(cdc *codec.Codec) *cobra.Command {
 	_ = cmd.MarkFlagRequired(FlagFunctionType)
 	_ = cmd.MarkFlagRequired(FlagFunctionParameters)
 	_ = cmd.MarkFlagRequired(FlagReserveTokens)
-	_ = cmd.MarkFlagRequired(FlagReserveAddress)
 	_ = cmd.MarkFlagRequired(FlagTxFeePercentage)
 	_ = cmd.MarkFlagRequired(FlagExitFeePercentage)
 	_ = cmd.MarkFlagRequired(FlagFeeAddress)
@@ -203,7 +243,8 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 		Use: "buy [bond-token-with-amount] [max-prices]",
 		Example: "" +
 			"buy 10abc 1000res1\n" +
-			"buy 10abc 1000res1,1000res2",
+			"buy 10abc 1000res1,1000res2\n" +
+			"buy 10abc 1000res1 --good-till-block=1500",
 		Short: "Buy from a bond",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -221,8 +262,50 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			goodTillBlock, err := client2.ParseGoodTillBlock(
+				viper.GetString(FlagGoodTillBlock))
+			if err != nil {
+				return err
+			}
+
 			msg := types.NewMsgBuy(cliCtx.GetFromAddress(),
-				bondCoinWithAmount, maxPrices)
+				bondCoinWithAmount, maxPrices, goodTillBlock)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondOrder)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdBuyWithBudget(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "buy-with-budget [bond-token] [budget]",
+		Example: "" +
+			"buy-with-budget abc 1000res1\n" +
+			"buy-with-budget abc 1000res1,1000res2",
+		Short: "Buy as many tokens from a bond as the budget allows",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Check that bond token is a valid token name
+			_, err := sdk.ParseCoin("0" + args[0])
+			if err != nil {
+				return types.ErrInvalidCoinDenomination(types.DefaultCodespace, args[0])
+			}
+
+			budget, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBuyWithBudget(cliCtx.GetFromAddress(),
+				args[0], budget)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -232,10 +315,13 @@ func GetCmdBuy(cdc *codec.Codec) *cobra.Command {
 
 func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "sell [bond-token-with-amount]",
-		Example: "sell 10abc",
-		Short:   "Sell from a bond",
-		Args:    cobra.ExactArgs(1),
+		Use: "sell [bond-token-with-amount]",
+		Example: "" +
+			"sell 10abc\n" +
+			"sell 10abc --min-returns=100res1,100res2\n" +
+			"sell 10abc --min-returns=100res1,100res2 --good-till-block=1500",
+		Short: "Sell from a bond",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -246,10 +332,26 @@ func GetCmdSell(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			msg := types.NewMsgSell(cliCtx.GetFromAddress(), bondCoinWithAmount)
+			goodTillBlock, err := client2.ParseGoodTillBlock(
+				viper.GetString(FlagGoodTillBlock))
+			if err != nil {
+				return err
+			}
+
+			minReturns, err := sdk.ParseCoins(viper.GetString(FlagMinReturns))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSell(cliCtx.GetFromAddress(),
+				bondCoinWithAmount, minReturns, goodTillBlock)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+
+	cmd.Flags().AddFlagSet(fsBondOrder)
+	cmd.Flags().AddFlagSet(fsMinReturns)
+
 	_ = cmd.MarkFlagRequired(client.FlagFrom)
 	return cmd
 }
@@ -259,7 +361,8 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 		Use: "swap [bond_token] [from_amount] [from_token] [to_token]",
 		Example: "" +
 			"swap abc 100 res1 res2\n" +
-			"swap abc 100 res2 res1",
+			"swap abc 100 res2 res1\n" +
+			"swap abc 100 res1 res2 --min-returns=90res2",
 		Short: "Perform a swap between two tokens",
 		Args:  cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -285,10 +388,398 @@ func GetCmdSwap(cdc *codec.Codec) *cobra.Command {
 				return types.ErrInvalidCoinDenomination(types.DefaultCodespace, args[3])
 			}
 
-			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from, args[3])
+			minReturns, err := sdk.ParseCoins(viper.GetString(FlagMinReturns))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSwap(cliCtx.GetFromAddress(), args[0], from,
+				args[3], minReturns)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsMinReturns)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdCommitSwap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "commit-swap [bond_token] [from_amount] [from_token] [to_token] [salt] [deposit]",
+		Example: "" +
+			"commit-swap abc 100 res1 res2 mysecretsalt 100res1\n" +
+			"commit-swap abc 100 res1 res2 mysecretsalt 100res1,100res2\n" +
+			"commit-swap abc 100 res1 res2 mysecretsalt 100res1 --min-returns=90res2",
+		Short: "Commit to a swap between two tokens without revealing it",
+		Long: "Commit to a swap between two tokens without revealing it. Only the hash of\n" +
+			"the swap details and salt is broadcast, together with the deposit. The same\n" +
+			"details and salt must be revealed using reveal-swap before the deadline,\n" +
+			"otherwise the deposit is forfeited.",
+		Args: cobra.ExactArgs(6),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Check that bond_token is a valid token name
+			_, err := sdk.ParseCoin("0" + args[0])
+			if err != nil {
+				return types.ErrInvalidCoinDenomination(types.DefaultCodespace, args[0])
+			}
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := sdk.ParseCoin(args[1] + args[2])
+			if err != nil {
+				return err
+			}
+
+			// Check that to_token is a valid token name
+			_, err = sdk.ParseCoin("0" + args[3])
+			if err != nil {
+				return types.ErrInvalidCoinDenomination(types.DefaultCodespace, args[3])
+			}
+
+			deposit, err := sdk.ParseCoins(args[5])
+			if err != nil {
+				return err
+			}
+
+			minReturns, err := sdk.ParseCoins(viper.GetString(FlagMinReturns))
+			if err != nil {
+				return err
+			}
+
+			// Check that the swap to be revealed later is valid
+			revealMsg := types.NewMsgRevealSwap(cliCtx.GetFromAddress(), args[0],
+				from, args[3], minReturns, args[4])
+			if err := revealMsg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCommitSwap(cliCtx.GetFromAddress(), args[0],
+				revealMsg.GetHash(), deposit)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsMinReturns)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdRevealSwap(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "reveal-swap [bond_token] [from_amount] [from_token] [to_token] [salt]",
+		Example: "" +
+			"reveal-swap abc 100 res1 res2 mysecretsalt\n" +
+			"reveal-swap abc 100 res1 res2 mysecretsalt --min-returns=90res2",
+		Short: "Reveal a previously committed swap between two tokens",
+		Args:  cobra.ExactArgs(5),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Check that bond_token is a valid token name
+			_, err := sdk.ParseCoin("0" + args[0])
+			if err != nil {
+				return types.ErrInvalidCoinDenomination(types.DefaultCodespace, args[0])
+			}
+
+			// Check that from amount and token can be parsed to a coin
+			from, err := sdk.ParseCoin(args[1] + args[2])
+			if err != nil {
+				return err
+			}
+
+			// Check that to_token is a valid token name
+			_, err = sdk.ParseCoin("0" + args[3])
+			if err != nil {
+				return types.ErrInvalidCoinDenomination(types.DefaultCodespace, args[3])
+			}
+
+			minReturns, err := sdk.ParseCoins(viper.GetString(FlagMinReturns))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealSwap(cliCtx.GetFromAddress(), args[0], from,
+				args[3], minReturns, args[4])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsMinReturns)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdUpdateBondState(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "update-bond-state [state]",
+		Example: "" +
+			"update-bond-state open --token=abc --signers=...\n" +
+			"update-bond-state closed --token=abc --signers=...",
+		Short: "Move an augmented function bond to its next phase",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgUpdateBondState(
+				_token, args[0], cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdPauseBond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pause-bond",
+		Example: "pause-bond --token=abc --signers=...",
+		Short:   "Pause a bond, so that no new orders are accepted",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgPauseBond(_token, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdResumeBond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "resume-bond",
+		Example: "resume-bond --token=abc --signers=...",
+		Short:   "Resume a paused bond",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgResumeBond(_token, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdCloseBond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "close-bond",
+		Example: "close-bond --token=abc --signers=...",
+		Short:   "Close a bond, so that holders can burn their tokens for a share of the reserve",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_signers := viper.GetString(FlagSigners)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse signers
+			signers, err := client2.ParseSigners(_signers)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCloseBond(_token, cliCtx.GetFromAddress(), signers)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsBondGeneral)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagSigners)
+
+	return cmd
+}
+
+func GetCmdSubmitBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "submit-bond-proposal",
+		Example: "" +
+			"submit-bond-proposal --token=abc --description=\"Lower fees\" --tx-fee-percentage=0.3 --voting-period=100\n" +
+			"submit-bond-proposal --token=abc --description=\"Steeper curve\" --function-parameters=\"m:12,n:2,c:100\" --voting-period=100",
+		Short: "Submit a proposal to change a bond's curve parameters or fees",
+		Long: "Submit a proposal to change a bond's curve parameters or fees. Holders of\n" +
+			"the bond token at the time of submission vote on the proposal, weighted by\n" +
+			"their balance, and the changes are applied if the proposal passes.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_token := viper.GetString(FlagToken)
+			_description := viper.GetString(FlagDescription)
+			_functionParameters := viper.GetString(FlagFunctionParameters)
+			_txFeePercentage := viper.GetString(FlagTxFeePercentage)
+			_exitFeePercentage := viper.GetString(FlagExitFeePercentage)
+			_feeAddress := viper.GetString(FlagFeeAddress)
+			_maxSupply := viper.GetString(FlagMaxSupply)
+			_votingPeriod := viper.GetString(FlagVotingPeriod)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Parse voting period
+			votingPeriod, err := sdk.ParseUint(_votingPeriod)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "voting period")
+			}
+
+			changes := types.NewBondParamChanges(_functionParameters,
+				_txFeePercentage, _exitFeePercentage, _feeAddress, _maxSupply)
+
+			msg := types.NewMsgSubmitBondProposal(cliCtx.GetFromAddress(),
+				_token, _description, changes, votingPeriod)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagToken, "", "The bond's token")
+	cmd.Flags().AddFlagSet(fsProposal)
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagToken)
+	_ = cmd.MarkFlagRequired(FlagDescription)
+	_ = cmd.MarkFlagRequired(FlagVotingPeriod)
+
+	return cmd
+}
+
+func GetCmdVoteBondProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vote-bond-proposal [proposal-id] [option]",
+		Example: "vote-bond-proposal 1 yes",
+		Short:   "Vote yes, no or abstain on a bond proposal",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalId, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "proposal id")
+			}
+
+			msg := types.NewMsgVoteBondProposal(cliCtx.GetFromAddress(),
+				proposalId, args[1])
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdWithdrawBondFees(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "withdraw-bond-fees [bond-token]",
+		Example: "withdraw-bond-fees abc",
+		Short:   "Withdraw the bond fees that have accrued to a fee recipient",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgWithdrawBondFees(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdWithdrawFundingPool(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "withdraw-funding-pool [bond-token]",
+		Example: "withdraw-funding-pool abc",
+		Short:   "Withdraw the funding pool of an augmented function bond to its fee address",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgWithdrawFundingPool(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	_ = cmd.MarkFlagRequired(client.FlagFrom)
+	return cmd
+}
+
+func GetCmdClaimVested(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "claim-vested [bond-token]",
+		Example: "claim-vested abc",
+		Short:   "Claim the bought bond tokens that have vested so far",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgClaimVested(cliCtx.GetFromAddress(), args[0])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
 	_ = cmd.MarkFlagRequired(client.FlagFrom)
 	return cmd
 }
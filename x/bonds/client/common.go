@@ -3,6 +3,7 @@ package client
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"strconv"
 	"strings"
 )
 
@@ -38,9 +39,9 @@ func paramsListToMap(paramValuePairs []string) (paramsFieldMap map[string]string
 
 func paramsMapToObj(paramsFieldMap map[string]string, expectedParams []string) (functionParams types.FunctionParams, err sdk.Error) {
 	for _, p := range expectedParams {
-		val, ok := sdk.NewIntFromString(paramsFieldMap[p])
-		if !ok {
-			return nil, types.ErrFunctionParameterMissingOrNonInteger(types.DefaultCodespace, p)
+		val, err := sdk.NewDecFromStr(paramsFieldMap[p])
+		if err != nil {
+			return nil, types.ErrFunctionParameterMissingOrNonFloat(types.DefaultCodespace, p)
 		} else {
 			functionParams = append(functionParams, types.NewFunctionParam(p, val))
 		}
@@ -56,8 +57,19 @@ func ParseFunctionParams(fnParamsStr string, fnType string) (fnParams types.Func
 		return nil, err
 	}
 
-	// Split (if not empty) and check number of parameters
+	// Split (if not empty)
 	paramValuePairs := splitParameters(fnParamsStr)
+
+	// Weighted swapper weights are keyed by reserve token, so any parameter
+	// names are accepted here and are checked against the reserve tokens
+	// when the message is validated (e.g. "res:0.5,rez:0.3,rex:0.2")
+	if fnType == types.WeightedSwapperFunction {
+		for _, pv := range paramValuePairs {
+			expectedParams = append(expectedParams, strings.SplitN(pv, ":", 2)[0])
+		}
+	}
+
+	// Check number of parameters
 	if len(paramValuePairs) != len(expectedParams) {
 		return nil, types.ErrIncorrectNumberOfFunctionParameters(types.DefaultCodespace, len(expectedParams))
 	}
@@ -68,7 +80,7 @@ func ParseFunctionParams(fnParamsStr string, fnType string) (fnParams types.Func
 		return nil, err
 	}
 
-	// Parse parameters into integers
+	// Parse parameters into decimals
 	functionParams, err := paramsMapToObj(paramsFieldMap, expectedParams)
 	if err != nil {
 		return nil, err
@@ -77,52 +89,11 @@ func ParseFunctionParams(fnParamsStr string, fnType string) (fnParams types.Func
 	return functionParams, nil
 }
 
-func checkReserveTokenNames(resTokens []string, token string) sdk.Error {
-	// Check that no token is the same as the main token, no token
-	// is duplicate, and that the token is a valid denomination
-	uniqueReserveTokens := make(map[string]string)
-	for _, r := range resTokens {
-		// Check if same as main token
-		if r == token {
-			return types.ErrBondTokenCannotAlsoBeReserveToken(types.DefaultCodespace)
-		}
-
-		// Check if duplicate
-		if _, ok := uniqueReserveTokens[r]; ok {
-			return types.ErrDuplicateReserveToken(types.DefaultCodespace)
-		} else {
-			uniqueReserveTokens[r] = ""
-		}
-
-		// Check if can be parsed as coin
-		_, err := sdk.ParseCoin("0" + r)
-		if err != nil {
-			return types.ErrInvalidCoinDenomination(types.DefaultCodespace, r)
-		}
-	}
-	return nil
-}
-
-func checkNoOfReserveTokens(resTokens []string, fnType string) sdk.Error {
-	// Come up with number of expected reserve tokens
-	expectedNoOfTokens, ok := types.NoOfReserveTokensForFunctionType[fnType]
-	if !ok {
-		return types.ErrUnrecognizedFunctionType(types.DefaultCodespace)
-	}
-
-	// Check that number of reserve tokens is correct (if expecting a specific number of tokens)
-	if expectedNoOfTokens != types.AnyNumberOfReserveTokens && len(resTokens) != expectedNoOfTokens {
-		return types.ErrIncorrectNumberOfReserveTokens(types.DefaultCodespace, expectedNoOfTokens)
-	}
-
-	return nil
-}
-
 func ParseReserveTokens(resTokensStr string, fnType string, token string) (resTokens []string, err sdk.Error) {
 	resTokens = strings.Split(resTokensStr, ",")
-	if err = checkReserveTokenNames(resTokens, token); err != nil {
+	if err = types.CheckReserveTokenNames(resTokens, token); err != nil {
 		return nil, err
-	} else if err = checkNoOfReserveTokens(resTokens, fnType); err != nil {
+	} else if err = types.CheckNoOfReserveTokens(resTokens, fnType); err != nil {
 		return nil, err
 	}
 	return resTokens, nil
@@ -187,6 +158,88 @@ func ParseSigners(signersStr string) (signers []sdk.AccAddress, err error) {
 	return signers, nil
 }
 
+func ParseFeeRecipients(feeRecipientsStr string) (feeRecipients types.FeeRecipients, err error) {
+
+	// Fee recipients are optional, in which case fees go to the fee address
+	if strings.TrimSpace(feeRecipientsStr) == "" {
+		return nil, nil
+	}
+
+	// Parse "addr1:70,addr2:30" into fee recipients
+	for _, rp := range strings.Split(feeRecipientsStr, ",") {
+		rpArray := strings.SplitN(rp, ":", 2)
+		if len(rpArray) != 2 {
+			return nil, types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "fee recipient percentage")
+		}
+		address, err := sdk.AccAddressFromBech32(rpArray[0])
+		if err != nil {
+			return nil, err
+		}
+		percentage, err := sdk.NewDecFromStr(rpArray[1])
+		if err != nil {
+			return nil, types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "fee recipient percentage")
+		}
+		feeRecipients = append(feeRecipients, types.NewFeeRecipient(address, percentage))
+	}
+	return feeRecipients, nil
+}
+
+func ParseVestingConfig(cliffBlocksStr, durationBlocksStr, appliesUntilSupplyStr string,
+	token string) (vesting types.VestingConfig, err error) {
+
+	// Vesting is optional, in which case bought tokens go straight to buyers
+	if strings.TrimSpace(cliffBlocksStr) == "" && strings.TrimSpace(durationBlocksStr) == "" &&
+		strings.TrimSpace(appliesUntilSupplyStr) == "" {
+		return types.NoVestingConfig(token), nil
+	}
+
+	// The cliff is optional, with zero meaning that tokens start vesting
+	// immediately
+	var cliffBlocks uint64
+	if strings.TrimSpace(cliffBlocksStr) != "" {
+		cliffBlocks, err = strconv.ParseUint(cliffBlocksStr, 10, 64)
+		if err != nil {
+			return types.VestingConfig{}, types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "vesting cliff blocks")
+		}
+	}
+
+	durationBlocks, err := strconv.ParseUint(durationBlocksStr, 10, 64)
+	if err != nil {
+		return types.VestingConfig{}, types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "vesting duration blocks")
+	}
+
+	appliesUntilSupply, err := sdk.ParseCoin(appliesUntilSupplyStr)
+	if err != nil {
+		return types.VestingConfig{}, err
+	} else if appliesUntilSupply.Denom != token {
+		return types.VestingConfig{}, types.ErrVestingSupplyDenomDoesNotMatchTokenDenom(types.DefaultCodespace)
+	}
+
+	return types.NewVestingConfig(cliffBlocks, durationBlocks, appliesUntilSupply), nil
+}
+
+func ParseHatchWhitelist(whitelistStr string) (whitelist []sdk.AccAddress, err error) {
+
+	// Whitelist is optional since it is only used by the augmented function
+	if strings.TrimSpace(whitelistStr) == "" {
+		return nil, nil
+	}
+
+	// Parse in the same way as signers
+	return ParseSigners(whitelistStr)
+}
+
+func ParseTransferAllowList(allowListStr string) (allowList []sdk.AccAddress, err error) {
+
+	// Allow-list is optional since it is only used by the allow-list policy
+	if strings.TrimSpace(allowListStr) == "" {
+		return nil, nil
+	}
+
+	// Parse in the same way as signers
+	return ParseSigners(allowListStr)
+}
+
 func ParseBatchBlocks(batchBlocksStr string) (batchBlocks sdk.Uint, err error) {
 
 	batchBlocks, err = sdk.ParseUint(batchBlocksStr)
@@ -195,3 +248,17 @@ func ParseBatchBlocks(batchBlocksStr string) (batchBlocks sdk.Uint, err error) {
 	}
 	return batchBlocks, nil
 }
+
+func ParseGoodTillBlock(goodTillBlockStr string) (goodTillBlock int64, err error) {
+
+	// Good-till-block is optional, with zero meaning that it is not set
+	if strings.TrimSpace(goodTillBlockStr) == "" {
+		return 0, nil
+	}
+
+	goodTillBlock, err = strconv.ParseInt(goodTillBlockStr, 10, 64)
+	if err != nil || goodTillBlock < 0 {
+		return 0, types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "good-till-block")
+	}
+	return goodTillBlock, nil
+}
@@ -0,0 +1,289 @@
+package rest
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	feedSubscriber       = "bonds-feed"
+	feedTxQuery          = "tm.event='Tx' AND message.module='bonds'"
+	feedBlockQuery       = "tm.event='NewBlockHeader'"
+	feedSubscriptionSize = 100
+	feedSubscriberBuffer = 100
+	feedWriteTimeout     = 10 * time.Second
+)
+
+// Bond events that are pushed to feed subscribers
+var feedEventTypes = map[string]bool{
+	types.EventTypeBuy:            true,
+	types.EventTypeSell:           true,
+	types.EventTypeSwap:           true,
+	types.EventTypeOrderCancel:    true,
+	types.EventTypeBatchPerformed: true,
+}
+
+// FeedEvent is a bonds event pushed to websocket feed subscribers. Events
+// emitted by transactions include the hash of the transaction, whereas events
+// emitted at the end of a block (such as batch_performed) do not.
+type FeedEvent struct {
+	Type       string            `json:"type"`
+	Bond       string            `json:"bond"`
+	Address    string            `json:"address,omitempty"`
+	Height     int64             `json:"height"`
+	TxHash     string            `json:"txhash,omitempty"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+func newFeedEvents(events []abci.Event, height int64, txHash string) []FeedEvent {
+	var feedEvents []FeedEvent
+	for _, e := range events {
+		if !feedEventTypes[e.Type] {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, a := range e.Attributes {
+			attributes[string(a.Key)] = string(a.Value)
+		}
+		feedEvents = append(feedEvents, FeedEvent{
+			Type:       e.Type,
+			Bond:       attributes[types.AttributeKeyBond],
+			Address:    attributes[types.AttributeKeyAddress],
+			Height:     height,
+			TxHash:     txHash,
+			Attributes: attributes,
+		})
+	}
+	return feedEvents
+}
+
+type feedSubscription struct {
+	token   string
+	address string
+	events  chan FeedEvent
+}
+
+// matches returns whether the event is for the subscription's bond token and
+// address (if specified). Events without an address, such as batch_performed,
+// are not filtered out by address.
+func (s *feedSubscription) matches(e FeedEvent) bool {
+	if s.token != "" && s.token != e.Bond {
+		return false
+	} else if s.address != "" && e.Address != "" && s.address != e.Address {
+		return false
+	}
+	return true
+}
+
+// feed subscribes to the node's events once the first websocket client
+// connects and fans out the bonds events to all websocket clients
+type feed struct {
+	cliCtx        context.CLIContext
+	mtx           sync.Mutex
+	client        *rpcclient.HTTP
+	subscriptions map[*feedSubscription]bool
+}
+
+func newFeed(cliCtx context.CLIContext) *feed {
+	return &feed{
+		cliCtx:        cliCtx,
+		subscriptions: make(map[*feedSubscription]bool),
+	}
+}
+
+func (f *feed) start() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.client != nil {
+		return nil
+	}
+
+	client := rpcclient.NewHTTP(f.cliCtx.NodeURI, "/websocket")
+	if err := client.Start(); err != nil {
+		return err
+	}
+
+	ctx := gocontext.Background()
+	txs, err := client.Subscribe(ctx, feedSubscriber, feedTxQuery, feedSubscriptionSize)
+	if err != nil {
+		_ = client.Stop()
+		return err
+	}
+	blocks, err := client.Subscribe(ctx, feedSubscriber, feedBlockQuery, feedSubscriptionSize)
+	if err != nil {
+		_ = client.Stop()
+		return err
+	}
+
+	f.client = client
+	go f.run(txs, blocks)
+	return nil
+}
+
+func (f *feed) run(txs, blocks <-chan ctypes.ResultEvent) {
+	for {
+		var events []FeedEvent
+		select {
+		case e, ok := <-txs:
+			if !ok {
+				f.stop()
+				return
+			}
+			data, ok := e.Data.(tmtypes.EventDataTx)
+			if !ok {
+				continue
+			}
+			txHash := fmt.Sprintf("%X", tmtypes.Tx(data.Tx).Hash())
+			events = newFeedEvents(data.Result.Events, data.Height, txHash)
+		case e, ok := <-blocks:
+			if !ok {
+				f.stop()
+				return
+			}
+			data, ok := e.Data.(tmtypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			events = newFeedEvents(data.ResultEndBlock.Events, data.Header.Height, "")
+		}
+		for _, e := range events {
+			f.broadcast(e)
+		}
+	}
+}
+
+// stop is called once the node's events stop being delivered (e.g. if the
+// connection to the node is lost). All subscribers are disconnected, and the
+// feed is subscribed to the node's events again when the next client connects.
+func (f *feed) stop() {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.client != nil {
+		_ = f.client.Stop()
+		f.client = nil
+	}
+	for s := range f.subscriptions {
+		delete(f.subscriptions, s)
+		close(s.events)
+	}
+}
+
+func (f *feed) broadcast(e FeedEvent) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for s := range f.subscriptions {
+		if !s.matches(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// Disconnect subscribers that are not keeping up
+			delete(f.subscriptions, s)
+			close(s.events)
+		}
+	}
+}
+
+func (f *feed) subscribe(token, address string) *feedSubscription {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	s := &feedSubscription{
+		token:   token,
+		address: address,
+		events:  make(chan FeedEvent, feedSubscriberBuffer),
+	}
+	f.subscriptions[s] = true
+	return s
+}
+
+func (f *feed) unsubscribe(s *feedSubscription) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.subscriptions[s] {
+		delete(f.subscriptions, s)
+		close(s.events)
+	}
+}
+
+var feedUpgrader = websocket.Upgrader{
+	// The feed is read-only, so it can be consumed from any origin
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// RegisterFeedRoutes registers the websocket endpoint that pushes buy, sell,
+// swap, order cancellation and batch events, optionally filtered by bond
+// token and address (e.g. /bonds_feed?bond_token=abc&address=ixo1...)
+func RegisterFeedRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/bonds_feed", feedHandler(newFeed(cliCtx))).Methods("GET")
+}
+
+func feedHandler(f *feed) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get(RestBondToken)
+		address := r.URL.Query().Get(RestAddress)
+		if address != "" {
+			if _, err := sdk.AccAddressFromBech32(address); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if err := f.start(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError,
+				fmt.Sprintf("could not subscribe to node events: %s", err.Error()))
+			return
+		}
+
+		// Upgrade writes an error response if it fails
+		conn, err := feedUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		s := f.subscribe(token, address)
+		defer f.unsubscribe(s)
+
+		// Messages from the client are discarded, but reading is required
+		// to notice when the client closes the connection
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case e, ok := <-s.events:
+				if !ok {
+					return
+				}
+				_ = conn.SetWriteDeadline(time.Now().Add(feedWriteTimeout))
+				if err := conn.WriteJSON(e); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
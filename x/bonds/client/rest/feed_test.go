@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func TestNewFeedEvents(t *testing.T) {
+	events := sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeBuy,
+			sdk.NewAttribute(types.AttributeKeyBond, "abc"),
+			sdk.NewAttribute(types.AttributeKeyAddress, "addr1"),
+			sdk.NewAttribute(types.AttributeKeyAmount, "10"),
+		),
+		// Not a feed event type, so filtered out
+		sdk.NewEvent(
+			types.EventTypeOrderFulfill,
+			sdk.NewAttribute(types.AttributeKeyBond, "abc"),
+			sdk.NewAttribute(types.AttributeKeyAddress, "addr1"),
+		),
+		sdk.NewEvent(
+			types.EventTypeBatchPerformed,
+			sdk.NewAttribute(types.AttributeKeyBond, "abc"),
+		),
+	}.ToABCIEvents()
+
+	feedEvents := newFeedEvents(events, 5, "HASH")
+	require.Equal(t, []FeedEvent{
+		{
+			Type:    types.EventTypeBuy,
+			Bond:    "abc",
+			Address: "addr1",
+			Height:  5,
+			TxHash:  "HASH",
+			Attributes: map[string]string{
+				types.AttributeKeyBond:    "abc",
+				types.AttributeKeyAddress: "addr1",
+				types.AttributeKeyAmount:  "10",
+			},
+		},
+		{
+			Type:   types.EventTypeBatchPerformed,
+			Bond:   "abc",
+			Height: 5,
+			TxHash: "HASH",
+			Attributes: map[string]string{
+				types.AttributeKeyBond: "abc",
+			},
+		},
+	}, feedEvents)
+
+	require.Empty(t, newFeedEvents(nil, 5, ""))
+}
+
+func TestFeedSubscriptionMatches(t *testing.T) {
+	buy := FeedEvent{Type: types.EventTypeBuy, Bond: "abc", Address: "addr1"}
+	otherBond := FeedEvent{Type: types.EventTypeBuy, Bond: "xyz", Address: "addr1"}
+	otherAddress := FeedEvent{Type: types.EventTypeBuy, Bond: "abc", Address: "addr2"}
+	batch := FeedEvent{Type: types.EventTypeBatchPerformed, Bond: "abc"}
+
+	testCases := []struct {
+		name     string
+		token    string
+		address  string
+		event    FeedEvent
+		expected bool
+	}{
+		{"no filters", "", "", buy, true},
+		{"no filters for other bond", "", "", otherBond, true},
+		{"token filter", "abc", "", buy, true},
+		{"token filter for other bond", "abc", "", otherBond, false},
+		{"address filter", "", "addr1", buy, true},
+		{"address filter for other address", "", "addr1", otherAddress, false},
+		{"address filter for batch event", "", "addr1", batch, true},
+		{"both filters", "abc", "addr1", buy, true},
+		{"both filters for other bond", "abc", "addr1", otherBond, false},
+		{"both filters for other address", "abc", "addr1", otherAddress, false},
+		{"both filters for batch event", "abc", "addr1", batch, true},
+		{"both filters for other bond's batch event", "xyz", "addr1", batch, false},
+	}
+
+	for _, tc := range testCases {
+		s := &feedSubscription{token: tc.token, address: tc.address}
+		require.Equal(t, tc.expected, s.matches(tc.event), tc.name)
+	}
+}
+
+func TestFeedStopsWhenEventsAreClosed(t *testing.T) {
+	f := newFeed(context.CLIContext{})
+	s1 := f.subscribe("abc", "")
+	s2 := f.subscribe("", "addr1")
+
+	txs := make(chan ctypes.ResultEvent)
+	blocks := make(chan ctypes.ResultEvent)
+	close(txs)
+
+	// run returns instead of spinning on the closed channel, and
+	// disconnects all subscribers
+	f.run(txs, blocks)
+	require.Nil(t, f.client)
+	require.Empty(t, f.subscriptions)
+	_, ok := <-s1.events
+	require.False(t, ok)
+	_, ok = <-s2.events
+	require.False(t, ok)
+
+	// Unsubscribing after the feed has stopped does not close twice
+	f.unsubscribe(s1)
+}
@@ -6,14 +6,25 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/gorilla/mux"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
 	"net/http"
 )
 
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute string) {
+	// Registered first, so that "orders" is not matched as a bond token
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/orders/{%s}", RestAddress),
+		queryOrdersHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		"/bonds", queryBondsHandler(cliCtx, queryRoute),
 	).Methods("GET")
 
+	r.HandleFunc(
+		"/bonds_detailed", queryBondsDetailedHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
 	r.HandleFunc(
 		fmt.Sprintf("/bonds/{%s}", RestBondToken),
 		queryBondHandler(cliCtx, queryRoute),
@@ -58,6 +69,46 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute st
 		fmt.Sprintf("/bonds/{%s}/swap_return/{%s}/{%s}", RestBondToken, RestFromTokenWithAmount, RestToToken),
 		querySwapReturnHandler(cliCtx, queryRoute),
 	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/state", RestBondToken),
+		queryStateHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/pool_balances", RestBondToken),
+		queryPoolBalancesHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/resting_orders/{%s}", RestAddress),
+		queryRestingOrdersHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/price_history", RestBondToken),
+		queryPriceHistoryHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/proposals", RestBondToken),
+		queryBondProposalsHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/proposals/{%s}", RestProposalId),
+		queryBondProposalHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/accrued_fees/{%s}", RestBondToken, RestAddress),
+		queryAccruedFeesHandler(cliCtx, queryRoute),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/bonds/{%s}/vesting/{%s}", RestBondToken, RestAddress),
+		queryVestingHandler(cliCtx, queryRoute),
+	).Methods("GET")
 }
 
 func queryBondsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
@@ -72,6 +123,18 @@ func queryBondsHandler(cliCtx context.CLIContext, queryRoute string) http.Handle
 	}
 }
 
+func queryBondsDetailedHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bonds_detailed", queryRoute), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
 func queryBondHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -236,3 +299,186 @@ func querySwapReturnHandler(cliCtx context.CLIContext, queryRoute string) http.H
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func queryStateHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/state/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryPoolBalancesHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/pool_balances/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryRestingOrdersHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		address := vars[RestAddress]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/resting_orders/%s",
+				queryRoute, address), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryPriceHistoryHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		// Heights are optional query parameters (0 if not specified)
+		fromHeight := "0"
+		if from := r.URL.Query().Get(RestFromHeight); from != "" {
+			fromHeight = from
+		}
+		toHeight := "0"
+		if to := r.URL.Query().Get(RestToHeight); to != "" {
+			toHeight = to
+		}
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/price_history/%s/%s/%s",
+				queryRoute, bondToken, fromHeight, toHeight), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBondProposalsHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_proposals/%s",
+				queryRoute, bondToken), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryBondProposalHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		proposalId := vars[RestProposalId]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/bond_proposal/%s",
+				queryRoute, proposalId), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryAccruedFeesHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		address := vars[RestAddress]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/accrued_fees/%s/%s",
+				queryRoute, bondToken, address), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryVestingHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		bondToken := vars[RestBondToken]
+		address := vars[RestAddress]
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/vesting/%s/%s",
+				queryRoute, bondToken, address), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func queryOrdersHandler(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		address := vars[RestAddress]
+
+		// Bond token and pagination are optional query parameters
+		err := r.ParseForm()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, types.DefaultOrdersQueryLimit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		token := r.FormValue(RestBondToken)
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryOrdersParams(token, page, limit))
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(
+			fmt.Sprintf("custom/%s/orders/%s", queryRoute, address), bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
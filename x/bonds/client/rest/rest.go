@@ -14,6 +14,10 @@ const (
 	RestBondAmount          = "bond_amount"
 	RestFromTokenWithAmount = "from_token_with_amount"
 	RestToToken             = "to_token"
+	RestAddress             = "address"
+	RestFromHeight          = "from"
+	RestToHeight            = "to"
+	RestProposalId          = "proposal_id"
 )
 
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
@@ -9,6 +9,7 @@ import (
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/client"
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
 	"net/http"
+	"strconv"
 )
 
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
@@ -27,6 +28,11 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		buyHandler(cliCtx),
 	).Methods("POST")
 
+	r.HandleFunc(
+		"/bonds/buy_with_budget",
+		buyWithBudgetHandler(cliCtx),
+	).Methods("POST")
+
 	r.HandleFunc(
 		"/bonds/sell",
 		sellHandler(cliCtx),
@@ -36,6 +42,61 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		"/bonds/swap",
 		swapHandler(cliCtx),
 	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/commit_swap",
+		commitSwapHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/reveal_swap",
+		revealSwapHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/update_bond_state",
+		updateBondStateHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/pause_bond",
+		pauseBondHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/resume_bond",
+		resumeBondHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/close_bond",
+		closeBondHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/submit_bond_proposal",
+		submitBondProposalHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/vote_bond_proposal",
+		voteBondProposalHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/withdraw_bond_fees",
+		withdrawBondFeesHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/withdraw_funding_pool",
+		withdrawFundingPoolHandler(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/bonds/claim_vested",
+		claimVestedHandler(cliCtx),
+	).Methods("POST")
 }
 
 type createBondReq struct {
@@ -46,10 +107,10 @@ type createBondReq struct {
 	FunctionType           string       `json:"function_type" yaml:"function_type"`
 	FunctionParameters     string       `json:"function_parameters" yaml:"function_parameters"`
 	ReserveTokens          string       `json:"reserve_tokens" yaml:"reserve_tokens"`
-	ReserveAddress         string       `json:"reserve_address" yaml:"reserve_address"`
 	TxFeePercentage        string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
 	ExitFeePercentage      string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
 	FeeAddress             string       `json:"fee_address" yaml:"fee_address"`
+	FeeRecipients          string       `json:"fee_recipients" yaml:"fee_recipients"`
 	MaxSupply              string       `json:"max_supply" yaml:"max_supply"`
 	OrderQuantityLimits    string       `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             string       `json:"sanity_rate" yaml:"sanity_rate"`
@@ -57,6 +118,13 @@ type createBondReq struct {
 	AllowSells             string       `json:"allow_sells" yaml:"allow_sells"`
 	Signers                string       `json:"signers" yaml:"signers"`
 	BatchBlocks            string       `json:"batch_blocks" yaml:"batch_blocks"`
+	HatchWhitelist         string       `json:"hatch_whitelist" yaml:"hatch_whitelist"`
+	CommitRevealSwaps      string       `json:"commit_reveal_swaps" yaml:"commit_reveal_swaps"`
+	VestingCliffBlocks     string       `json:"vesting_cliff_blocks" yaml:"vesting_cliff_blocks"`
+	VestingDurationBlocks  string       `json:"vesting_duration_blocks" yaml:"vesting_duration_blocks"`
+	VestingUntilSupply     string       `json:"vesting_applies_until_supply" yaml:"vesting_applies_until_supply"`
+	TransferPolicy         string       `json:"transfer_policy" yaml:"transfer_policy"`
+	TransferAllowList      string       `json:"transfer_allow_list" yaml:"transfer_allow_list"`
 }
 
 func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -93,12 +161,6 @@ func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		reserveAddress, err := sdk.AccAddressFromBech32(req.ReserveAddress)
-		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
 		txFeePercentageDec, err := sdk.NewDecFromStr(req.TxFeePercentage)
 		if err != nil {
 			err = types.ErrArgumentMissingOrNonFloat(types.DefaultCodespace, "tx fee percentage")
@@ -125,6 +187,13 @@ func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse fee recipients
+		feeRecipients, err := client.ParseFeeRecipients(req.FeeRecipients)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		maxSupply, err := client.ParseMaxSupply(req.MaxSupply, req.Token)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -158,11 +227,44 @@ func createBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
+		// Parse hatch whitelist
+		hatchWhitelist, err := client.ParseHatchWhitelist(req.HatchWhitelist)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Commit-reveal swaps are optional and disabled by default
+		if req.CommitRevealSwaps == "" {
+			req.CommitRevealSwaps = types.FALSE
+		}
+
+		// Parse vesting
+		vesting, err := client.ParseVestingConfig(req.VestingCliffBlocks,
+			req.VestingDurationBlocks, req.VestingUntilSupply, req.Token)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse transfer allow-list
+		transferAllowList, err := client.ParseTransferAllowList(req.TransferAllowList)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Transfer policy is optional and open by default
+		if req.TransferPolicy == "" {
+			req.TransferPolicy = types.OpenTransferPolicy
+		}
+
 		msg := types.NewMsgCreateBond(req.Token, req.Name, req.Description,
 			creator, req.FunctionType, functionParams, reserveTokens,
-			reserveAddress, txFeePercentageDec, exitFeePercentageDec,
-			feeAddress, maxSupply, orderQuantityLimits, sanityRate,
-			sanityMarginPercentage, req.AllowSells, signers, batchBlocks)
+			txFeePercentageDec, exitFeePercentageDec, feeAddress,
+			feeRecipients, maxSupply, orderQuantityLimits, sanityRate, sanityMarginPercentage,
+			req.AllowSells, signers, batchBlocks, hatchWhitelist,
+			req.CommitRevealSwaps, vesting, req.TransferPolicy, transferAllowList)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -225,10 +327,11 @@ func editBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type buyReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondToken  string       `json:"bond_token" yaml:"bond_token"`
-	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
-	MaxPrices  string       `json:"max_prices" yaml:"max_prices"`
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken     string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount    string       `json:"bond_amount" yaml:"bond_amount"`
+	MaxPrices     string       `json:"max_prices" yaml:"max_prices"`
+	GoodTillBlock string       `json:"good_till_block" yaml:"good_till_block"`
 }
 
 func buyHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -263,7 +366,64 @@ func buyHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgBuy(buyer, bondCoin, maxPrices)
+		goodTillBlock, err := client.ParseGoodTillBlock(req.GoodTillBlock)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuy(buyer, bondCoin, maxPrices, goodTillBlock)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type buyWithBudgetReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Budget    string       `json:"budget" yaml:"budget"`
+}
+
+func buyWithBudgetHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req buyWithBudgetReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		buyer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that BondToken is a valid token name
+		_, err = sdk.ParseCoin("0" + req.BondToken)
+		if err != nil {
+			err = types.ErrInvalidCoinDenomination(types.DefaultCodespace, req.BondToken)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		budget, err := sdk.ParseCoins(req.Budget)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgBuyWithBudget(buyer, req.BondToken, budget)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -275,9 +435,11 @@ func buyHandler(cliCtx context.CLIContext) http.HandlerFunc {
 }
 
 type sellReq struct {
-	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
-	BondToken  string       `json:"bond_token" yaml:"bond_token"`
-	BondAmount string       `json:"bond_amount" yaml:"bond_amount"`
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken     string       `json:"bond_token" yaml:"bond_token"`
+	BondAmount    string       `json:"bond_amount" yaml:"bond_amount"`
+	MinReturns    string       `json:"min_returns" yaml:"min_returns"`
+	GoodTillBlock string       `json:"good_till_block" yaml:"good_till_block"`
 }
 
 func sellHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -306,7 +468,19 @@ func sellHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSell(seller, bondCoin)
+		goodTillBlock, err := client.ParseGoodTillBlock(req.GoodTillBlock)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSell(seller, bondCoin, minReturns, goodTillBlock)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -323,6 +497,7 @@ type swapReq struct {
 	FromAmount string       `json:"from_amount" yaml:"from_amount"`
 	FromToken  string       `json:"from_token" yaml:"from_token"`
 	ToToken    string       `json:"to_token" yaml:"to_token"`
+	MinReturns string       `json:"min_returns" yaml:"min_returns"`
 }
 
 func swapHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -368,7 +543,452 @@ func swapHandler(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgSwap(swapper, req.BondToken, fromCoin, req.ToToken)
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgSwap(swapper, req.BondToken, fromCoin, req.ToToken, minReturns)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type commitSwapReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+	Hash      string       `json:"hash" yaml:"hash"`
+	Deposit   string       `json:"deposit" yaml:"deposit"`
+}
+
+func commitSwapHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req commitSwapReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		swapper, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that BondToken is a valid token name
+		_, err = sdk.ParseCoin("0" + req.BondToken)
+		if err != nil {
+			err = types.ErrInvalidCoinDenomination(types.DefaultCodespace, req.BondToken)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		deposit, err := sdk.ParseCoins(req.Deposit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgCommitSwap(swapper, req.BondToken, req.Hash, deposit)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type revealSwapReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken  string       `json:"bond_token" yaml:"bond_token"`
+	FromAmount string       `json:"from_amount" yaml:"from_amount"`
+	FromToken  string       `json:"from_token" yaml:"from_token"`
+	ToToken    string       `json:"to_token" yaml:"to_token"`
+	MinReturns string       `json:"min_returns" yaml:"min_returns"`
+	Salt       string       `json:"salt" yaml:"salt"`
+}
+
+func revealSwapHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req revealSwapReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		swapper, err := sdk.AccAddressFromBech32(baseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that BondToken is a valid token name
+		_, err = sdk.ParseCoin("0" + req.BondToken)
+		if err != nil {
+			err = types.ErrInvalidCoinDenomination(types.DefaultCodespace, req.BondToken)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that from amount and token can be parsed to a coin
+		fromCoin, err := sdk.ParseCoin(req.FromAmount + req.FromToken)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Check that ToToken is a valid token name
+		_, err = sdk.ParseCoin("0" + req.ToToken)
+		if err != nil {
+			err = types.ErrInvalidCoinDenomination(types.DefaultCodespace, req.ToToken)
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		minReturns, err := sdk.ParseCoins(req.MinReturns)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgRevealSwap(swapper, req.BondToken, fromCoin,
+			req.ToToken, minReturns, req.Salt)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type updateBondStateReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token   string       `json:"token" yaml:"token"`
+	State   string       `json:"state" yaml:"state"`
+	Signers string       `json:"signers" yaml:"signers"`
+}
+
+func updateBondStateHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req updateBondStateReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUpdateBondState(req.Token, req.State, editor, signers)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type bondLifecycleReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token   string       `json:"token" yaml:"token"`
+	Signers string       `json:"signers" yaml:"signers"`
+}
+
+func pauseBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return bondLifecycleHandler(cliCtx, func(token string, editor sdk.AccAddress, signers []sdk.AccAddress) sdk.Msg {
+		return types.NewMsgPauseBond(token, editor, signers)
+	})
+}
+
+func resumeBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return bondLifecycleHandler(cliCtx, func(token string, editor sdk.AccAddress, signers []sdk.AccAddress) sdk.Msg {
+		return types.NewMsgResumeBond(token, editor, signers)
+	})
+}
+
+func closeBondHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return bondLifecycleHandler(cliCtx, func(token string, editor sdk.AccAddress, signers []sdk.AccAddress) sdk.Msg {
+		return types.NewMsgCloseBond(token, editor, signers)
+	})
+}
+
+func bondLifecycleHandler(cliCtx context.CLIContext,
+	newMsg func(token string, editor sdk.AccAddress, signers []sdk.AccAddress) sdk.Msg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req bondLifecycleReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		editor, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse signers
+		signers, err := client.ParseSigners(req.Signers)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := newMsg(req.Token, editor, signers)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type submitBondProposalReq struct {
+	BaseReq            rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Token              string       `json:"token" yaml:"token"`
+	Description        string       `json:"description" yaml:"description"`
+	FunctionParameters string       `json:"function_parameters" yaml:"function_parameters"`
+	TxFeePercentage    string       `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage  string       `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress         string       `json:"fee_address" yaml:"fee_address"`
+	MaxSupply          string       `json:"max_supply" yaml:"max_supply"`
+	VotingPeriod       string       `json:"voting_period" yaml:"voting_period"`
+}
+
+func submitBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req submitBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Parse voting period
+		votingPeriod, err := sdk.ParseUint(req.VotingPeriod)
+		if err != nil {
+			err = types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "voting period")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		changes := types.NewBondParamChanges(req.FunctionParameters,
+			req.TxFeePercentage, req.ExitFeePercentage, req.FeeAddress,
+			req.MaxSupply)
+
+		msg := types.NewMsgSubmitBondProposal(proposer, req.Token,
+			req.Description, changes, votingPeriod)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type voteBondProposalReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	ProposalId string       `json:"proposal_id" yaml:"proposal_id"`
+	Option     string       `json:"option" yaml:"option"`
+}
+
+func voteBondProposalHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req voteBondProposalReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		voter, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalId, err := strconv.ParseUint(req.ProposalId, 10, 64)
+		if err != nil {
+			err = types.ErrArgumentMissingOrNonUInteger(types.DefaultCodespace, "proposal id")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgVoteBondProposal(voter, proposalId, req.Option)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type withdrawBondFeesReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func withdrawBondFeesHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawBondFeesReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawBondFees(recipient, req.BondToken)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type withdrawFundingPoolReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func withdrawFundingPoolHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawFundingPoolReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		recipient, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgWithdrawFundingPool(recipient, req.BondToken)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
+
+type claimVestedReq struct {
+	BaseReq   rest.BaseReq `json:"base_req" yaml:"base_req"`
+	BondToken string       `json:"bond_token" yaml:"bond_token"`
+}
+
+func claimVestedHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req claimVestedReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		claimer, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgClaimVested(claimer, req.BondToken)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -1,7 +1,9 @@
 package bonds
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
 )
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
@@ -14,22 +16,224 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	for _, b := range data.Batches {
 		keeper.SetBatch(ctx, b.Token, b)
 	}
+
+	// Initialise last batches
+	for _, b := range data.LastBatches {
+		keeper.SetLastBatch(ctx, b.Token, b)
+	}
+
+	// Initialise reserves
+	for _, r := range data.Reserves {
+		keeper.SetReserveBalances(ctx, r.Token, r.Balances)
+	}
+
+	// Initialise funding pools
+	for _, p := range data.FundingPools {
+		keeper.SetFundingPoolBalances(ctx, p.Token, p.Balances)
+	}
+
+	// Initialise accrued fees
+	for _, f := range data.AccruedFees {
+		keeper.SetAccruedFees(ctx, f.Token, f.Recipient, f.Fees)
+	}
+
+	// Initialise vesting schedules
+	for _, v := range data.Vesting {
+		keeper.SetVestingSchedules(ctx, v.Token, v.Address, v.Schedules)
+	}
+
+	// Initialise order records, continuing order IDs from the highest one
+	var lastOrderId uint64
+	for _, r := range data.OrderRecords {
+		keeper.SetOrderRecord(ctx, r)
+		if r.Id > lastOrderId {
+			lastOrderId = r.Id
+		}
+	}
+	keeper.SetNextOrderId(ctx, lastOrderId+1)
+
+	// Initialise proposals (which also indexes the proposals that are still
+	// being voted on), continuing proposal IDs from the highest one
+	var lastProposalId uint64
+	for _, p := range data.Proposals {
+		keeper.SetProposal(ctx, p)
+		if p.ProposalId > lastProposalId {
+			lastProposalId = p.ProposalId
+		}
+	}
+	keeper.SetNextProposalId(ctx, lastProposalId+1)
+
+	// Initialise votes, together with the voting powers recorded for them
+	for _, v := range data.Votes {
+		keeper.SetVote(ctx, v.ProposalId, v.Vote)
+		keeper.SetVotingPower(ctx, v.ProposalId, v.Vote.Voter, v.VotingPower)
+	}
+
+	// Initialise price histories
+	for _, h := range data.PriceHistory {
+		keeper.SetPriceHistory(ctx, h.Token, h.Counter, h.Entries)
+	}
+
+	// Migrate reserves of bonds that still use a reserve address
+	err := keeper.MigrateLegacyReserves(ctx)
+	if err != nil {
+		panic(fmt.Sprintf("failed to migrate reserves: %s", err.Error()))
+	}
+
+	// Check bond supplies against the supply module, which is initialised first
+	total := keeper.SupplyKeeper.GetSupply(ctx).GetTotal()
+	for _, b := range data.Bonds {
+		expected := expectedSupply(keeper.MustGetBatch(ctx, b.Token), b.CurrentSupply)
+		if !expected.Amount.Equal(total.AmountOf(b.Token)) {
+			panic(fmt.Sprintf("supply of %s is %s but bond expects %s",
+				b.Token, total.AmountOf(b.Token).String(), expected.Amount.String()))
+		}
+	}
+}
+
+// PrepForZeroHeightGenesis settles the pending batch of every bond that is
+// accepting orders, in the same way as at the end of a batch, and refunds any
+// orders that would otherwise be left in the batches intermediary account or
+// that refer to block heights that will no longer be valid once the chain
+// restarts at height zero. Vesting schedules are rebased to height zero.
+func PrepForZeroHeightGenesis(ctx sdk.Context, keeper Keeper) {
+	reason := types.ErrOrderCancelledByZeroHeightExport(types.DefaultCodespace)
+
+	iterator := keeper.GetBondIterator(ctx)
+	var tokens []string
+	for ; iterator.Valid(); iterator.Next() {
+		tokens = append(tokens, keeper.MustGetBondByKey(ctx, iterator.Key()).Token)
+	}
+	iterator.Close()
+
+	for _, token := range tokens {
+		bond := keeper.MustGetBond(ctx, token)
+
+		// Batches of paused and settled bonds are not processed, so their
+		// orders are cancelled and refunded
+		if !bond.IsAcceptingOrders() {
+			keeper.CancelAllOrders(ctx, token, reason)
+			continue
+		}
+
+		// Perform orders and save the batch as the last batch
+		keeper.PerformOrders(ctx, token)
+		batch := keeper.MustGetBatch(ctx, token)
+		keeper.SetLastBatch(ctx, token, batch)
+		keeper.RecordPriceHistory(ctx, token, batch)
+
+		// Open augmented function bond if the hatch supply has been reached
+		bond = keeper.MustGetBond(ctx, token)
+		if bond.FunctionType == types.AugmentedFunction &&
+			bond.State == types.HatchState &&
+			!bond.CurrentSupply.IsLT(bond.GetHatchSupply()) {
+			keeper.SetBondState(ctx, token, types.OpenState)
+		}
+
+		// Resting orders and swap commitments expire at block heights, so
+		// these are refunded rather than carried over into the new batch,
+		// which is reset to a full batch in the process
+		pending := types.NewBatch(token, bond.BatchBlocks)
+		pending.RestingBuys = batch.RestingBuys
+		pending.RestingSells = batch.RestingSells
+		pending.SwapCommits = batch.SwapCommits
+		keeper.SetBatch(ctx, token, pending)
+		keeper.CancelAllOrders(ctx, token, reason)
+	}
+
+	keeper.RebaseVestingSchedules(ctx, ctx.BlockHeight())
+}
+
+// expectedSupply returns the supply of a bond's tokens in circulation, which
+// excludes tokens that were already burned for sells that are still in the
+// batch but are still a part of the bond's current supply
+func expectedSupply(batch Batch, currentSupply sdk.Coin) sdk.Coin {
+	supply := currentSupply
+	for _, s := range batch.Sells {
+		if s.Cancelled == types.FALSE {
+			supply = supply.Sub(s.Amount)
+		}
+	}
+	for _, s := range batch.RestingSells {
+		supply = supply.Sub(s.Amount)
+	}
+	return supply
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	// Export bonds and batches
+	// Export bonds, batches, last batches, reserves, funding pools, accrued
+	// fees, vesting schedules, price histories and order records
 	var bonds []Bond
 	var batches []Batch
+	var lastBatches []Batch
+	var reserves []BondReserve
+	var fundingPools []BondFundingPool
+	var accruedFees []BondFeeAccrual
+	var vesting []AccountVestingSchedules
+	var priceHistory []BondPriceHistory
 	iterator := k.GetBondIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
 		bond := k.MustGetBondByKey(ctx, iterator.Key())
 		batch := k.MustGetBatch(ctx, bond.Token)
 		bonds = append(bonds, bond)
 		batches = append(batches, batch)
+		if k.LastBatchExists(ctx, bond.Token) {
+			lastBatches = append(lastBatches, k.MustGetLastBatch(ctx, bond.Token))
+		}
+
+		reserveBalances := k.GetReserveBalances(ctx, bond.Token)
+		if !reserveBalances.IsZero() {
+			reserves = append(reserves, NewBondReserve(bond.Token, reserveBalances))
+		}
+
+		fundingPoolBalances := k.GetFundingPoolBalances(ctx, bond.Token)
+		if !fundingPoolBalances.IsZero() {
+			fundingPools = append(fundingPools, NewBondFundingPool(bond.Token, fundingPoolBalances))
+		}
+
+		accruedFees = append(accruedFees, k.GetAccruedFeesByBond(ctx, bond.Token)...)
+		vesting = append(vesting, k.GetVestingSchedulesByBond(ctx, bond.Token)...)
+
+		if counter := k.GetPriceHistoryCounter(ctx, bond.Token); counter > 0 {
+			priceHistory = append(priceHistory, types.NewBondPriceHistory(
+				bond.Token, counter, k.GetPriceHistory(ctx, bond.Token, 0, 0)))
+		}
+	}
+
+	var orderRecords []OrderRecord
+	recordsIterator := k.GetOrderRecordsIterator(ctx)
+	defer recordsIterator.Close()
+	for ; recordsIterator.Valid(); recordsIterator.Next() {
+		orderRecords = append(orderRecords, k.MustGetOrderRecordByKey(ctx, recordsIterator.Key()))
+	}
+
+	// Export proposals, and the votes of the proposals still being voted on
+	// (the next proposal ID and the index of these proposals are derived
+	// from the proposals in InitGenesis)
+	var proposals []BondProposal
+	var votes []ProposalVote
+	proposalsIterator := k.GetProposalIterator(ctx)
+	defer proposalsIterator.Close()
+	for ; proposalsIterator.Valid(); proposalsIterator.Next() {
+		proposal := k.MustGetProposalByKey(ctx, proposalsIterator.Key())
+		proposals = append(proposals, proposal)
+		for _, vote := range k.GetVotes(ctx, proposal.ProposalId) {
+			power := k.GetVotingPower(ctx, proposal.ProposalId, vote.Voter)
+			votes = append(votes, types.NewProposalVote(proposal.ProposalId, vote, power))
+		}
 	}
 
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
+		Bonds:        bonds,
+		Batches:      batches,
+		LastBatches:  lastBatches,
+		Reserves:     reserves,
+		AccruedFees:  accruedFees,
+		Vesting:      vesting,
+		OrderRecords: orderRecords,
+		Proposals:    proposals,
+		Votes:        votes,
+		PriceHistory: priceHistory,
+		FundingPools: fundingPools,
 	}
 }
@@ -0,0 +1,294 @@
+package bonds
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/keeper"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func TestGenesis(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+
+	require.Nil(t, types.ValidCreateBondMsg.ValidateBasic())
+	res := handler(ctx, types.ValidCreateBondMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// Buy tokens and perform the batch, which records the price history
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, sdk.NewCoins(
+		sdk.NewInt64Coin(types.ValidReserveToken, 1000000)))
+	require.Nil(t, err)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 100),
+		sdk.NewCoins(sdk.NewInt64Coin(types.ValidReserveToken, 1000000)), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+
+	// Submit a proposal and vote on it
+	changes := types.NewBondParamChanges("m:2,n:1,c:1", types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField)
+	res = handler(ctx, types.NewMsgSubmitBondProposal(buyer, token,
+		"Description", changes, sdk.NewUint(types.MinProposalVotingPeriod)))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgVoteBondProposal(buyer, 1, types.YesVoteOption))
+	require.True(t, res.IsOK(), res.Log)
+
+	genesisState := ExportGenesis(ctx, k)
+	require.Nil(t, ValidateGenesis(genesisState))
+	require.Equal(t, 1, len(genesisState.Bonds))
+	require.Equal(t, 1, len(genesisState.Proposals))
+	require.Equal(t, []ProposalVote{types.NewProposalVote(1,
+		types.NewBondProposalVote(buyer, types.YesVoteOption), sdk.NewInt(100))},
+		genesisState.Votes)
+	require.Equal(t, 1, len(genesisState.PriceHistory))
+	require.Equal(t, uint64(1), genesisState.PriceHistory[0].Counter)
+
+	// Bond supplies have to match the supply module
+	newCtx, newK, _ := keeper.CreateTestInput()
+	require.Panics(t, func() { InitGenesis(newCtx, newK, genesisState) })
+
+	newCtx, newK, _ = keeper.CreateTestInput()
+	newK.SupplyKeeper.SetSupply(newCtx, supply.NewSupply(k.SupplyKeeper.GetSupply(ctx).GetTotal()))
+	InitGenesis(newCtx, newK, genesisState)
+	require.Equal(t, genesisState, ExportGenesis(newCtx, newK))
+	require.Equal(t, k.GetNextProposalId(ctx), newK.GetNextProposalId(newCtx))
+	require.Equal(t, k.GetActiveProposalIds(ctx), newK.GetActiveProposalIds(newCtx))
+	require.Equal(t, k.GetPriceHistory(ctx, token, 0, 0), newK.GetPriceHistory(newCtx, token, 0, 0))
+}
+
+func TestPrepForZeroHeightGenesis(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+	tokens := func(amount int64) sdk.Coin { return sdk.NewInt64Coin(token, amount) }
+	endBlocks := func(from, to int64) {
+		for h := from; h <= to; h++ {
+			ctx = ctx.WithBlockHeight(h)
+			EndBlocker(ctx, k)
+		}
+	}
+
+	// Power function bond with batches of 3 blocks, whose first 20 tokens vest
+	msg := types.ValidCreateBondMsg
+	msg.BatchBlocks = sdk.NewUint(3)
+	msg.Vesting = types.NewVestingConfig(10, 100, tokens(20))
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// Swapper bond with commit-reveal swaps
+	swapperMsg := types.ValidCreateBondMsg
+	swapperMsg.Token = "swp"
+	swapperMsg.MaxSupply = sdk.NewInt64Coin("swp", 1000000)
+	swapperMsg.Vesting = types.NoVestingConfig("swp")
+	swapperMsg.FunctionType = types.SwapperFunction
+	swapperMsg.FunctionParameters = nil
+	swapperMsg.ReserveTokens = []string{types.ValidReserveToken, "rez"}
+	swapperMsg.CommitRevealSwaps = types.TRUE
+	require.Nil(t, swapperMsg.ValidateBasic())
+	res = handler(ctx, swapperMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	for _, address := range []sdk.AccAddress{buyer, other} {
+		_, err := k.CoinKeeper.AddCoins(ctx, address, reserveCoins(1000))
+		require.Nil(t, err)
+	}
+	ctx = ctx.WithBlockHeight(1)
+	res = handler(ctx, types.NewMsgBuy(buyer, tokens(20), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	endBlocks(1, 3)
+	ctx = ctx.WithBlockHeight(4)
+	res = handler(ctx, types.NewMsgBuy(other, tokens(10), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	endBlocks(4, 7)
+	require.Equal(t, tokens(30), k.MustGetBond(ctx, token).CurrentSupply)
+	schedules := k.GetVestingSchedules(ctx, token, buyer)
+	require.Equal(t, 1, len(schedules))
+
+	// Export half-way through a batch, with a pending buy, a sell whose
+	// tokens have been burned, a resting buy and an unrevealed swap commitment
+	ctx = ctx.WithBlockHeight(8)
+	res = handler(ctx, types.NewMsgBuy(buyer, tokens(5), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgSell(other, tokens(5), nil, 0))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgBuy(other, tokens(5), reserveCoins(1), 100))
+	require.True(t, res.IsOK(), res.Log)
+	reveal := types.NewMsgRevealSwap(buyer, "swp",
+		sdk.NewInt64Coin(types.ValidReserveToken, 50), "rez", nil, "salt")
+	res = handler(ctx, types.NewMsgCommitSwap(buyer, "swp", reveal.GetHash(), reserveCoins(50)))
+	require.True(t, res.IsOK(), res.Log)
+	batch := k.MustGetBatch(ctx, token)
+	require.Equal(t, sdk.NewUint(2), batch.BlocksRemaining)
+	require.Equal(t, 1, len(batch.RestingBuys))
+	require.Equal(t, 1, len(k.MustGetBatch(ctx, "swp").SwapCommits))
+
+	PrepForZeroHeightGenesis(ctx, k)
+	requireInvariantsHold(t, ctx, k)
+
+	// The pending buy and sell are performed, while the resting buy and the
+	// swap commitment are refunded, so nothing is left in the batches
+	// intermediary account, and the batch is reset to a full batch
+	require.True(t, k.SupplyKeeper.GetModuleAccount(ctx,
+		types.BatchesIntermediaryAccount).GetCoins().IsZero())
+	require.Equal(t, tokens(30), k.MustGetBond(ctx, token).CurrentSupply)
+	require.Equal(t, tokens(5), sdk.NewCoin(token, k.CoinKeeper.GetCoins(ctx, buyer).AmountOf(token)))
+	require.Equal(t, tokens(5), sdk.NewCoin(token, k.CoinKeeper.GetCoins(ctx, other).AmountOf(token)))
+	require.Equal(t, reserveCoins(2000), k.CoinKeeper.GetCoins(ctx, buyer).Add(
+		k.CoinKeeper.GetCoins(ctx, other)).Add(k.GetReserveBalances(ctx, token)).Sub(
+		sdk.NewCoins(tokens(10))))
+	batch = k.MustGetBatch(ctx, token)
+	require.Equal(t, types.NewBatch(token, msg.BatchBlocks), batch)
+	require.Equal(t, types.NewBatch("swp", swapperMsg.BatchBlocks), k.MustGetBatch(ctx, "swp"))
+
+	// Vesting schedules are rebased to the new height of zero
+	rebased := schedules[0]
+	rebased.StartHeight -= 8
+	rebased.CliffHeight -= 8
+	rebased.EndHeight -= 8
+	require.Equal(t, []types.VestingSchedule{rebased}, k.GetVestingSchedules(ctx, token, buyer))
+
+	// The exported state can be imported, including its supply check
+	genesisState := ExportGenesis(ctx, k)
+	require.Nil(t, ValidateGenesis(genesisState))
+	newCtx, newK, _ := keeper.CreateTestInput()
+	newK.SupplyKeeper.SetSupply(newCtx, supply.NewSupply(k.SupplyKeeper.GetSupply(ctx).GetTotal()))
+	require.NotPanics(t, func() { InitGenesis(newCtx, newK, genesisState) })
+	require.Equal(t, genesisState, ExportGenesis(newCtx, newK))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	bond := types.NewBond(types.ValidToken, "Name", "Description",
+		types.ValidCreatorAddress, types.PowerFunction,
+		types.ValidCreateBondMsg.FunctionParameters,
+		[]string{types.ValidReserveToken}, sdk.ZeroDec(), sdk.ZeroDec(),
+		types.ValidFeeAddress, nil, sdk.NewInt64Coin(types.ValidToken, 1000000),
+		nil, sdk.ZeroDec(), sdk.ZeroDec(), types.TRUE,
+		[]sdk.AccAddress{types.ValidCreatorAddress}, sdk.OneUint(), nil,
+		types.FALSE, types.NoVestingConfig(types.ValidToken),
+		types.OpenTransferPolicy, nil)
+	changes := types.NewBondParamChanges("m:2,n:1,c:1", types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField)
+	proposal := types.NewBondProposal(1, bond.Token, types.ValidBuyerAddress,
+		"Description", changes, 0, sdk.NewUint(types.MinProposalVotingPeriod),
+		sdk.NewInt(100))
+	vote := types.NewProposalVote(1, types.NewBondProposalVote(
+		types.ValidBuyerAddress, types.YesVoteOption), sdk.NewInt(100))
+	history := types.NewBondPriceHistory(bond.Token, 1, []types.PriceHistoryEntry{{Height: 1}})
+
+	validState := func() GenesisState {
+		genesisState := DefaultGenesisState()
+		genesisState.Bonds = []Bond{bond}
+		genesisState.Batches = []Batch{types.NewBatch(bond.Token, bond.BatchBlocks)}
+		genesisState.Proposals = []BondProposal{proposal}
+		genesisState.Votes = []ProposalVote{vote}
+		genesisState.PriceHistory = []BondPriceHistory{history}
+		return genesisState
+	}
+	require.Nil(t, ValidateGenesis(validState()))
+
+	// Proposals
+	genesisState := validState()
+	genesisState.Proposals = append(genesisState.Proposals, proposal)
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState = validState()
+	genesisState.Proposals[0].Token = "xyz"
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState = validState()
+	genesisState.Proposals[0].Status = "INVALID"
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	// Votes are only kept for proposals that are being voted on
+	genesisState = validState()
+	genesisState.Proposals[0].Status = types.PassedProposalStatus
+	require.NotNil(t, ValidateGenesis(genesisState))
+	genesisState.Votes = nil
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState = validState()
+	genesisState.Votes = append(genesisState.Votes, vote)
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState = validState()
+	genesisState.Votes[0].VotingPower = sdk.ZeroInt()
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	// Price histories
+	genesisState = validState()
+	genesisState.PriceHistory[0].Counter = 0
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState = validState()
+	genesisState.PriceHistory[0].Counter = 2
+	genesisState.PriceHistory[0].Entries = []types.PriceHistoryEntry{{Height: 2}, {Height: 1}}
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
+
+func TestInitGenesis_SharedLegacyReserve(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	reserveAddress := types.ValidOtherAddress
+
+	// Two power function bonds (price x + 1) with supplies of 100 and 200,
+	// which imply reserves of 5100 and 20200, share a legacy reserve address
+	newLegacyBond := func(token string, supply int64) Bond {
+		msg := types.ValidCreateBondMsg
+		bond := types.NewBond(token, msg.Name, msg.Description, msg.Creator,
+			msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
+			msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
+			msg.FeeRecipients, sdk.NewInt64Coin(token, 1000000), msg.OrderQuantityLimits,
+			msg.SanityRate, msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
+			msg.BatchBlocks, msg.HatchWhitelist, msg.CommitRevealSwaps,
+			types.NoVestingConfig(token), msg.TransferPolicy, msg.TransferAllowList)
+		bond.CurrentSupply = sdk.NewInt64Coin(token, supply)
+		bond.ReserveAddress = reserveAddress
+		return bond
+	}
+	bonds := []Bond{newLegacyBond("abc", 100), newLegacyBond("xyz", 200)}
+
+	genesisState := DefaultGenesisState()
+	for _, b := range bonds {
+		genesisState.Bonds = append(genesisState.Bonds, b)
+		genesisState.Batches = append(genesisState.Batches, types.NewBatch(b.Token, b.BatchBlocks))
+	}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	// The reserve address also holds coins that are not reserve tokens
+	other := sdk.NewInt64Coin("other", 10)
+	_, err := k.CoinKeeper.AddCoins(ctx, reserveAddress, reserveCoins(25300).Add(sdk.Coins{other}))
+	require.Nil(t, err)
+	bondTokens := sdk.NewCoins(sdk.NewInt64Coin("abc", 100), sdk.NewInt64Coin("xyz", 200))
+	_, err = k.CoinKeeper.AddCoins(ctx, types.ValidBuyerAddress, bondTokens)
+	require.Nil(t, err)
+	k.SupplyKeeper.SetSupply(ctx, supply.NewSupply(bondTokens))
+
+	InitGenesis(ctx, k, genesisState)
+	require.Equal(t, reserveCoins(5100), k.GetReserveBalances(ctx, "abc"))
+	require.Equal(t, reserveCoins(20200), k.GetReserveBalances(ctx, "xyz"))
+	require.Equal(t, sdk.Coins{other}, k.CoinKeeper.GetCoins(ctx, reserveAddress))
+	require.True(t, k.MustGetBond(ctx, "abc").ReserveAddress.Empty())
+	require.True(t, k.MustGetBond(ctx, "xyz").ReserveAddress.Empty())
+	requireInvariantsHold(t, ctx, k)
+
+	// Swappers have no curve-implied reserve, so cannot share their reserve
+	swapper := newLegacyBond("swp", 100)
+	swapper.FunctionType = types.SwapperFunction
+	swapper.FunctionParameters = nil
+	swapper.ReserveTokens = []string{types.ValidReserveToken, "rez"}
+	genesisState.Bonds = append(genesisState.Bonds, swapper)
+	genesisState.Batches = append(genesisState.Batches, types.NewBatch(swapper.Token, swapper.BatchBlocks))
+	require.NotNil(t, ValidateGenesis(genesisState))
+	genesisState.Bonds[2].ReserveAddress = types.ValidFeeAddress
+	require.Nil(t, ValidateGenesis(genesisState))
+}
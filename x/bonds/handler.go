@@ -18,10 +18,34 @@ func NewHandler(keeper keeper.Keeper) sdk.Handler {
 			return handleMsgEditBond(ctx, keeper, msg)
 		case types.MsgBuy:
 			return handleMsgBuy(ctx, keeper, msg)
+		case types.MsgBuyWithBudget:
+			return handleMsgBuyWithBudget(ctx, keeper, msg)
 		case types.MsgSell:
 			return handleMsgSell(ctx, keeper, msg)
 		case types.MsgSwap:
 			return handleMsgSwap(ctx, keeper, msg)
+		case types.MsgCommitSwap:
+			return handleMsgCommitSwap(ctx, keeper, msg)
+		case types.MsgRevealSwap:
+			return handleMsgRevealSwap(ctx, keeper, msg)
+		case types.MsgUpdateBondState:
+			return handleMsgUpdateBondState(ctx, keeper, msg)
+		case types.MsgPauseBond:
+			return handleMsgPauseBond(ctx, keeper, msg)
+		case types.MsgResumeBond:
+			return handleMsgResumeBond(ctx, keeper, msg)
+		case types.MsgCloseBond:
+			return handleMsgCloseBond(ctx, keeper, msg)
+		case types.MsgSubmitBondProposal:
+			return handleMsgSubmitBondProposal(ctx, keeper, msg)
+		case types.MsgVoteBondProposal:
+			return handleMsgVoteBondProposal(ctx, keeper, msg)
+		case types.MsgWithdrawBondFees:
+			return handleMsgWithdrawBondFees(ctx, keeper, msg)
+		case types.MsgWithdrawFundingPool:
+			return handleMsgWithdrawFundingPool(ctx, keeper, msg)
+		case types.MsgClaimVested:
+			return handleMsgClaimVested(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized bonds Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -34,26 +58,86 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) []abci.ValidatorUpdate {
 	iterator := keeper.GetBondIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
 		bond := keeper.MustGetBondByKey(ctx, iterator.Key())
+
+		// Batches of paused and settled bonds are not processed
+		if !bond.IsAcceptingOrders() {
+			continue
+		}
 		batch := keeper.MustGetBatch(ctx, bond.Token)
 
 		// Subtract one block
 		batch.BlocksRemaining = batch.BlocksRemaining.SubUint64(1)
 		keeper.SetBatch(ctx, bond.Token, batch)
 
+		// Cancel any expired resting orders
+		keeper.CancelExpiredRestingOrders(ctx, bond.Token)
+
+		// Forfeit any unrevealed swap commitments past their reveal deadline
+		keeper.ForfeitExpiredSwapCommits(ctx, bond.Token)
+
 		// If blocks remaining > 0 do not perform orders
 		if !batch.BlocksRemaining.IsZero() {
 			continue
 		}
 
+		// Defer swaps revealed in this block, since the swaps are shuffled
+		// using the last block hash, which was known when these were revealed
+		deferredSwaps := keeper.DeferSwapsRevealedInBlock(ctx, bond.Token)
+
 		// Perform orders
 		keeper.PerformOrders(ctx, bond.Token)
 
 		// Get batch again just in case orders were cancelled
 		batch = keeper.MustGetBatch(ctx, bond.Token)
 
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeBatchPerformed,
+			sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
+			sdk.NewAttribute(types.AttributeKeyTotalBuyAmount, batch.TotalBuyAmount.String()),
+			sdk.NewAttribute(types.AttributeKeyTotalSellAmount, batch.TotalSellAmount.String()),
+			sdk.NewAttribute(types.AttributeKeyBuyPrices, batch.BuyPrices.String()),
+			sdk.NewAttribute(types.AttributeKeySellPrices, batch.SellPrices.String()),
+		))
+
 		// Save current as last and reset current
 		keeper.SetLastBatch(ctx, bond.Token, batch)
 		keeper.SetBatch(ctx, bond.Token, types.NewBatch(bond.Token, bond.BatchBlocks))
+
+		// Record prices, volumes, supply and reserve of the cleared batch
+		keeper.RecordPriceHistory(ctx, bond.Token, batch)
+
+		// Open augmented function bond if the hatch supply has been reached
+		bond = keeper.MustGetBond(ctx, bond.Token)
+		if bond.FunctionType == types.AugmentedFunction &&
+			bond.State == types.HatchState &&
+			!bond.CurrentSupply.IsLT(bond.GetHatchSupply()) {
+			keeper.SetBondState(ctx, bond.Token, types.OpenState)
+		}
+
+		// Carry resting orders over into the new batch
+		keeper.CarryOverRestingOrders(ctx, bond.Token, batch.RestingBuys, batch.RestingSells)
+
+		// Carry unrevealed swap commitments over into the new batch
+		keeper.CarryOverSwapCommits(ctx, bond.Token, batch.SwapCommits)
+
+		// Carry deferred swaps over into the new batch
+		keeper.CarryOverSwapOrders(ctx, bond.Token, deferredSwaps)
+	}
+
+	for _, proposalId := range keeper.GetActiveProposalIds(ctx) {
+		proposal := keeper.MustGetProposal(ctx, proposalId)
+
+		// Subtract one block
+		proposal.BlocksRemaining = proposal.BlocksRemaining.SubUint64(1)
+
+		// If blocks remaining > 0 voting is still in progress
+		if !proposal.BlocksRemaining.IsZero() {
+			keeper.SetProposal(ctx, proposal)
+			continue
+		}
+
+		// Tally votes and apply changes if the proposal passed
+		keeper.EndProposal(ctx, proposal)
 	}
 	return []abci.ValidatorUpdate{}
 }
@@ -68,9 +152,11 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 
 	bond := NewBond(msg.Token, msg.Name, msg.Description, msg.Creator,
 		msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
-		msg.ReserveAddress, msg.TxFeePercentage, msg.ExitFeePercentage,
-		msg.FeeAddress, msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
-		msg.SanityMarginPercentage, msg.AllowSells, msg.Signers, msg.BatchBlocks)
+		msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
+		msg.FeeRecipients, msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
+		msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
+		msg.BatchBlocks, msg.HatchWhitelist, msg.CommitRevealSwaps, msg.Vesting,
+		msg.TransferPolicy, msg.TransferAllowList)
 
 	keeper.SetBond(ctx, msg.Token, bond)
 	keeper.SetBatch(ctx, msg.Token, types.NewBatch(bond.Token, msg.BatchBlocks))
@@ -88,10 +174,10 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 			sdk.NewAttribute(types.AttributeKeyFunctionType, msg.FunctionType),
 			sdk.NewAttribute(types.AttributeKeyFunctionParameters, msg.FunctionParameters.String()),
 			sdk.NewAttribute(types.AttributeKeyReserveTokens, types.StringsToString(msg.ReserveTokens)),
-			sdk.NewAttribute(types.AttributeKeyReserveAddress, msg.ReserveAddress.String()),
 			sdk.NewAttribute(types.AttributeKeyTxFeePercentage, msg.TxFeePercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyExitFeePercentage, msg.ExitFeePercentage.String()),
 			sdk.NewAttribute(types.AttributeKeyFeeAddress, msg.FeeAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyFeeRecipients, msg.FeeRecipients.String()),
 			sdk.NewAttribute(types.AttributeKeyMaxSupply, msg.MaxSupply.String()),
 			sdk.NewAttribute(types.AttributeKeyOrderQuantityLimits, msg.OrderQuantityLimits.String()),
 			sdk.NewAttribute(types.AttributeKeySanityRate, msg.SanityRate.String()),
@@ -99,6 +185,14 @@ func handleMsgCreateBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCre
 			sdk.NewAttribute(types.AttributeKeyAllowSells, msg.AllowSells),
 			sdk.NewAttribute(types.AttributeKeySigners, types.AccAddressesToString(msg.Signers)),
 			sdk.NewAttribute(types.AttributeKeyBatchBlocks, msg.BatchBlocks.String()),
+			sdk.NewAttribute(types.AttributeKeyHatchWhitelist, types.AccAddressesToString(msg.HatchWhitelist)),
+			sdk.NewAttribute(types.AttributeKeyState, bond.State),
+			sdk.NewAttribute(types.AttributeKeyCommitRevealSwaps, msg.CommitRevealSwaps),
+			sdk.NewAttribute(types.AttributeKeyVestingCliffBlocks, fmt.Sprint(msg.Vesting.CliffBlocks)),
+			sdk.NewAttribute(types.AttributeKeyVestingDurationBlocks, fmt.Sprint(msg.Vesting.DurationBlocks)),
+			sdk.NewAttribute(types.AttributeKeyVestingUntilSupply, msg.Vesting.AppliesUntilSupply.String()),
+			sdk.NewAttribute(types.AttributeKeyTransferPolicy, msg.TransferPolicy),
+			sdk.NewAttribute(types.AttributeKeyTransferAllowList, types.AccAddressesToString(msg.TransferAllowList)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -196,6 +290,32 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) sdk.R
 		return types.ErrBondDoesNotExist(types.DefaultCodespace, token).Result()
 	}
 
+	// Check that bond is not closed, paused or settled
+	if bond.State == types.ClosedState || !bond.IsAcceptingOrders() {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check that the buyer is allowed to hold the bond's tokens
+	if err := keeper.CheckTransferAllowed(ctx, bond, msg.Buyer); err != nil {
+		return err.Result()
+	}
+
+	// During the hatch, only whitelisted addresses can buy up to the hatch supply
+	if bond.State == types.HatchState {
+		if !bond.IsWhitelistedForHatch(msg.Buyer) {
+			return types.ErrAddressNotWhitelisted(types.DefaultCodespace, msg.Buyer).Result()
+		}
+		adjustedSupply := keeper.GetSupplyAdjustedForBuy(ctx, token)
+		if bond.GetHatchSupply().IsLT(adjustedSupply.Add(msg.Amount)) {
+			return types.ErrCannotMintMoreThanHatchSupply(types.DefaultCodespace).Result()
+		}
+	}
+
+	// Check that good-till-block has not already passed
+	if msg.GoodTillBlock != 0 && msg.GoodTillBlock < ctx.BlockHeight() {
+		return types.ErrOrderAlreadyExpired(types.DefaultCodespace, msg.GoodTillBlock).Result()
+	}
+
 	// Check max prices
 	if !bond.ReserveDenomsEqualTo(msg.MaxPrices) {
 		return types.ErrReserveDenomsMismatch(types.DefaultCodespace, msg.MaxPrices, bond.ReserveTokens).Result()
@@ -209,7 +329,8 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) sdk.R
 	// For the swapper, the first buy is the initialisation of the reserves
 	// The max prices are used as the actual prices and one token is minted
 	// The amount of token serves to define the price of adding more liquidity
-	if bond.CurrentSupply.IsZero() && bond.FunctionType == types.SwapperFunction {
+	if bond.CurrentSupply.IsZero() && (bond.FunctionType == types.SwapperFunction ||
+		bond.FunctionType == types.WeightedSwapperFunction) {
 		return performFirstSwapperFunctionBuy(ctx, keeper, msg)
 	}
 
@@ -221,26 +342,31 @@ func handleMsgBuy(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuy) sdk.R
 	}
 
 	// Create order
-	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices)
+	order := types.NewBuyOrder(msg.Buyer, msg.Amount, msg.MaxPrices, msg.GoodTillBlock)
 
 	// Get buy price and check if can add buy order to batch
 	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterBuy(ctx, token, order)
-	if err != nil {
+	if err != nil && order.IsGoodTillBlock() && err.Code() == types.CodeMaxPriceExceeded {
+		// Good-till-block order rests until the next batch or until it expires
+		keeper.AddRestingBuyOrder(ctx, token, order)
+	} else if err != nil {
 		return err.Result()
-	}
+	} else {
+		// Add buy order to batch
+		keeper.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
 
-	// Add buy order to batch
-	keeper.AddBuyOrder(ctx, token, order, buyPrices, sellPrices)
-
-	// Cancel unfulfillable orders
-	keeper.CancelUnfulfillableOrders(ctx, token)
+		// Cancel unfulfillable orders
+		keeper.CancelUnfulfillableOrders(ctx, token)
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeBuy,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Buyer.String()),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeyMaxPrices, msg.MaxPrices.String()),
+			sdk.NewAttribute(types.AttributeKeyGoodTillBlock, fmt.Sprintf("%d", msg.GoodTillBlock)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -268,7 +394,7 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 	}
 
 	// Use max prices as the amount to send to the liquidity pool (i.e. price)
-	err := keeper.CoinKeeper.SendCoins(ctx, msg.Buyer, bond.ReserveAddress, msg.MaxPrices)
+	err := keeper.DepositReserve(ctx, bond.Token, msg.Buyer, msg.MaxPrices)
 	if err != nil {
 		return err.Result()
 	}
@@ -307,6 +433,48 @@ func performFirstSwapperFunctionBuy(ctx sdk.Context, keeper keeper.Keeper, msg t
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+func handleMsgBuyWithBudget(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgBuyWithBudget) sdk.Result {
+
+	token := msg.BondToken
+	bond, found := keeper.GetBond(ctx, token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, token).Result()
+	}
+
+	// Check that bond is not closed, paused or settled
+	if bond.State == types.ClosedState || !bond.IsAcceptingOrders() {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check budget
+	if !bond.ReserveDenomsEqualTo(msg.Budget) {
+		return types.ErrReserveDenomsMismatch(types.DefaultCodespace, msg.Budget, bond.ReserveTokens).Result()
+	}
+
+	// Solve for the max amount of tokens that the budget can buy
+	amount, err := keeper.GetMaxBuyAmountForBudget(ctx, token, msg.Budget)
+	if err != nil {
+		return err.Result()
+	}
+
+	// Add a regular buy order with the budget as the max prices, so that any
+	// surplus is returned to the buyer once the buy order is performed
+	buyMsg := types.NewMsgBuy(msg.Buyer, sdk.NewCoin(token, amount), msg.Budget, 0)
+	res := handleMsgBuy(ctx, keeper, buyMsg)
+	if !res.IsOK() {
+		return res
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBuyWithBudget,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyBudget, msg.Budget.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+	))
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
 func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk.Result {
 
 	token := msg.Amount.Denom
@@ -315,10 +483,31 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk
 		return types.ErrBondDoesNotExist(types.DefaultCodespace, token).Result()
 	}
 
+	// Sells of a settled bond are performed immediately in exchange for a
+	// pro-rata share of the remaining reserve
+	if bond.State == types.SettledState {
+		return handleMsgSellForSettledBond(ctx, keeper, msg)
+	}
+
 	if strings.ToLower(bond.AllowSells) == types.FALSE {
 		return types.ErrBondDoesNotAllowSelling(types.DefaultCodespace).Result()
 	}
 
+	// Check that bond is not in the hatch phase or paused
+	if bond.State == types.HatchState || !bond.IsAcceptingOrders() {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check that good-till-block has not already passed
+	if msg.GoodTillBlock != 0 && msg.GoodTillBlock < ctx.BlockHeight() {
+		return types.ErrOrderAlreadyExpired(types.DefaultCodespace, msg.GoodTillBlock).Result()
+	}
+
+	// Check min returns
+	if !bond.ReserveDenomsInclude(msg.MinReturns) {
+		return types.ErrReserveDenomsMismatch(types.DefaultCodespace, msg.MinReturns, bond.ReserveTokens).Result()
+	}
+
 	// Check if order quantity limit exceeded
 	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.Amount}) {
 		return types.ErrOrderQuantityLimitExceeded(types.DefaultCodespace).Result()
@@ -339,25 +528,102 @@ func handleMsgSell(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk
 	}
 
 	// Create order
-	order := types.NewSellOrder(msg.Seller, msg.Amount)
+	order := types.NewSellOrder(msg.Seller, msg.Amount, msg.MinReturns, msg.GoodTillBlock)
 
 	// Get sell price and check if can add sell order to batch
 	buyPrices, sellPrices, err := keeper.GetUpdatedBatchPricesAfterSell(ctx, token, order)
+	if err != nil && order.IsGoodTillBlock() && err.Code() == types.CodeMinReturnsNotMet {
+		// Good-till-block order rests until the next batch or until it expires
+		keeper.AddRestingSellOrder(ctx, token, order)
+	} else if err != nil {
+		return err.Result()
+	} else {
+		// Add sell order to batch
+		keeper.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
+
+		// Cancel unfulfillable orders
+		keeper.CancelUnfulfillableOrders(ctx, token)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSell,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Seller.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
+			sdk.NewAttribute(types.AttributeKeyGoodTillBlock, fmt.Sprintf("%d", msg.GoodTillBlock)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Seller.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgSellForSettledBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSell) sdk.Result {
+
+	token := msg.Amount.Denom
+	bond := keeper.MustGetBond(ctx, token)
+
+	// Check min returns
+	if !bond.ReserveDenomsInclude(msg.MinReturns) {
+		return types.ErrReserveDenomsMismatch(types.DefaultCodespace, msg.MinReturns, bond.ReserveTokens).Result()
+	}
+
+	// Get pro-rata share of the reserve (before the current supply is reduced)
+	returns := keeper.GetSettlementReturns(ctx, token, msg.Amount.Amount)
+	if !returns.IsAllGTE(msg.MinReturns) {
+		return types.ErrMinReturnsNotMet(types.DefaultCodespace, returns, msg.MinReturns).Result()
+	}
+
+	// Send coins to be burned from seller (enforces sellAmount <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Seller,
+		types.BondsMintBurnAccount, sdk.Coins{msg.Amount})
 	if err != nil {
 		return err.Result()
 	}
 
-	// Add sell order to batch
-	keeper.AddSellOrder(ctx, token, order, buyPrices, sellPrices)
+	// Burn bond tokens to be sold
+	err = keeper.SupplyKeeper.BurnCoins(ctx, types.BondsMintBurnAccount,
+		sdk.Coins{msg.Amount})
+	if err != nil {
+		return err.Result()
+	}
 
-	//// Cancel unfulfillable orders (Note: no need)
-	//keeper.CancelUnfulfillableOrders(ctx, token)
+	// Send share of the reserve to seller (no fees are charged)
+	if !returns.IsZero() {
+		err = keeper.WithdrawReserve(ctx, bond.Token, msg.Seller, returns)
+		if err != nil {
+			return err.Result()
+		}
+	}
+
+	// Update supply
+	keeper.SetCurrentSupply(ctx, token, bond.CurrentSupply.Sub(msg.Amount))
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("performed settlement sell for %s from %s", msg.Amount.String(), msg.Seller.String()))
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeSell,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Seller.String()),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
+			sdk.NewAttribute(types.AttributeKeyGoodTillBlock, fmt.Sprintf("%d", msg.GoodTillBlock)),
+		),
+		sdk.NewEvent(
+			types.EventTypeOrderFulfill,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.Amount.Denom),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Seller.String()),
+			sdk.NewAttribute(types.AttributeKeyTokensBurned, msg.Amount.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyReturnedToAddress, returns.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -376,6 +642,16 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondToken).Result()
 	}
 
+	// Check that bond is not paused or settled
+	if !bond.IsAcceptingOrders() {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check that swaps do not have to go through commit-reveal
+	if bond.HasCommitRevealSwaps() {
+		return types.ErrSwapsRequireCommitReveal(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
 	// Check if order quantity limit exceeded
 	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
 		return types.ErrOrderQuantityLimitExceeded(types.DefaultCodespace).Result()
@@ -389,7 +665,7 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 	}
 
 	// Create order
-	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken)
+	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken, msg.MinReturns)
 
 	// Add swap order to batch
 	keeper.AddSwapOrder(ctx, msg.BondToken, order)
@@ -401,9 +677,11 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 		sdk.NewEvent(
 			types.EventTypeSwap,
 			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Swapper.String()),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
 			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -414,3 +692,485 @@ func handleMsgSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSwap) sdk
 
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
+
+func handleMsgCommitSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCommitSwap) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// Check that bond is not paused or settled
+	if !bond.IsAcceptingOrders() {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check that bond has commit-reveal swaps enabled
+	if !bond.HasCommitRevealSwaps() {
+		return types.ErrCommitRevealSwapsNotEnabled(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// Check that deposit is in reserve tokens
+	if !bond.ReserveDenomsInclude(msg.Deposit) {
+		return types.ErrReserveDenomsMismatch(types.DefaultCodespace, msg.Deposit, bond.ReserveTokens).Result()
+	}
+
+	// Check that commitment is unique
+	if _, found := keeper.GetSwapCommit(ctx, msg.BondToken, msg.Hash); found {
+		return types.ErrSwapCommitAlreadyExists(types.DefaultCodespace, msg.Hash).Result()
+	}
+
+	// Take deposit from swapper (enforces deposit <= balance)
+	err := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Swapper,
+		types.BatchesIntermediaryAccount, msg.Deposit)
+	if err != nil {
+		return err.Result()
+	}
+
+	// Create commitment, which can be revealed within the next BatchBlocks blocks
+	revealDeadline := ctx.BlockHeight() + int64(bond.BatchBlocks.Uint64())
+	commit := types.NewSwapCommit(msg.Swapper, msg.Hash, msg.Deposit, revealDeadline)
+
+	// Add swap commitment to batch
+	keeper.AddSwapCommit(ctx, msg.BondToken, commit)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCommitSwap,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyHash, msg.Hash),
+			sdk.NewAttribute(types.AttributeKeyDeposit, msg.Deposit.String()),
+			sdk.NewAttribute(types.AttributeKeyRevealDeadline, fmt.Sprintf("%d", revealDeadline)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgRevealSwap(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgRevealSwap) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// Check that bond is not paused or settled
+	if !bond.IsAcceptingOrders() {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check that bond has commit-reveal swaps enabled
+	if !bond.HasCommitRevealSwaps() {
+		return types.ErrCommitRevealSwapsNotEnabled(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// Get commitment matching the revealed swap
+	hash := msg.GetHash()
+	commit, found := keeper.GetSwapCommit(ctx, msg.BondToken, hash)
+	if !found || !commit.Address.Equals(msg.Swapper) {
+		return types.ErrSwapCommitNotFound(types.DefaultCodespace, hash).Result()
+	}
+
+	// Check if order quantity limit exceeded
+	if bond.AnyOrderQuantityLimitsExceeded(sdk.Coins{msg.From}) {
+		return types.ErrOrderQuantityLimitExceeded(types.DefaultCodespace).Result()
+	}
+
+	// Check that coins to be swapped were deposited
+	if commit.Deposit.AmountOf(msg.From.Denom).LT(msg.From.Amount) {
+		return types.ErrSwapExceedsCommitDeposit(types.DefaultCodespace, msg.From, commit.Deposit).Result()
+	}
+
+	// Remove commitment and return unused deposit to swapper
+	keeper.RemoveSwapCommit(ctx, msg.BondToken, hash)
+	unusedDeposit := commit.Deposit.Sub(sdk.Coins{msg.From})
+	if !unusedDeposit.IsZero() {
+		err := keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, msg.Swapper, unusedDeposit)
+		if err != nil {
+			return err.Result()
+		}
+	}
+
+	// Create order (coins to be swapped are already in the intermediary account)
+	order := types.NewSwapOrder(msg.Swapper, msg.From, msg.ToToken, msg.MinReturns)
+
+	// Add swap order to batch
+	keeper.AddSwapOrder(ctx, msg.BondToken, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevealSwap,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyHash, hash),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.From.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySwapFromToken, msg.From.Denom),
+			sdk.NewAttribute(types.AttributeKeySwapToToken, msg.ToToken),
+			sdk.NewAttribute(types.AttributeKeyMinReturns, msg.MinReturns.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Swapper.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgUpdateBondState(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgUpdateBondState) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.Token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.Token).Result()
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		errMsg := fmt.Sprintf("List of signers does not match the one in the bond")
+		return sdk.ErrInternal(errMsg).Result()
+	}
+
+	// Only augmented function bonds go through the hatch/open/closed phases
+	if bond.FunctionType != types.AugmentedFunction {
+		return types.ErrFunctionNotAvailableForFunctionType(types.DefaultCodespace).Result()
+	}
+
+	if bond.State == types.HatchState && msg.State == types.OpenState {
+		// Pending buys were priced at the hatch price, so cannot open yet
+		batch := keeper.MustGetBatch(ctx, bond.Token)
+		for _, bo := range batch.Buys {
+			if !bo.IsCancelled() {
+				return types.ErrCannotChangeStateWithPendingBuys(types.DefaultCodespace).Result()
+			}
+		}
+
+		if bond.CurrentSupply.IsZero() {
+			return types.ErrFunctionRequiresNonZeroCurrentSupply(types.DefaultCodespace).Result()
+		}
+
+		// If opening before reaching the hatch supply, the curve is re-anchored
+		// to the amount that was actually raised during the hatch (d0 = p0*s)
+		if bond.CurrentSupply.IsLT(bond.GetHatchSupply()) {
+			p0 := bond.FunctionParameters.AsMap()["p0"]
+			d0 := p0.MulInt(bond.CurrentSupply.Amount)
+			bond.FunctionParameters = bond.FunctionParameters.ReplaceParam("d0", d0)
+			keeper.SetBond(ctx, bond.Token, bond)
+		}
+	} else if !(bond.State == types.OpenState && msg.State == types.ClosedState) {
+		return types.ErrInvalidStateTransition(types.DefaultCodespace, bond.State, msg.State).Result()
+	}
+
+	keeper.SetBondState(ctx, bond.Token, msg.State)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgPauseBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgPauseBond) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.Token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.Token).Result()
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		errMsg := fmt.Sprintf("List of signers does not match the one in the bond")
+		return sdk.ErrInternal(errMsg).Result()
+	}
+
+	// Only open bonds can be paused (and are resumed back to open)
+	if bond.State != types.OpenState {
+		return types.ErrInvalidStateTransition(types.DefaultCodespace, bond.State, types.PausedState).Result()
+	}
+
+	keeper.SetBondState(ctx, bond.Token, types.PausedState)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgResumeBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgResumeBond) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.Token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.Token).Result()
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		errMsg := fmt.Sprintf("List of signers does not match the one in the bond")
+		return sdk.ErrInternal(errMsg).Result()
+	}
+
+	if bond.State != types.PausedState {
+		return types.ErrInvalidStateTransition(types.DefaultCodespace, bond.State, types.OpenState).Result()
+	}
+
+	keeper.SetBondState(ctx, bond.Token, types.OpenState)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgCloseBond(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCloseBond) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.Token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.Token).Result()
+	}
+
+	if !bond.SignersEqualTo(msg.Signers) {
+		errMsg := fmt.Sprintf("List of signers does not match the one in the bond")
+		return sdk.ErrInternal(errMsg).Result()
+	}
+
+	if bond.State == types.SettledState {
+		return types.ErrInvalidStateTransition(types.DefaultCodespace, bond.State, types.SettledState).Result()
+	}
+
+	// Cancel all pending orders, so that the reserve and the current supply
+	// are final and can be used to distribute the reserve pro-rata
+	keeper.CancelAllOrders(ctx, bond.Token,
+		types.ErrOrderCancelledByBondClosure(types.DefaultCodespace))
+
+	keeper.SetBondState(ctx, bond.Token, types.SettledState)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Editor.String()),
+		),
+	)
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgSubmitBondProposal(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgSubmitBondProposal) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.Token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.Token).Result()
+	}
+
+	if bond.State == types.SettledState {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State).Result()
+	}
+
+	// Check that the changes are valid for the bond at submission time
+	if _, err := msg.Changes.ApplyTo(bond); err != nil {
+		return err.Result()
+	}
+
+	// Limit the number of proposals that are voted on at the same time
+	if keeper.GetActiveProposalCountByBond(ctx, bond.Token) >= types.MaxActiveProposalsPerBond {
+		return types.ErrTooManyActiveProposals(types.DefaultCodespace, bond.Token, types.MaxActiveProposalsPerBond).Result()
+	}
+
+	// The proposer must hold a minimum share of the total voting power, which
+	// is recorded so that the quorum is relative to the supply at submission
+	totalVotingPower := keeper.GetTotalVotingPower(ctx, bond.Token)
+	proposerPower := keeper.CoinKeeper.GetCoins(ctx, msg.Proposer).AmountOf(bond.Token)
+	if !proposerPower.IsPositive() {
+		return types.ErrNoVotingPower(types.DefaultCodespace, msg.Proposer, bond.Token).Result()
+	} else if sdk.NewDecFromInt(proposerPower).LT(types.MinProposerVotingPower.MulInt(totalVotingPower)) {
+		return types.ErrProposerVotingPowerTooLow(types.DefaultCodespace, msg.Proposer, types.MinProposerVotingPower).Result()
+	}
+
+	proposalId := keeper.GetNextProposalId(ctx)
+	proposal := types.NewBondProposal(proposalId, bond.Token, msg.Proposer,
+		msg.Description, msg.Changes, ctx.BlockHeight(), msg.VotingPeriod,
+		totalVotingPower)
+	keeper.SetProposal(ctx, proposal)
+	keeper.SetNextProposalId(ctx, proposalId+1)
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("bond proposal %d for %s submitted by %s",
+		proposalId, bond.Token, msg.Proposer.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSubmitBondProposal,
+			sdk.NewAttribute(types.AttributeKeyProposalId, fmt.Sprintf("%d", proposalId)),
+			sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
+			sdk.NewAttribute(types.AttributeKeyDescription, msg.Description),
+			sdk.NewAttribute(types.AttributeKeyVotingPeriod, msg.VotingPeriod.String()),
+			sdk.NewAttribute(types.AttributeKeyVotingPower, totalVotingPower.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Proposer.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgVoteBondProposal(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgVoteBondProposal) sdk.Result {
+
+	proposal, found := keeper.GetProposal(ctx, msg.ProposalId)
+	if !found {
+		return types.ErrBondProposalNotFound(types.DefaultCodespace, msg.ProposalId).Result()
+	}
+
+	if !proposal.IsVoting() {
+		return types.ErrBondProposalNotVoting(types.DefaultCodespace, proposal.ProposalId, proposal.Status).Result()
+	}
+
+	// Voting power is the voter's balance when voting (see Tally)
+	votingPower := keeper.CoinKeeper.GetCoins(ctx, msg.Voter).AmountOf(proposal.Token)
+	if !votingPower.IsPositive() {
+		return types.ErrNoVotingPower(types.DefaultCodespace, msg.Voter, proposal.Token).Result()
+	}
+
+	// Any previous vote (and voting power) of the voter is replaced
+	keeper.SetVotingPower(ctx, proposal.ProposalId, msg.Voter, votingPower)
+	keeper.SetVote(ctx, proposal.ProposalId, types.NewBondProposalVote(msg.Voter, msg.Option))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeVoteBondProposal,
+			sdk.NewAttribute(types.AttributeKeyProposalId, fmt.Sprintf("%d", proposal.ProposalId)),
+			sdk.NewAttribute(types.AttributeKeyBond, proposal.Token),
+			sdk.NewAttribute(types.AttributeKeyVoteOption, msg.Option),
+			sdk.NewAttribute(types.AttributeKeyVotingPower, votingPower.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Voter.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgWithdrawBondFees(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawBondFees) sdk.Result {
+
+	if !keeper.BondExists(ctx, msg.BondToken) {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// Fees can still be withdrawn once the recipient is no longer a recipient
+	fees, err := keeper.WithdrawAccruedFees(ctx, msg.BondToken, msg.Recipient)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("%s fees %s withdrawn by %s",
+		msg.BondToken, fees.String(), msg.Recipient.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeWithdrawBondFees,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, fees.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgWithdrawFundingPool(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgWithdrawFundingPool) sdk.Result {
+
+	bond, found := keeper.GetBond(ctx, msg.BondToken)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// The funding pool is withdrawn by (and to) the bond's fee address
+	if !bond.FeeAddress.Equals(msg.Recipient) {
+		return types.ErrNotFundingPoolRecipient(types.DefaultCodespace, msg.Recipient, bond.Token).Result()
+	}
+
+	withdrawn, err := keeper.WithdrawFundingPool(ctx, bond.Token, msg.Recipient)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("%s funding pool %s withdrawn by %s",
+		bond.Token, withdrawn.String(), msg.Recipient.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeWithdrawFundingPool,
+			sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, withdrawn.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Recipient.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgClaimVested(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgClaimVested) sdk.Result {
+
+	if !keeper.BondExists(ctx, msg.BondToken) {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, msg.BondToken).Result()
+	}
+
+	// Vested tokens can be claimed irrespective of the bond's state
+	claimed, err := keeper.ClaimVestedTokens(ctx, msg.BondToken, msg.Claimer)
+	if err != nil {
+		return err.Result()
+	}
+
+	logger := keeper.Logger(ctx)
+	logger.Info(fmt.Sprintf("vested %s claimed by %s",
+		claimed.String(), msg.Claimer.String()))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeClaimVested,
+			sdk.NewAttribute(types.AttributeKeyBond, msg.BondToken),
+			sdk.NewAttribute(types.AttributeKeyAddress, msg.Claimer.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, claimed.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Claimer.String()),
+		),
+	})
+
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
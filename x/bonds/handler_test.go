@@ -0,0 +1,968 @@
+package bonds
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/keeper"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-cosmos/x/did"
+)
+
+func reserveCoins(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.ValidReserveToken, amount))
+}
+
+func requireInvariantsHold(t *testing.T, ctx sdk.Context, k keeper.Keeper) {
+	msg, broken := keeper.AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+// addLinkedDid adds a DID, with a KYC-validated credential if kycValidated,
+// and links the address to it
+func addLinkedDid(t *testing.T, ctx sdk.Context, k keeper.Keeper, address sdk.AccAddress,
+	accountDid string, kycValidated bool) {
+	k.DidKeeper.AddDidDoc(ctx, did.BaseDidDoc{Did: accountDid})
+	credential := did.DidCredential{
+		CredType: []string{"Credential", "ProofOfKYC"},
+		Issuer:   "issuer",
+		Claim:    did.Claim{Id: accountDid, KYCValidated: kycValidated},
+	}
+	require.Nil(t, k.DidKeeper.AddCredentials(ctx, accountDid, credential))
+	k.DidKeeper.SetAccountDid(ctx, address, accountDid)
+}
+
+func TestHandler_BuyTransferPolicies(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000000))
+	require.Nil(t, err)
+	_, err = k.CoinKeeper.AddCoins(ctx, other, reserveCoins(1000000))
+	require.Nil(t, err)
+
+	createBond := func(token, transferPolicy string, transferAllowList []sdk.AccAddress) {
+		msg := types.ValidCreateBondMsg
+		msg.Token = token
+		msg.MaxSupply = sdk.NewInt64Coin(token, 1000000)
+		msg.Vesting = types.NoVestingConfig(token)
+		msg.TransferPolicy = transferPolicy
+		msg.TransferAllowList = transferAllowList
+		require.Nil(t, msg.ValidateBasic())
+		res := handler(ctx, msg)
+		require.True(t, res.IsOK(), res.Log)
+	}
+	buy := func(buyer sdk.AccAddress, token string) sdk.Result {
+		// (failed messages are handled in a cache context, as they would be reverted)
+		cacheCtx, write := ctx.CacheContext()
+		res := handler(cacheCtx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(1000), 0))
+		if res.IsOK() {
+			write()
+		}
+		return res
+	}
+
+	// Any address can buy the tokens of a bond with an open transfer policy
+	createBond("opn", types.OpenTransferPolicy, nil)
+	res := buy(buyer, "opn")
+	require.True(t, res.IsOK(), res.Log)
+
+	// Only addresses in the allow-list can buy the tokens of an allow_list bond
+	createBond("lst", types.AllowListTransferPolicy, []sdk.AccAddress{other})
+	res = buy(buyer, "lst")
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+	res = buy(other, "lst")
+	require.True(t, res.IsOK(), res.Log)
+
+	// Only addresses linked to a DID with a KYC-validated credential can buy
+	// the tokens of a credential_required bond
+	createBond("kyc", types.CredentialRequiredTransferPolicy, nil)
+	addLinkedDid(t, ctx, k, other, "did:ixo:other", false)
+	res = buy(other, "kyc")
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+
+	// A KYC-validated DID whose ID is the address, but which the address has
+	// not been linked to, does not allow the address to buy
+	k.DidKeeper.AddDidDoc(ctx, did.BaseDidDoc{Did: string(buyer)})
+	require.Nil(t, k.DidKeeper.AddCredentials(ctx, string(buyer), did.DidCredential{
+		CredType: []string{"Credential", "ProofOfKYC"},
+		Claim:    did.Claim{Id: string(buyer), KYCValidated: true},
+	}))
+	res = buy(buyer, "kyc")
+	require.Equal(t, types.CodeTransferNotAllowed, res.Code)
+
+	addLinkedDid(t, ctx, k, buyer, "did:ixo:buyer", true)
+	res = buy(buyer, "kyc")
+	require.True(t, res.IsOK(), res.Log)
+
+	EndBlocker(ctx, k)
+	require.Equal(t, sdk.NewInt(10), k.CoinKeeper.GetCoins(ctx, buyer).AmountOf("opn"))
+	require.Equal(t, sdk.NewInt(10), k.CoinKeeper.GetCoins(ctx, other).AmountOf("lst"))
+	require.Equal(t, sdk.NewInt(10), k.CoinKeeper.GetCoins(ctx, buyer).AmountOf("kyc"))
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_RestingOrders(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+
+	// Power function bond with price x + 1, so the reserve at supply s is
+	// s^2/2 + s
+	res := handler(ctx, types.ValidCreateBondMsg)
+	require.True(t, res.IsOK(), res.Log)
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000))
+	require.Nil(t, err)
+	_, err = k.CoinKeeper.AddCoins(ctx, other, reserveCoins(1000))
+	require.Nil(t, err)
+
+	ctx = ctx.WithBlockHeight(1)
+	res = handler(ctx, types.NewMsgBuy(other, sdk.NewInt64Coin(token, 20), reserveCoins(1000), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+
+	// Buying 10 more tokens costs r(30)-r(20) = 260, so orders with lower max
+	// prices fail, unless they are good-till-block, in which case they rest
+	// with their max prices held by the bonds module
+	ctx = ctx.WithBlockHeight(2)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(200), 0))
+	require.Equal(t, types.CodeMaxPriceExceeded, res.Code)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(200), 10))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(100), 3))
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, k.MustGetBatch(ctx, token).Buys)
+	require.Equal(t, 2, len(k.MustGetBatch(ctx, token).RestingBuys))
+	require.Equal(t, reserveCoins(700), k.CoinKeeper.GetCoins(ctx, buyer))
+
+	// Orders that are still unfulfillable keep resting in the next batch
+	EndBlocker(ctx, k)
+	require.Equal(t, 2, len(k.MustGetBatch(ctx, token).RestingBuys))
+	require.Equal(t, sdk.NewInt(20), k.MustGetBond(ctx, token).CurrentSupply.Amount)
+
+	// The order that is good till block 3 expires and its max prices are
+	// returned, while the sell makes the other order fulfillable (at r(10) = 60),
+	// so that it is added to the next batch
+	ctx = ctx.WithBlockHeight(3)
+	res = handler(ctx, types.NewMsgSell(other, sdk.NewInt64Coin(token, 20), reserveCoins(220), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, reserveCoins(800), k.CoinKeeper.GetCoins(ctx, buyer))
+	require.Empty(t, k.MustGetBatch(ctx, token).RestingBuys)
+	require.Equal(t, 1, len(k.MustGetBatch(ctx, token).Buys))
+	require.True(t, k.MustGetBond(ctx, token).CurrentSupply.IsZero())
+
+	ctx = ctx.WithBlockHeight(4)
+	EndBlocker(ctx, k)
+	require.Equal(t, reserveCoins(940).Add(sdk.NewCoins(sdk.NewInt64Coin(token, 10))),
+		k.CoinKeeper.GetCoins(ctx, buyer))
+	require.Equal(t, sdk.NewInt(10), k.MustGetBond(ctx, token).CurrentSupply.Amount)
+	requireInvariantsHold(t, ctx, k)
+}
+
+// cancelEventAddresses returns the addresses of the orders cancelled in the events
+func cancelEventAddresses(events sdk.Events) (addresses []string) {
+	for _, e := range events {
+		if e.Type != types.EventTypeOrderCancel {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if string(a.Key) == types.AttributeKeyAddress {
+				addresses = append(addresses, string(a.Value))
+			}
+		}
+	}
+	return addresses
+}
+
+func TestHandler_SellMinReturns(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	seller := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+	tokenCoins := func(amount int64) sdk.Coins {
+		return sdk.NewCoins(sdk.NewInt64Coin(token, amount))
+	}
+
+	// Power function bond with price x + 1, so the reserve at supply s is
+	// s^2/2 + s, bought up to a supply of 20 (r(20) = 220)
+	res := handler(ctx, types.ValidCreateBondMsg)
+	require.True(t, res.IsOK(), res.Log)
+	for _, buyer := range []sdk.AccAddress{seller, other} {
+		_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(110))
+		require.Nil(t, err)
+		res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(110), 0))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	EndBlocker(ctx, k)
+	require.Equal(t, tokenCoins(10), k.CoinKeeper.GetCoins(ctx, seller))
+
+	// Selling 10 tokens returns r(20)-r(10) = 160, so a sell with higher min
+	// returns is rejected when it is submitted
+	ctx = ctx.WithBlockHeight(1)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgSell(seller, sdk.NewInt64Coin(token, 10), reserveCoins(200), 0))
+	require.Equal(t, types.CodeMinReturnsNotMet, res.Code)
+
+	// Selling 5 tokens returns r(20)-r(15) = 92.5, which meets min returns of
+	// 90, until another sell of 10 tokens lowers the sell price to
+	// (r(20)-r(5))/15 = 13.5 per token, so that the first sell is cancelled
+	// and its burned tokens are re-minted
+	res = handler(ctx, types.NewMsgSell(seller, sdk.NewInt64Coin(token, 5), reserveCoins(90), 0))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, tokenCoins(5), k.CoinKeeper.GetCoins(ctx, seller))
+	res = handler(ctx, types.NewMsgSell(other, sdk.NewInt64Coin(token, 10), nil, 0))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []string{seller.String()}, cancelEventAddresses(res.Events))
+	require.Equal(t, tokenCoins(10), k.CoinKeeper.GetCoins(ctx, seller))
+	sells := k.MustGetBatch(ctx, token).Sells
+	require.Equal(t, 2, len(sells))
+	require.True(t, sells[0].IsCancelled())
+	require.NotEmpty(t, sells[0].CancelReason)
+	require.False(t, sells[1].IsCancelled())
+
+	// The other sell is performed at r(20)-r(10) = 160
+	EndBlocker(ctx, k)
+	require.Equal(t, tokenCoins(10), k.CoinKeeper.GetCoins(ctx, seller))
+	require.Equal(t, reserveCoins(160), k.CoinKeeper.GetCoins(ctx, other))
+	require.Equal(t, sdk.NewInt(10), k.MustGetBond(ctx, token).CurrentSupply.Amount)
+	requireInvariantsHold(t, ctx, k)
+
+	// Selling 5 tokens now returns r(10)-r(5) = 42.5, so a good-till-block
+	// sell with min returns of 50 rests, with its tokens still burned, until
+	// it expires and its tokens are re-minted
+	ctx = ctx.WithBlockHeight(2)
+	res = handler(ctx, types.NewMsgSell(seller, sdk.NewInt64Coin(token, 5), reserveCoins(50), 3))
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, k.MustGetBatch(ctx, token).Sells)
+	require.Equal(t, 1, len(k.MustGetBatch(ctx, token).RestingSells))
+	require.Equal(t, tokenCoins(5), k.CoinKeeper.GetCoins(ctx, seller))
+	EndBlocker(ctx, k)
+	require.Equal(t, 1, len(k.MustGetBatch(ctx, token).RestingSells))
+	requireInvariantsHold(t, ctx, k)
+
+	ctx = ctx.WithBlockHeight(3)
+	EndBlocker(ctx, k)
+	require.Empty(t, k.MustGetBatch(ctx, token).RestingSells)
+	require.Equal(t, tokenCoins(10), k.CoinKeeper.GetCoins(ctx, seller))
+	require.Equal(t, sdk.NewInt(10), k.MustGetBond(ctx, token).CurrentSupply.Amount)
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_SwapMinReturns(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	swapper := types.ValidBuyerAddress
+	reserves := sdk.NewCoins(
+		sdk.NewInt64Coin(types.ValidReserveToken, 1000), sdk.NewInt64Coin("rez", 1000))
+
+	msg := types.ValidCreateBondMsg
+	msg.FunctionType = types.SwapperFunction
+	msg.FunctionParameters = nil
+	msg.ReserveTokens = []string{types.ValidReserveToken, "rez"}
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	_, err := k.CoinKeeper.AddCoins(ctx, swapper, reserves.Add(reserves))
+	require.Nil(t, err)
+	res = handler(ctx, types.NewMsgBuy(swapper, sdk.NewInt64Coin(token, 10), reserves, 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, reserves, k.CoinKeeper.GetCoins(ctx, swapper).Sub(sdk.NewCoins(sdk.NewInt64Coin(token, 10))))
+
+	ctx = ctx.WithBlockHeight(1)
+	from := sdk.NewInt64Coin(types.ValidReserveToken, 100)
+
+	// Swapping 100 res for rez returns 1000 - 1000*1000/1100 = 90.9 rez, so the
+	// first swap, with min returns of 95 rez, is cancelled when the batch is
+	// performed and the swapped res is refunded, while the second is performed
+	res = handler(ctx, types.NewMsgSwap(swapper, token, from, "rez",
+		sdk.NewCoins(sdk.NewInt64Coin("rez", 95))))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgSwap(swapper, token, from, "rez",
+		sdk.NewCoins(sdk.NewInt64Coin("rez", 80))))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewInt(800), k.CoinKeeper.GetCoins(ctx, swapper).AmountOf(types.ValidReserveToken))
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	EndBlocker(ctx, k)
+	require.Equal(t, []string{swapper.String()}, cancelEventAddresses(ctx.EventManager().Events()))
+	swaps := k.MustGetLastBatch(ctx, token).Swaps
+	require.Equal(t, 2, len(swaps))
+	require.True(t, swaps[0].IsCancelled())
+	require.NotEmpty(t, swaps[0].CancelReason)
+	require.False(t, swaps[1].IsCancelled())
+	require.Equal(t, sdk.NewInt(900), k.CoinKeeper.GetCoins(ctx, swapper).AmountOf(types.ValidReserveToken))
+	require.Equal(t, sdk.NewInt(1090), k.CoinKeeper.GetCoins(ctx, swapper).AmountOf("rez"))
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_BuyWithBudget(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+
+	// Power function bond with price x + 1, so the reserve at supply s is
+	// s^2/2 + s, and a 10% tx fee charged on top of the price
+	msg := types.ValidCreateBondMsg
+	msg.TxFeePercentage = sdk.NewDec(10)
+	msg.MaxSupply = sdk.NewInt64Coin(token, 15)
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000))
+	require.Nil(t, err)
+
+	// A budget has to be in the reserve tokens and has to buy at least a token
+	// (failed messages are handled in a cache context, as they would be reverted)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgBuyWithBudget(buyer, token,
+		sdk.NewCoins(sdk.NewInt64Coin("other", 100))))
+	require.Equal(t, types.CodeReserveDenomsMismatch, res.Code)
+	res = handler(cacheCtx, types.NewMsgBuyWithBudget(buyer, token, reserveCoins(1)))
+	require.Equal(t, types.CodeBudgetTooSmall, res.Code)
+
+	// Excluding the fee, a budget of 70 leaves 63 for the reserve, which buys
+	// 10 tokens at r(10) = 60, and the surplus of 70-66 = 4 is returned
+	res = handler(ctx, types.NewMsgBuyWithBudget(buyer, token, reserveCoins(70)))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewInt64Coin(token, 10), k.MustGetBatch(ctx, token).TotalBuyAmount)
+	require.Equal(t, reserveCoins(930), k.CoinKeeper.GetCoins(ctx, buyer))
+	EndBlocker(ctx, k)
+	require.Equal(t, reserveCoins(934).Add(sdk.NewCoins(sdk.NewInt64Coin(token, 10))),
+		k.CoinKeeper.GetCoins(ctx, buyer))
+	require.Equal(t, reserveCoins(60), k.GetReserveBalances(ctx, token))
+
+	// A budget that covers more than the max supply only buys up to it, after
+	// which no more tokens can be bought
+	res = handler(ctx, types.NewMsgBuyWithBudget(buyer, token, reserveCoins(500)))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewInt64Coin(token, 5), k.MustGetBatch(ctx, token).TotalBuyAmount)
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgBuyWithBudget(buyer, token, reserveCoins(500)))
+	require.Equal(t, types.CodeInvalidResultantSupply, res.Code)
+	EndBlocker(ctx, k)
+	require.Equal(t, sdk.NewInt(15), k.MustGetBond(ctx, token).CurrentSupply.Amount)
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_FeeRecipients(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	creator := types.ValidCreatorAddress
+	other := types.ValidOtherAddress
+
+	// Power function bond with price x + 1 and 10% tx and exit fees, which
+	// are split 60/40 between two fee recipients
+	msg := types.ValidCreateBondMsg
+	msg.TxFeePercentage = sdk.NewDec(10)
+	msg.ExitFeePercentage = sdk.NewDec(10)
+	msg.FeeRecipients = types.FeeRecipients{
+		types.NewFeeRecipient(creator, sdk.NewDec(60)),
+		types.NewFeeRecipient(other, sdk.NewDec(40)),
+	}
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000))
+	require.Nil(t, err)
+
+	// Buying 10 tokens at r(10) = 60 charges a fee of 6, of which 3.6 is
+	// rounded down to 3 plus the remainder of 1, and 2.4 is rounded down to 2
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(100), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, reserveCoins(4), k.GetAccruedFees(ctx, token, creator))
+	require.Equal(t, reserveCoins(2), k.GetAccruedFees(ctx, token, other))
+
+	// Selling the tokens charges both fees, 12 in total, of which 7 plus the
+	// remainder of 1 and 4 accrue on top of the existing shares
+	res = handler(ctx, types.NewMsgSell(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(48), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, reserveCoins(1000-66+48), k.CoinKeeper.GetCoins(ctx, buyer))
+	require.Equal(t, []types.BondFeeAccrual{
+		types.NewBondFeeAccrual(token, creator, reserveCoins(12)),
+		types.NewBondFeeAccrual(token, other, reserveCoins(6)),
+	}, k.GetAccruedFeesByBond(ctx, token))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, msg.FeeAddress).Empty())
+	requireInvariantsHold(t, ctx, k)
+
+	// Each recipient withdraws its own share, and only addresses with accrued
+	// fees can withdraw
+	// (failed messages are handled in a cache context, as they would be reverted)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgWithdrawBondFees(buyer, token))
+	require.Equal(t, types.CodeNoAccruedFees, res.Code)
+	res = handler(ctx, types.NewMsgWithdrawBondFees(creator, token))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, reserveCoins(12), k.CoinKeeper.GetCoins(ctx, creator))
+	require.True(t, k.GetAccruedFees(ctx, token, creator).IsZero())
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgWithdrawBondFees(creator, token))
+	require.Equal(t, types.CodeNoAccruedFees, res.Code)
+	res = handler(ctx, types.NewMsgWithdrawBondFees(other, token))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, reserveCoins(6), k.CoinKeeper.GetCoins(ctx, other))
+	require.Empty(t, k.GetAccruedFeesByBond(ctx, token))
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_Vesting(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	tokens := func(amount int64) sdk.Coin { return sdk.NewInt64Coin(token, amount) }
+
+	// Tokens bought while the supply is below 20 vest over 100 blocks, with
+	// a cliff after 10 blocks
+	msg := types.ValidCreateBondMsg
+	msg.Vesting = types.NewVestingConfig(10, 100, tokens(20))
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000))
+	require.Nil(t, err)
+
+	// The first 20 tokens are locked in the vesting account, while the next
+	// 10 are bought at a supply of 20, so are not
+	ctx = ctx.WithBlockHeight(1)
+	res = handler(ctx, types.NewMsgBuy(buyer, tokens(20), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	ctx = ctx.WithBlockHeight(2)
+	res = handler(ctx, types.NewMsgBuy(buyer, tokens(10), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, sdk.NewInt(10), k.CoinKeeper.GetCoins(ctx, buyer).AmountOf(token))
+	require.Equal(t, []types.VestingSchedule{types.NewVestingSchedule(msg.Vesting, 1, tokens(20))},
+		k.GetVestingSchedules(ctx, token, buyer))
+	requireInvariantsHold(t, ctx, k)
+
+	// Nothing can be claimed before the cliff
+	// (failed messages are handled in a cache context, as they would be reverted)
+	ctx = ctx.WithBlockHeight(10)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgClaimVested(buyer, token))
+	require.Equal(t, types.CodeNoVestedTokens, res.Code)
+
+	// Tokens vest linearly from the start, so 20*10/100 = 2 are claimable at
+	// the cliff and 20*50/100 = 10 half-way, of which 8 are yet to be claimed
+	claim := func(height, claimed, balance int64) {
+		ctx = ctx.WithBlockHeight(height)
+		require.Equal(t, tokens(claimed), k.GetClaimableVestedTokens(ctx, token, buyer))
+		res := handler(ctx, types.NewMsgClaimVested(buyer, token))
+		require.True(t, res.IsOK(), res.Log)
+		require.Equal(t, sdk.NewInt(balance), k.CoinKeeper.GetCoins(ctx, buyer).AmountOf(token))
+		requireInvariantsHold(t, ctx, k)
+	}
+	claim(11, 2, 12)
+	claim(51, 8, 20)
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgClaimVested(buyer, token))
+	require.Equal(t, types.CodeNoVestedTokens, res.Code)
+
+	// Once fully vested and claimed, the schedule is removed
+	claim(200, 10, 30)
+	require.Empty(t, k.GetVestingSchedules(ctx, token, buyer))
+	require.Empty(t, k.GetVestingSchedulesByBond(ctx, token))
+}
+
+func TestHandler_BondProposals(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+	votingPeriod := sdk.NewUint(types.MinProposalVotingPeriod)
+
+	// Power function bond with price x + 1, so the reserve at supply 100 is
+	// r(100) = 5100, of which the buyer and the other address hold 60 and 40
+	res := handler(ctx, types.ValidCreateBondMsg)
+	require.True(t, res.IsOK(), res.Log)
+	for _, b := range []struct {
+		address sdk.AccAddress
+		amount  int64
+	}{{buyer, 60}, {other, 40}} {
+		_, err := k.CoinKeeper.AddCoins(ctx, b.address, reserveCoins(10000))
+		require.Nil(t, err)
+		res = handler(ctx, types.NewMsgBuy(b.address, sdk.NewInt64Coin(token, b.amount), reserveCoins(10000), 0))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	EndBlocker(ctx, k)
+
+	endVoting := func() {
+		for i := 0; i < types.MinProposalVotingPeriod; i++ {
+			ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+			EndBlocker(ctx, k)
+		}
+	}
+	vote := func(voter sdk.AccAddress, proposalId uint64, option string) {
+		res := handler(ctx, types.NewMsgVoteBondProposal(voter, proposalId, option))
+		require.True(t, res.IsOK(), res.Log)
+	}
+
+	// A proposal to lower the tx fee and one to change the curve to price
+	// 2x + 1, which would need a reserve of r(100) = 10100
+	feeChanges := types.NewBondParamChanges(types.DoNotModifyField, "5",
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField)
+	curveChanges := types.NewBondParamChanges("m:2,n:1,c:1", types.DoNotModifyField,
+		types.DoNotModifyField, types.DoNotModifyField, types.DoNotModifyField)
+	res = handler(ctx, types.NewMsgSubmitBondProposal(buyer, token, "Fee", feeChanges, votingPeriod))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgSubmitBondProposal(other, token, "Curve", curveChanges, votingPeriod))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewInt(100), k.MustGetProposal(ctx, 1).TotalVotingPower)
+
+	// Only token holders can propose and vote
+	// (failed messages are handled in a cache context, as they would be reverted)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgSubmitBondProposal(types.ValidCreatorAddress,
+		token, "Fee", feeChanges, votingPeriod))
+	require.Equal(t, types.CodeNoVotingPower, res.Code)
+	res = handler(cacheCtx, types.NewMsgVoteBondProposal(types.ValidCreatorAddress, 1, types.YesVoteOption))
+	require.Equal(t, types.CodeNoVotingPower, res.Code)
+
+	// Both proposals pass by 60 to 40, but the reserve does not cover the
+	// changed curve, so the curve proposal fails
+	vote(buyer, 1, types.YesVoteOption)
+	vote(other, 1, types.NoVoteOption)
+	vote(buyer, 2, types.YesVoteOption)
+	vote(other, 2, types.NoVoteOption)
+	endVoting()
+	require.Empty(t, k.GetActiveProposalIds(ctx))
+	proposal := k.MustGetProposal(ctx, 1)
+	require.Equal(t, types.PassedProposalStatus, proposal.Status)
+	require.Equal(t, types.NewTallyResult(sdk.NewInt(60), sdk.NewInt(40), sdk.ZeroInt()),
+		proposal.TallyResult)
+	require.Equal(t, types.FailedProposalStatus, k.MustGetProposal(ctx, 2).Status)
+	require.Empty(t, k.GetVotes(ctx, 1))
+	bond := k.MustGetBond(ctx, token)
+	require.Equal(t, sdk.NewDec(5), bond.TxFeePercentage)
+	require.Equal(t, types.ValidCreateBondMsg.FunctionParameters, bond.FunctionParameters)
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgVoteBondProposal(buyer, 1, types.YesVoteOption))
+	require.Equal(t, types.CodeProposalInvalid, res.Code)
+
+	// Tokens sent to another voter after voting are only counted for the
+	// voter that holds them at the end of the voting period, so the yes vote
+	// only counts 30 against the other address's 70
+	exitFeeChanges := types.NewBondParamChanges(types.DoNotModifyField, types.DoNotModifyField,
+		"5", types.DoNotModifyField, types.DoNotModifyField)
+	res = handler(ctx, types.NewMsgSubmitBondProposal(buyer, token, "Exit fee", exitFeeChanges, votingPeriod))
+	require.True(t, res.IsOK(), res.Log)
+	vote(buyer, 3, types.YesVoteOption)
+	err := k.CoinKeeper.SendCoins(ctx, buyer, other, sdk.NewCoins(sdk.NewInt64Coin(token, 30)))
+	require.Nil(t, err)
+	vote(other, 3, types.NoVoteOption)
+	endVoting()
+	proposal = k.MustGetProposal(ctx, 3)
+	require.Equal(t, types.RejectedProposalStatus, proposal.Status)
+	require.Equal(t, types.NewTallyResult(sdk.NewInt(30), sdk.NewInt(70), sdk.ZeroInt()),
+		proposal.TallyResult)
+	require.True(t, k.MustGetBond(ctx, token).ExitFeePercentage.IsZero())
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_OrderRecords(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+
+	// Power function bond with price x + 1, so the reserve at supply s is
+	// s^2/2 + s
+	res := handler(ctx, types.ValidCreateBondMsg)
+	require.True(t, res.IsOK(), res.Log)
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000))
+	require.Nil(t, err)
+	_, err = k.CoinKeeper.AddCoins(ctx, other, reserveCoins(1000))
+	require.Nil(t, err)
+	status := func(address sdk.AccAddress, height int64, id uint64) types.OrderRecord {
+		record, found := k.GetOrderRecord(ctx, address, token, height, id)
+		require.True(t, found)
+		return record
+	}
+
+	// A buy that can pay r(10) = 60 is pending until a second buy raises the
+	// batch price to r(20) = 220, which it cannot pay its share of, so it is
+	// cancelled, while a good-till-block buy that cannot pay that price rests
+	ctx = ctx.WithBlockHeight(1)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(60), 0))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, types.PendingOrderStatus, status(buyer, 1, 1).Status)
+	res = handler(ctx, types.NewMsgBuy(other, sdk.NewInt64Coin(token, 10), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgBuy(other, sdk.NewInt64Coin(token, 10), reserveCoins(10), 3))
+	require.True(t, res.IsOK(), res.Log)
+	record := status(buyer, 1, 1)
+	require.Equal(t, types.CancelledOrderStatus, record.Status)
+	require.NotEmpty(t, record.CancelReason)
+	require.Equal(t, types.PendingOrderStatus, status(other, 1, 2).Status)
+	require.Equal(t, types.RestingOrderStatus, status(other, 1, 3).Status)
+	require.Equal(t, types.AttributeValueBuyOrder, status(other, 1, 3).OrderType)
+
+	// Without the cancelled buy, the second buy is fulfilled at r(10)
+	EndBlocker(ctx, k)
+	record = status(other, 1, 2)
+	require.Equal(t, types.FulfilledOrderStatus, record.Status)
+	require.Equal(t, int64(1), record.FulfilledHeight)
+	require.Equal(t, reserveCoins(60), record.ChargedPrices)
+	require.Equal(t, types.RestingOrderStatus, status(other, 1, 3).Status)
+	require.Equal(t, reserveCoins(1000), k.CoinKeeper.GetCoins(ctx, buyer))
+
+	// A sell is fulfilled with its returns, while the resting buy expires and
+	// is cancelled
+	ctx = ctx.WithBlockHeight(2)
+	res = handler(ctx, types.NewMsgSell(other, sdk.NewInt64Coin(token, 10), reserveCoins(60), 0))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, types.PendingOrderStatus, status(other, 2, 4).Status)
+	EndBlocker(ctx, k)
+	record = status(other, 2, 4)
+	require.Equal(t, types.FulfilledOrderStatus, record.Status)
+	require.Equal(t, types.AttributeValueSellOrder, record.OrderType)
+	require.Equal(t, reserveCoins(60), record.Returned)
+	ctx = ctx.WithBlockHeight(3)
+	EndBlocker(ctx, k)
+	record = status(other, 1, 3)
+	require.Equal(t, types.CancelledOrderStatus, record.Status)
+	require.NotEmpty(t, record.CancelReason)
+
+	// The records of each address are listed by height
+	records, total := k.GetOrderRecords(ctx, other, token, 1, 10)
+	require.Equal(t, 3, total)
+	require.Equal(t, []uint64{2, 3, 4}, []uint64{records[0].Id, records[1].Id, records[2].Id})
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_FundingPool(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+
+	// Hatch raises d0 = 1000 at p0 = 10, so the hatch supply is 100 and the
+	// reserve function once open is r(s) = s^2/20 (since r0 = 500)
+	msg := types.ValidCreateBondMsg
+	msg.FunctionType = types.AugmentedFunction
+	msg.FunctionParameters = types.FunctionParams{
+		types.NewFunctionParam("d0", sdk.NewDec(1000)),
+		types.NewFunctionParam("p0", sdk.NewDec(10)),
+		types.NewFunctionParam("theta", sdk.NewDecWithPrec(5, 1)),
+		types.NewFunctionParam("kappa", sdk.NewDec(2)),
+	}
+	msg.ExitFeePercentage = sdk.NewDec(10)
+	msg.HatchWhitelist = []sdk.AccAddress{buyer}
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(10000))
+	require.Nil(t, err)
+
+	// Theta of the hatch price goes to the funding pool
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 100), reserveCoins(1000), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, types.OpenState, k.MustGetBond(ctx, token).State)
+	require.Equal(t, reserveCoins(500), k.GetReserveBalances(ctx, token))
+	require.Equal(t, reserveCoins(500), k.GetFundingPoolBalances(ctx, token))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, msg.FeeAddress).Empty())
+
+	// Once open, the entry tribute is charged on top of the reserve price, so
+	// that theta of what is paid still goes to the funding pool, while the
+	// reserve receives r(110)-r(100) = 105
+	// (failed messages are handled in a cache context, as they would be reverted)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(209), 0))
+	require.Equal(t, types.CodeMaxPriceExceeded, res.Code)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(210), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, sdk.NewInt(110), k.MustGetBond(ctx, token).CurrentSupply.Amount)
+	require.Equal(t, reserveCoins(605), k.GetReserveBalances(ctx, token))
+	require.Equal(t, reserveCoins(605), k.GetFundingPoolBalances(ctx, token))
+	require.Equal(t, reserveCoins(10000-1000-210), k.CoinKeeper.GetCoins(ctx, buyer).Sub(
+		sdk.NewCoins(sdk.NewInt64Coin(token, 110))))
+
+	// The exit fee of a sell is an exit tribute, taken out of the returns
+	// r(110)-r(100) = 105 and sent to the funding pool
+	res = handler(ctx, types.NewMsgSell(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(94), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, reserveCoins(500), k.GetReserveBalances(ctx, token))
+	require.Equal(t, reserveCoins(616), k.GetFundingPoolBalances(ctx, token))
+	require.Equal(t, reserveCoins(10000-1000-210+94), k.CoinKeeper.GetCoins(ctx, buyer).Sub(
+		sdk.NewCoins(sdk.NewInt64Coin(token, 100))))
+	requireInvariantsHold(t, ctx, k)
+
+	// Only the fee address can withdraw the funding pool
+	res = handler(ctx, types.NewMsgWithdrawFundingPool(buyer, token))
+	require.False(t, res.IsOK())
+	res = handler(ctx, types.NewMsgWithdrawFundingPool(msg.FeeAddress, token))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, reserveCoins(616), k.CoinKeeper.GetCoins(ctx, msg.FeeAddress))
+	require.True(t, k.GetFundingPoolBalances(ctx, token).IsZero())
+	res = handler(ctx, types.NewMsgWithdrawFundingPool(msg.FeeAddress, token))
+	require.False(t, res.IsOK())
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_CommitRevealSwaps(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	swapper := types.ValidBuyerAddress
+	reserves := sdk.NewCoins(
+		sdk.NewInt64Coin(types.ValidReserveToken, 1000), sdk.NewInt64Coin("rez", 1000))
+
+	msg := types.ValidCreateBondMsg
+	msg.FunctionType = types.SwapperFunction
+	msg.FunctionParameters = nil
+	msg.ReserveTokens = []string{types.ValidReserveToken, "rez"}
+	msg.CommitRevealSwaps = types.TRUE
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	_, err := k.CoinKeeper.AddCoins(ctx, swapper, reserves.Add(reserves))
+	require.Nil(t, err)
+	res = handler(ctx, types.NewMsgBuy(swapper, sdk.NewInt64Coin(token, 10), reserves, 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	balance := k.CoinKeeper.GetCoins(ctx, swapper)
+
+	// Swaps cannot be made without a commitment
+	// (failed messages are handled in a cache context, as they would be reverted)
+	ctx = ctx.WithBlockHeight(1)
+	from := sdk.NewInt64Coin(types.ValidReserveToken, 50)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgSwap(swapper, token, from, "rez", nil))
+	require.Equal(t, types.CodeCommitRevealSwaps, res.Code)
+	reveal := types.NewMsgRevealSwap(swapper, token, from, "rez", nil, "salt")
+	res = handler(cacheCtx, reveal)
+	require.Equal(t, types.CodeSwapCommitNotFound, res.Code)
+
+	// Commit to a swap, to one that is never revealed, and to one that
+	// exceeds its deposit, each with a deposit of 100
+	deposit := sdk.NewCoins(sdk.NewInt64Coin(types.ValidReserveToken, 100))
+	unrevealed := types.NewMsgRevealSwap(swapper, token, from, "rez", nil, "other")
+	exceeding := types.NewMsgRevealSwap(swapper, token,
+		sdk.NewInt64Coin(types.ValidReserveToken, 150), "rez", nil, "salt")
+	for _, r := range []types.MsgRevealSwap{reveal, unrevealed, exceeding} {
+		res = handler(ctx, types.NewMsgCommitSwap(swapper, token, r.GetHash(), deposit))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgCommitSwap(swapper, token, reveal.GetHash(), deposit))
+	require.Equal(t, types.CodeSwapCommitInvalid, res.Code)
+	require.Equal(t, balance.Sub(reserveCoins(300)), k.CoinKeeper.GetCoins(ctx, swapper))
+
+	// A reveal has to match the committed hash and deposit, and the unused
+	// deposit is returned once revealed
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgRevealSwap(swapper, token, from, "rez", nil, "wrong"))
+	require.Equal(t, types.CodeSwapCommitNotFound, res.Code)
+	res = handler(cacheCtx, exceeding)
+	require.Equal(t, types.CodeSwapCommitInvalid, res.Code)
+	res = handler(ctx, reveal)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, balance.Sub(reserveCoins(250)), k.CoinKeeper.GetCoins(ctx, swapper))
+	EndBlocker(ctx, k)
+	require.Equal(t, 2, len(k.MustGetBatch(ctx, token).SwapCommits))
+
+	// Unrevealed commitments are forfeited to the fee address once their
+	// reveal deadline (one batch after the commitment) is reached, while the
+	// revealed swap is performed
+	ctx = ctx.WithBlockHeight(2)
+	EndBlocker(ctx, k)
+	require.Empty(t, k.MustGetBatch(ctx, token).SwapCommits)
+	require.Equal(t, reserveCoins(200), k.CoinKeeper.GetCoins(ctx, msg.FeeAddress))
+	require.Equal(t, balance.AmountOf(types.ValidReserveToken).SubRaw(250),
+		k.CoinKeeper.GetCoins(ctx, swapper).AmountOf(types.ValidReserveToken))
+	require.True(t, k.CoinKeeper.GetCoins(ctx, swapper).AmountOf("rez").GT(balance.AmountOf("rez")))
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, unrevealed)
+	require.Equal(t, types.CodeSwapCommitNotFound, res.Code)
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_SwapsRevealedInBatchEndingBlockAreDeferred(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	swapper := types.ValidBuyerAddress
+	reserves := sdk.NewCoins(
+		sdk.NewInt64Coin(types.ValidReserveToken, 1000), sdk.NewInt64Coin("rez", 1000))
+
+	msg := types.ValidCreateBondMsg
+	msg.FunctionType = types.SwapperFunction
+	msg.FunctionParameters = nil
+	msg.ReserveTokens = []string{types.ValidReserveToken, "rez"}
+	msg.CommitRevealSwaps = types.TRUE
+	require.Nil(t, msg.ValidateBasic())
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	_, err := k.CoinKeeper.AddCoins(ctx, swapper, reserves.Add(reserves))
+	require.Nil(t, err)
+	res = handler(ctx, types.NewMsgBuy(swapper, sdk.NewInt64Coin(token, 10), reserves, 0))
+	require.True(t, res.IsOK(), res.Log)
+
+	// Commit to a swap in block 1
+	from := sdk.NewInt64Coin(types.ValidReserveToken, 100)
+	reveal := types.NewMsgRevealSwap(swapper, token, from, "rez", nil, "salt")
+	ctx = ctx.WithBlockHeight(1)
+	res = handler(ctx, types.NewMsgCommitSwap(swapper, token, reveal.GetHash(), sdk.Coins{from}))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+
+	// The swap is revealed in block 2, which ends a batch, so the last block
+	// hash used to shuffle the batch's swaps is known, and the swap is
+	// deferred to the next batch instead of being performed
+	ctx = ctx.WithBlockHeight(2)
+	res = handler(ctx, reveal)
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Empty(t, k.MustGetLastBatch(ctx, token).Swaps)
+	require.Equal(t, 1, len(k.MustGetBatch(ctx, token).Swaps))
+	require.Equal(t, sdk.NewInt(1000), k.CoinKeeper.GetCoins(ctx, swapper).AmountOf("rez"))
+	requireInvariantsHold(t, ctx, k)
+
+	// The swap is performed at the end of the next batch
+	ctx = ctx.WithBlockHeight(3)
+	EndBlocker(ctx, k)
+	require.Equal(t, 1, len(k.MustGetLastBatch(ctx, token).Swaps))
+	require.False(t, k.MustGetLastBatch(ctx, token).Swaps[0].IsCancelled())
+	require.Empty(t, k.MustGetBatch(ctx, token).Swaps)
+	require.True(t, k.CoinKeeper.GetCoins(ctx, swapper).AmountOf("rez").GT(sdk.NewInt(1000)))
+	requireInvariantsHold(t, ctx, k)
+}
+
+func TestHandler_PauseResumeAndClose(t *testing.T) {
+	ctx, k, _ := keeper.CreateTestInput()
+	handler := NewHandler(k)
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+	creator := types.ValidCreatorAddress
+	signers := []sdk.AccAddress{creator}
+	balance := func(address sdk.AccAddress) sdk.Coins {
+		return k.CoinKeeper.GetCoins(ctx, address)
+	}
+	holding := func(reserve, tokens int64) sdk.Coins {
+		return reserveCoins(reserve).Add(sdk.NewCoins(sdk.NewInt64Coin(token, tokens)))
+	}
+
+	// Power function bond with price x + 1, so the reserve at supply s is
+	// s^2/2 + s, bought up to a supply of 40 (r(40) = 840, or 21 per token)
+	res := handler(ctx, types.ValidCreateBondMsg)
+	require.True(t, res.IsOK(), res.Log)
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(1000))
+	require.Nil(t, err)
+	_, err = k.CoinKeeper.AddCoins(ctx, other, reserveCoins(1000))
+	require.Nil(t, err)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(1000), 0))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgBuy(other, sdk.NewInt64Coin(token, 30), reserveCoins(1000), 0))
+	require.True(t, res.IsOK(), res.Log)
+	EndBlocker(ctx, k)
+	require.Equal(t, holding(790, 10), balance(buyer))
+	require.Equal(t, holding(370, 30), balance(other))
+
+	// Orders are rejected while the bond is paused
+	// (failed messages are handled in a cache context, as they would be reverted)
+	ctx = ctx.WithBlockHeight(1)
+	res = handler(ctx, types.NewMsgPauseBond(token, creator, signers))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, types.PausedState, k.MustGetBond(ctx, token).State)
+	cacheCtx, _ := ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgPauseBond(token, creator, signers))
+	require.Equal(t, types.CodeInvalidStateTransition, res.Code)
+	res = handler(cacheCtx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(500), 0))
+	require.Equal(t, types.CodeInvalidStateForAction, res.Code)
+	res = handler(cacheCtx, types.NewMsgSell(other, sdk.NewInt64Coin(token, 5), nil, 0))
+	require.Equal(t, types.CodeInvalidStateForAction, res.Code)
+	EndBlocker(ctx, k)
+
+	// Orders are accepted again once the bond is resumed: a buy and a sell
+	// in the batch, and a good-till-block buy that rests
+	ctx = ctx.WithBlockHeight(2)
+	res = handler(ctx, types.NewMsgResumeBond(token, creator, signers))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, types.OpenState, k.MustGetBond(ctx, token).State)
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgResumeBond(token, creator, signers))
+	require.Equal(t, types.CodeInvalidStateTransition, res.Code)
+	res = handler(ctx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(500), 0))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgSell(other, sdk.NewInt64Coin(token, 5), nil, 0))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, types.NewMsgBuy(other, sdk.NewInt64Coin(token, 5), reserveCoins(10), 100))
+	require.True(t, res.IsOK(), res.Log)
+	batch := k.MustGetBatch(ctx, token)
+	require.Equal(t, 1, len(batch.Buys))
+	require.Equal(t, 1, len(batch.Sells))
+	require.Equal(t, 1, len(batch.RestingBuys))
+	require.Equal(t, holding(290, 10), balance(buyer))
+	require.Equal(t, holding(360, 25), balance(other))
+
+	// Closing the bond cancels every order in the batch, returning the max
+	// prices of buys and re-minting the tokens of sells
+	res = handler(ctx, types.NewMsgCloseBond(token, creator, signers))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []string{buyer.String(), other.String(), other.String()},
+		cancelEventAddresses(res.Events))
+	require.Equal(t, types.SettledState, k.MustGetBond(ctx, token).State)
+	batch = k.MustGetBatch(ctx, token)
+	require.Empty(t, batch.Buys)
+	require.Empty(t, batch.Sells)
+	require.Empty(t, batch.RestingBuys)
+	require.Equal(t, holding(790, 10), balance(buyer))
+	require.Equal(t, holding(370, 30), balance(other))
+	requireInvariantsHold(t, ctx, k)
+
+	// A settled bond cannot be closed again or accept buys
+	cacheCtx, _ = ctx.CacheContext()
+	res = handler(cacheCtx, types.NewMsgCloseBond(token, creator, signers))
+	require.Equal(t, types.CodeInvalidStateTransition, res.Code)
+	res = handler(cacheCtx, types.NewMsgBuy(buyer, sdk.NewInt64Coin(token, 10), reserveCoins(500), 0))
+	require.Equal(t, types.CodeInvalidStateForAction, res.Code)
+	EndBlocker(ctx, k)
+
+	// Sells of a settled bond are performed immediately, returning a pro-rata
+	// share of the reserve of 840 for the supply of 40 (21 per token),
+	// regardless of the order in which holders sell
+	ctx = ctx.WithBlockHeight(3)
+	sell := func(seller sdk.AccAddress, amount int64) {
+		res := handler(ctx, types.NewMsgSell(seller, sdk.NewInt64Coin(token, amount), nil, 0))
+		require.True(t, res.IsOK(), res.Log)
+		requireInvariantsHold(t, ctx, k)
+	}
+	require.Equal(t, reserveCoins(210), k.GetSettlementReturns(ctx, token, sdk.NewInt(10)))
+	sell(other, 15)
+	require.Equal(t, holding(370+315, 15), balance(other))
+	sell(buyer, 10)
+	require.Equal(t, reserveCoins(790+210), balance(buyer))
+	sell(other, 15)
+	require.Equal(t, reserveCoins(1000), balance(other))
+	require.True(t, k.MustGetBond(ctx, token).CurrentSupply.IsZero())
+	require.True(t, k.GetReserveBalances(ctx, token).IsZero())
+	EndBlocker(ctx, k)
+	requireInvariantsHold(t, ctx, k)
+}
@@ -1,9 +1,13 @@
 package keeper
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"sort"
 )
 
 func (k Keeper) MustGetBatch(ctx sdk.Context, token string) types.Batch {
@@ -49,6 +53,13 @@ func (k Keeper) SetLastBatch(ctx sdk.Context, token string, batch types.Batch) {
 }
 
 func (k Keeper) AddBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder, buyPrices, sellPrices sdk.DecCoins) {
+	// Record the order, or mark it as pending again if it was resting
+	if bo.Id == 0 {
+		k.recordNewOrder(ctx, token, types.AttributeValueBuyOrder, &bo.BaseOrder, "")
+	} else {
+		k.recordOrderStatus(ctx, token, bo.BaseOrder, types.PendingOrderStatus)
+	}
+
 	batch := k.MustGetBatch(ctx, token)
 	batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo.Amount)
 	batch.BuyPrices = buyPrices
@@ -61,6 +72,13 @@ func (k Keeper) AddBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder, bu
 }
 
 func (k Keeper) AddSellOrder(ctx sdk.Context, token string, so types.SellOrder, buyPrices, sellPrices sdk.DecCoins) {
+	// Record the order, or mark it as pending again if it was resting
+	if so.Id == 0 {
+		k.recordNewOrder(ctx, token, types.AttributeValueSellOrder, &so.BaseOrder, "")
+	} else {
+		k.recordOrderStatus(ctx, token, so.BaseOrder, types.PendingOrderStatus)
+	}
+
 	batch := k.MustGetBatch(ctx, token)
 	batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
 	batch.BuyPrices = buyPrices
@@ -73,6 +91,8 @@ func (k Keeper) AddSellOrder(ctx sdk.Context, token string, so types.SellOrder,
 }
 
 func (k Keeper) AddSwapOrder(ctx sdk.Context, token string, so types.SwapOrder) {
+	k.recordNewOrder(ctx, token, types.AttributeValueSwapOrder, &so.BaseOrder, so.ToToken)
+
 	batch := k.MustGetBatch(ctx, token)
 	batch.Swaps = append(batch.Swaps, so)
 	k.SetBatch(ctx, token, batch)
@@ -81,6 +101,54 @@ func (k Keeper) AddSwapOrder(ctx sdk.Context, token string, so types.SwapOrder)
 	logger.Info(fmt.Sprintf("added swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
 }
 
+func (k Keeper) AddRestingBuyOrder(ctx sdk.Context, token string, bo types.BuyOrder) {
+	// Record the order if it rests without having been added to a batch
+	if bo.Id == 0 {
+		k.recordNewOrder(ctx, token, types.AttributeValueBuyOrder, &bo.BaseOrder, "")
+	}
+	k.recordOrderStatus(ctx, token, bo.BaseOrder, types.RestingOrderStatus)
+
+	batch := k.MustGetBatch(ctx, token)
+	batch.RestingBuys = append(batch.RestingBuys, bo)
+	k.SetBatch(ctx, token, batch)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added resting buy order for %s from %s good till block %d",
+		bo.Amount.String(), bo.Address.String(), bo.GoodTillBlock))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderRest,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+		sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyGoodTillBlock, fmt.Sprintf("%d", bo.GoodTillBlock)),
+	))
+}
+
+func (k Keeper) AddRestingSellOrder(ctx sdk.Context, token string, so types.SellOrder) {
+	// Record the order if it rests without having been added to a batch
+	if so.Id == 0 {
+		k.recordNewOrder(ctx, token, types.AttributeValueSellOrder, &so.BaseOrder, "")
+	}
+	k.recordOrderStatus(ctx, token, so.BaseOrder, types.RestingOrderStatus)
+
+	batch := k.MustGetBatch(ctx, token)
+	batch.RestingSells = append(batch.RestingSells, so)
+	k.SetBatch(ctx, token, batch)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added resting sell order for %s from %s good till block %d",
+		so.Amount.String(), so.Address.String(), so.GoodTillBlock))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrderRest,
+		sdk.NewAttribute(types.AttributeKeyBond, token),
+		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+		sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+		sdk.NewAttribute(types.AttributeKeyGoodTillBlock, fmt.Sprintf("%d", so.GoodTillBlock)),
+	))
+}
+
 func (k Keeper) GetBatchBuySellPrices(ctx sdk.Context, token string, batch types.Batch) (buyPricesPT, sellPricesPT sdk.DecCoins, err sdk.Error) {
 	bond := k.MustGetBond(ctx, token)
 
@@ -157,6 +225,67 @@ func (k Keeper) GetUpdatedBatchPricesAfterBuy(ctx sdk.Context, token string, bo
 	return buyPrices, sellPrices, nil
 }
 
+func (k Keeper) GetMaxBuyAmountForBudget(ctx sdk.Context, token string, budget sdk.Coins) (amount sdk.Int, err sdk.Error) {
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
+
+	// Exclude the tx fee and entry tribute (charged on top of the price) from
+	// the budget
+	feeFactor := sdk.OneDec().Add(bond.TxFeePercentage.QuoInt64(100)).Add(
+		bond.GetEntryTributeRate())
+	reserveBudget := sdk.Coins{}
+	for _, b := range budget {
+		reserveBudget = reserveBudget.Add(sdk.Coins{sdk.NewCoin(b.Denom,
+			sdk.NewDecFromInt(b.Amount).Quo(feeFactor).TruncateInt())})
+	}
+
+	// Solve for the max amount that can be minted according to the function
+	amount, err = bond.GetMaxMintForBudget(reserveBudget, reserveBalances)
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+
+	// Cap amount to max supply (or hatch supply) and order quantity limit
+	supplyLimit := bond.MaxSupply.Amount
+	if bond.State == types.HatchState {
+		supplyLimit = sdk.MinInt(supplyLimit, bond.GetHatchSupply().Amount)
+	}
+	adjustedSupply := k.GetSupplyAdjustedForBuy(ctx, token)
+	if !supplyLimit.GT(adjustedSupply.Amount) {
+		return sdk.ZeroInt(), types.ErrCannotMintMoreThanMaxSupply(types.DefaultCodespace)
+	}
+	amount = sdk.MinInt(amount, supplyLimit.Sub(adjustedSupply.Amount))
+	if limit := bond.OrderQuantityLimits.AmountOf(token); limit.IsPositive() {
+		amount = sdk.MinInt(amount, limit)
+	}
+
+	// Batch prices (which also account for other orders in the batch) and
+	// rounding can differ from the function, so if the amount is not
+	// fulfillable at batch prices, search for the largest one that is
+	fulfillable := func(amount sdk.Int) bool {
+		bo := types.NewBuyOrder(nil, sdk.NewCoin(token, amount), budget, 0)
+		_, _, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+		return err == nil
+	}
+	if amount.IsPositive() && !fulfillable(amount) {
+		lo, hi := sdk.ZeroInt(), amount
+		for hi.Sub(lo).GT(sdk.OneInt()) {
+			mid := lo.Add(hi).QuoRaw(2)
+			if fulfillable(mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		amount = lo
+	}
+
+	if !amount.IsPositive() {
+		return sdk.ZeroInt(), types.ErrBudgetTooSmallToBuyAnyTokens(types.DefaultCodespace, budget, token)
+	}
+	return amount, nil
+}
+
 func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so types.SellOrder) (buyPrices, sellPrices sdk.DecCoins, err sdk.Error) {
 	batch := k.MustGetBatch(ctx, token)
 
@@ -173,6 +302,11 @@ func (k Keeper) GetUpdatedBatchPricesAfterSell(ctx sdk.Context, token string, so
 		return nil, nil, err
 	}
 
+	err = k.CheckIfSellOrderFulfillableAtPrice(ctx, token, so, sellPrices)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return buyPrices, sellPrices, nil
 }
 
@@ -186,9 +320,16 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 		return err
 	}
 
-	// Send bond tokens bought to buyer
-	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BondsMintBurnAccount, bo.Address, sdk.Coins{bo.Amount})
+	// Send bond tokens bought to buyer, or lock them in a vesting schedule
+	// if the bond's vesting applies at the supply before the buy
+	tokensVesting := sdk.NewCoin(token, sdk.ZeroInt())
+	if bond.Vesting.AppliesAt(bond.CurrentSupply) {
+		err = k.LockVestingTokens(ctx, token, bo.Address, bo.Amount)
+		tokensVesting = bo.Amount
+	} else {
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BondsMintBurnAccount, bo.Address, sdk.Coins{bo.Amount})
+	}
 	if err != nil {
 		return err
 	}
@@ -196,24 +337,40 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reservePricesRounded := types.RoundReservePrices(reservePrices)
 	txFees := bond.GetTxFees(reservePrices)
-	totalPrices := reservePricesRounded.Add(txFees)
+	entryTributes := bond.GetEntryTributes(reservePrices)
+	totalPrices := reservePricesRounded.Add(txFees).Add(entryTributes)
 
 	if totalPrices.IsAnyGT(bo.MaxPrices) {
 		return types.ErrMaxPriceExceeded(types.DefaultCodespace, totalPrices, bo.MaxPrices)
 	}
 
-	// Add new reserve to reserve address (reservePricesRounded should never be zero)
-	// TODO: investigate possibility of zero reservePricesRounded
-	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BatchesIntermediaryAccount, bond.ReserveAddress, reservePricesRounded)
+	// Split off any funding pool share (only non-zero for augmented hatches),
+	// which goes to the funding pool together with any entry tributes (only
+	// non-zero for open augmented function bonds)
+	fundingPoolShares := bond.GetFundingPoolShares(reservePricesRounded)
+	toReserve := reservePricesRounded.Sub(fundingPoolShares)
+	toFundingPool := fundingPoolShares.Add(entryTributes)
+
+	// Add new reserve to bond reserve (toReserve should never be zero)
+	// TODO: investigate possibility of zero toReserve
+	err = k.DepositReserveFromModule(ctx, token,
+		types.BatchesIntermediaryAccount, toReserve)
 	if err != nil {
 		return err
 	}
 
-	// Add charged fee to fee address
+	// Add funding pool share and entry tributes to the bond's funding pool
+	if !toFundingPool.IsZero() {
+		err = k.DepositFundingPoolFromModule(ctx, token,
+			types.BatchesIntermediaryAccount, toFundingPool)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Charge fee to fee address or fee recipients
 	if !txFees.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bond.FeeAddress, txFees)
+		err = k.ChargeFees(ctx, token, types.BatchesIntermediaryAccount, txFees)
 		if err != nil {
 			return err
 		}
@@ -241,11 +398,15 @@ func (k Keeper) PerformBuyAtPrice(ctx sdk.Context, token string, bo types.BuyOrd
 		sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
 		sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
 		sdk.NewAttribute(types.AttributeKeyTokensMinted, bo.Amount.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyTokensVesting, tokensVesting.Amount.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedPrices, reservePricesRounded.String()),
 		sdk.NewAttribute(types.AttributeKeyChargedFees, txFees.String()),
+		sdk.NewAttribute(types.AttributeKeyChargedFundingPool, toFundingPool.String()),
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, returnToBuyer.String()),
 	))
 
+	k.recordOrderFulfilled(ctx, token, bo.BaseOrder, prices, reservePricesRounded, txFees.Add(entryTributes), returnToBuyer)
+
 	return nil
 }
 
@@ -260,16 +421,33 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 	totalFees := types.AdjustFees(txFees.Add(exitFees), reserveReturnsRounded) // calculate actual total fees
 	totalReturns := reserveReturnsRounded.Sub(totalFees)                       // calculate actual reserveReturns
 
+	if !totalReturns.IsAllGTE(so.MinReturns) {
+		return types.ErrMinReturnsNotMet(types.DefaultCodespace, totalReturns, so.MinReturns)
+	}
+
 	// Send total returns to seller (totalReturns should never be zero)
 	// TODO: investigate possibility of zero totalReturns
-	err = k.CoinKeeper.SendCoins(ctx, bond.ReserveAddress, so.Address, totalReturns)
+	err = k.WithdrawReserve(ctx, token, so.Address, totalReturns)
 	if err != nil {
 		return err
 	}
 
-	// Send total fee to fee address
-	if !totalFees.IsZero() {
-		err := k.CoinKeeper.SendCoins(ctx, bond.ReserveAddress, bond.FeeAddress, totalFees)
+	// Split off any exit tributes (the exit fees of augmented function bonds),
+	// which go to the bond's funding pool
+	exitTributes := sdk.Coins{}
+	if bond.ChargesExitTributes() {
+		exitTributes = types.AdjustFees(exitFees, totalFees)
+	}
+	if !exitTributes.IsZero() {
+		err := k.DepositFundingPoolFromReserve(ctx, token, exitTributes)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Charge remaining fee to fee address or fee recipients
+	if remainingFees := totalFees.Sub(exitTributes); !remainingFees.IsZero() {
+		err := k.ChargeFeesFromReserve(ctx, token, remainingFees)
 		if err != nil {
 			return err
 		}
@@ -291,6 +469,8 @@ func (k Keeper) PerformSellAtPrice(ctx sdk.Context, token string, so types.SellO
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, totalReturns.String()),
 	))
 
+	k.recordOrderFulfilled(ctx, token, so.BaseOrder, prices, nil, totalFees, totalReturns)
+
 	return nil
 }
 
@@ -307,6 +487,11 @@ func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (
 	}
 	adjustedInput := so.Amount.Sub(txFee) // same as during GetReturnsForSwap
 
+	// Check if min returns are met
+	if !reserveReturns.IsAllGTE(so.MinReturns) {
+		return types.ErrMinReturnsNotMet(types.DefaultCodespace, reserveReturns, so.MinReturns), true
+	}
+
 	// Check if new rates violate sanity rate
 	newReserveBalances := reserveBalances.Add(sdk.Coins{adjustedInput}).Sub(reserveReturns)
 	if bond.ReservesViolateSanityRate(newReserveBalances) {
@@ -314,22 +499,21 @@ func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (
 	}
 
 	// Give resultant tokens to swapper (reserveReturns should never be zero)
-	err = k.CoinKeeper.SendCoins(ctx, bond.ReserveAddress, so.Address, reserveReturns)
+	err = k.WithdrawReserve(ctx, token, so.Address, reserveReturns)
 	if err != nil {
 		return err, false
 	}
 
 	// Add fee-reduced coins to be swapped to reserve (adjustedInput should never be zero)
-	err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-		types.BatchesIntermediaryAccount, bond.ReserveAddress, sdk.Coins{adjustedInput})
+	err = k.DepositReserveFromModule(ctx, token,
+		types.BatchesIntermediaryAccount, sdk.Coins{adjustedInput})
 	if err != nil {
 		return err, false
 	}
 
-	// Add fee (taken from swapper) to fee address
+	// Charge fee (taken from swapper) to fee address or fee recipients
 	if !txFee.IsZero() {
-		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
-			types.BatchesIntermediaryAccount, bond.FeeAddress, sdk.Coins{txFee})
+		err = k.ChargeFees(ctx, token, types.BatchesIntermediaryAccount, sdk.Coins{txFee})
 		if err != nil {
 			return err, false
 		}
@@ -349,6 +533,8 @@ func (k Keeper) PerformSwap(ctx sdk.Context, token string, so types.SwapOrder) (
 		sdk.NewAttribute(types.AttributeKeyReturnedToAddress, reserveReturns.String()),
 	))
 
+	k.recordOrderFulfilled(ctx, token, so.BaseOrder, nil, nil, sdk.Coins{txFee}, reserveReturns)
+
 	return nil, true
 }
 
@@ -394,19 +580,28 @@ func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
 	logger := ctx.Logger()
 	batch := k.MustGetBatch(ctx, token)
 
-	// Perform swaps
-	// TODO: implement swaps front-running prevention
-	for i, so := range batch.Swaps {
+	// Perform swaps (shuffled for commit-reveal bonds to prevent front-running)
+	for _, i := range k.getSwapOrderSequence(ctx, token, len(batch.Swaps)) {
+		so := batch.Swaps[i]
 		if !so.IsCancelled() {
 			err, ok := k.PerformSwap(ctx, token, so)
 			if err != nil {
 				if ok {
 					batch.Swaps[i].Cancelled = types.TRUE
 					batch.Swaps[i].CancelReason = err.Error()
+					k.recordOrderCancelled(ctx, token, so.BaseOrder, err.Error())
 
 					logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
 					logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
 
+					ctx.EventManager().EmitEvent(sdk.NewEvent(
+						types.EventTypeOrderCancel,
+						sdk.NewAttribute(types.AttributeKeyBond, token),
+						sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSwapOrder),
+						sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+						sdk.NewAttribute(types.AttributeKeyCancelReason, err.Error()),
+					))
+
 					// Return from amount to swapper
 					err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
 						types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
@@ -426,6 +621,43 @@ func (k Keeper) PerformSwapOrders(ctx sdk.Context, token string) {
 	k.SetBatch(ctx, token, batch)
 }
 
+// getSwapOrderSequence returns the order in which the n swaps in the batch are
+// performed. Swaps are performed in insertion order, unless the bond has
+// commit-reveal swaps enabled, in which case the order is a deterministic
+// shuffle seeded from the last block hash. The last block hash is already
+// known in the block that performs the batch, so swaps revealed in that block
+// are deferred to the next batch (see DeferSwapsRevealedInBlock), and the
+// seed is unknown at the time that any of the shuffled swaps were revealed.
+func (k Keeper) getSwapOrderSequence(ctx sdk.Context, token string, n int) []int {
+	sequence := make([]int, n)
+	for i := range sequence {
+		sequence[i] = i
+	}
+
+	bond := k.MustGetBond(ctx, token)
+	if !bond.HasCommitRevealSwaps() {
+		return sequence
+	}
+
+	// Sort swap indices by hash(seed|index), where seed = hash(blockHash|token)
+	hasher := sha256.New()
+	hasher.Write(ctx.BlockHeader().LastBlockId.Hash)
+	hasher.Write([]byte(token))
+	seed := hasher.Sum(nil)
+	keys := make([][]byte, n)
+	for i := range keys {
+		indexBz := make([]byte, 8)
+		binary.BigEndian.PutUint64(indexBz, uint64(i))
+		key := sha256.Sum256(append(seed, indexBz...))
+		keys[i] = key[:]
+	}
+	sort.SliceStable(sequence, func(i, j int) bool {
+		return bytes.Compare(keys[sequence[i]], keys[sequence[j]]) < 0
+	})
+
+	return sequence
+}
+
 func (k Keeper) PerformOrders(ctx sdk.Context, token string) {
 	k.PerformBuyOrders(ctx, token)
 	k.PerformSellOrders(ctx, token)
@@ -438,7 +670,8 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 	reservePrices := types.MultiplyDecCoinsByInt(prices, bo.Amount.Amount)
 	reserveRounded := types.RoundReservePrices(reservePrices)
 	txFees := bond.GetTxFees(reservePrices)
-	totalPrices := reserveRounded.Add(txFees)
+	entryTributes := bond.GetEntryTributes(reservePrices)
+	totalPrices := reserveRounded.Add(txFees).Add(entryTributes)
 
 	// Check that max prices not exceeded
 	if totalPrices.IsAnyGT(bo.MaxPrices) {
@@ -448,21 +681,51 @@ func (k Keeper) CheckIfBuyOrderFulfillableAtPrice(ctx sdk.Context, token string,
 	return nil
 }
 
+func (k Keeper) CheckIfSellOrderFulfillableAtPrice(ctx sdk.Context, token string, so types.SellOrder, prices sdk.DecCoins) sdk.Error {
+	bond := k.MustGetBond(ctx, token)
+
+	reserveReturns := types.MultiplyDecCoinsByInt(prices, so.Amount.Amount)
+	reserveReturnsRounded := types.RoundReserveReturns(reserveReturns)
+	txFees := bond.GetTxFees(reserveReturns)
+	exitFees := bond.GetExitFees(reserveReturns)
+
+	totalFees := types.AdjustFees(txFees.Add(exitFees), reserveReturnsRounded)
+	totalReturns := reserveReturnsRounded.Sub(totalFees)
+
+	// Check that min returns met
+	if !totalReturns.IsAllGTE(so.MinReturns) {
+		return types.ErrMinReturnsNotMet(types.DefaultCodespace, totalReturns, so.MinReturns)
+	}
+
+	return nil
+}
+
 func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelledOrders int) {
 	logger := k.Logger(ctx)
 	batch := k.MustGetBatch(ctx, token)
 
-	// Cancel unfulfillable buys
-	for i, bo := range batch.Buys {
+	// Cancel unfulfillable buys, or rest them if they are good-till-block
+	var buys, restingBuys []types.BuyOrder
+	for _, bo := range batch.Buys {
 		if !bo.IsCancelled() {
 			err := k.CheckIfBuyOrderFulfillableAtPrice(ctx, token, bo, batch.BuyPrices)
 			if err != nil {
-				// Cancel (important to use batch.Buys[i] and not bo!)
-				batch.Buys[i].Cancelled = types.TRUE
-				batch.Buys[i].CancelReason = err.Error()
 				batch.TotalBuyAmount = batch.TotalBuyAmount.Sub(bo.Amount)
 				cancelledOrders += 1
 
+				// Good-till-block orders are moved out of the batch's buys and
+				// rest (keeping the reserve in the intermediary account) until
+				// the next batch or until they expire
+				if bo.IsGoodTillBlock() {
+					restingBuys = append(restingBuys, bo)
+					continue
+				}
+
+				// Cancel
+				bo.Cancelled = types.TRUE
+				bo.CancelReason = err.Error()
+				k.recordOrderCancelled(ctx, token, bo.BaseOrder, bo.CancelReason)
+
 				logger.Info(fmt.Sprintf("cancelled buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
 				logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
 
@@ -482,33 +745,444 @@ func (k Keeper) CancelUnfulfillableBuys(ctx sdk.Context, token string) (cancelle
 				}
 			}
 		}
+		buys = append(buys, bo)
 	}
+	batch.Buys = buys
 
-	// Save batch and return number of cancelled orders
+	// Save batch, add resting orders, and return number of cancelled orders
 	k.SetBatch(ctx, token, batch)
+	for _, bo := range restingBuys {
+		k.AddRestingBuyOrder(ctx, token, bo)
+	}
 	return cancelledOrders
 }
 
-func (k Keeper) CancelUnfulfillableOrders(ctx sdk.Context, token string) (cancelledOrders int) {
+func (k Keeper) CancelUnfulfillableSells(ctx sdk.Context, token string) (cancelledOrders int) {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatch(ctx, token)
+
+	// Cancel unfulfillable sells, or rest them if they are good-till-block
+	var sells, restingSells []types.SellOrder
+	for _, so := range batch.Sells {
+		if !so.IsCancelled() {
+			err := k.CheckIfSellOrderFulfillableAtPrice(ctx, token, so, batch.SellPrices)
+			if err != nil {
+				batch.TotalSellAmount = batch.TotalSellAmount.Sub(so.Amount)
+				cancelledOrders += 1
+
+				// Good-till-block orders are moved out of the batch's sells and
+				// rest (with the tokens still burned) until the next batch or
+				// until they expire
+				if so.IsGoodTillBlock() {
+					restingSells = append(restingSells, so)
+					continue
+				}
+
+				// Cancel
+				so.Cancelled = types.TRUE
+				so.CancelReason = err.Error()
+				k.recordOrderCancelled(ctx, token, so.BaseOrder, so.CancelReason)
+
+				logger.Info(fmt.Sprintf("cancelled sell order for %s from %s", so.Amount.String(), so.Address.String()))
+				logger.Debug(fmt.Sprintf("cancellation reason: %s", err.Error()))
+
+				ctx.EventManager().EmitEvent(sdk.NewEvent(
+					types.EventTypeOrderCancel,
+					sdk.NewAttribute(types.AttributeKeyBond, token),
+					sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+					sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+					sdk.NewAttribute(types.AttributeKeyCancelReason, so.CancelReason),
+				))
+
+				// Re-mint burned tokens and return them to seller
+				err := k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, sdk.Coins{so.Amount})
+				if err != nil {
+					panic(err)
+				}
+				err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+					types.BondsMintBurnAccount, so.Address, sdk.Coins{so.Amount})
+				if err != nil {
+					panic(err)
+				}
+			}
+		}
+		sells = append(sells, so)
+	}
+	batch.Sells = sells
+
+	// Save batch, add resting orders, and return number of cancelled orders
+	k.SetBatch(ctx, token, batch)
+	for _, so := range restingSells {
+		k.AddRestingSellOrder(ctx, token, so)
+	}
+	return cancelledOrders
+}
+
+func (k Keeper) UpdateBatchPrices(ctx sdk.Context, token string) {
 	batch := k.MustGetBatch(ctx, token)
+	buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+	if err != nil {
+		panic(err)
+	}
+	batch.BuyPrices = buyPrices
+	batch.SellPrices = sellPrices
+	k.SetBatch(ctx, token, batch)
+}
+
+func (k Keeper) CancelUnfulfillableOrders(ctx sdk.Context, token string) (cancelledOrders int) {
 	cancelledOrders = 0
 
-	cancelledOrders += k.CancelUnfulfillableBuys(ctx, token)
-	//cancelledOrders += k.CancelUnfulfillableSells(ctx, token) // Sells always fulfillable
-	//cancelledOrders += k.CancelUnfulfillableSwaps(ctx, token) // Swaps only cancelled while they are being performed
+	// Any cancellation changes the buy and sell prices, which can make other
+	// orders unfulfillable, so this is repeated until nothing gets cancelled
+	// Note: swaps are only cancelled while they are being performed
+	for {
+		cancelledBuys := k.CancelUnfulfillableBuys(ctx, token)
+		if cancelledBuys > 0 {
+			k.UpdateBatchPrices(ctx, token)
+		}
+
+		cancelledSells := k.CancelUnfulfillableSells(ctx, token)
+		if cancelledSells > 0 {
+			k.UpdateBatchPrices(ctx, token)
+		}
+
+		if cancelledBuys+cancelledSells == 0 {
+			break
+		}
+		cancelledOrders += cancelledBuys + cancelledSells
+	}
+
+	return cancelledOrders
+}
+
+func (k Keeper) CancelExpiredRestingOrders(ctx sdk.Context, token string) (cancelledOrders int) {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatch(ctx, token)
+	height := ctx.BlockHeight()
+
+	// Cancel expired resting buys and return reserve to buyer
+	var restingBuys []types.BuyOrder
+	for _, bo := range batch.RestingBuys {
+		if !bo.HasExpired(height) {
+			restingBuys = append(restingBuys, bo)
+			continue
+		}
+		cancelledOrders += 1
+		cancelReason := types.ErrOrderExpired(types.DefaultCodespace, bo.GoodTillBlock).Error()
+		k.recordOrderCancelled(ctx, token, bo.BaseOrder, cancelReason)
+
+		logger.Info(fmt.Sprintf("cancelled expired buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderCancel,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyCancelReason, cancelReason),
+		))
+
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, bo.Address, bo.MaxPrices)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Cancel expired resting sells and re-mint the burned tokens to seller
+	var restingSells []types.SellOrder
+	for _, so := range batch.RestingSells {
+		if !so.HasExpired(height) {
+			restingSells = append(restingSells, so)
+			continue
+		}
+		cancelledOrders += 1
+		cancelReason := types.ErrOrderExpired(types.DefaultCodespace, so.GoodTillBlock).Error()
+		k.recordOrderCancelled(ctx, token, so.BaseOrder, cancelReason)
+
+		logger.Info(fmt.Sprintf("cancelled expired sell order for %s from %s", so.Amount.String(), so.Address.String()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderCancel,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyCancelReason, cancelReason),
+		))
+
+		err := k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, sdk.Coins{so.Amount})
+		if err != nil {
+			panic(err)
+		}
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BondsMintBurnAccount, so.Address, sdk.Coins{so.Amount})
+		if err != nil {
+			panic(err)
+		}
+	}
 
-	// Update buy and sell prices if any cancellation took place
+	// Save batch only if any resting order expired
 	if cancelledOrders > 0 {
-		batch = k.MustGetBatch(ctx, token) // get batch again
-		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(ctx, token, batch)
+		batch.RestingBuys = restingBuys
+		batch.RestingSells = restingSells
+		k.SetBatch(ctx, token, batch)
+	}
+	return cancelledOrders
+}
+
+func (k Keeper) CarryOverRestingOrders(ctx sdk.Context, token string, restingBuys []types.BuyOrder, restingSells []types.SellOrder) {
+	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
+
+	for _, bo := range restingBuys {
+		// Buy keeps resting (until it expires) if the bond is closed
+		if bond.State == types.ClosedState {
+			k.AddRestingBuyOrder(ctx, token, bo)
+			continue
+		}
+
+		// Buy keeps resting if it would exceed the hatch supply
+		if bond.State == types.HatchState {
+			adjustedSupply := k.GetSupplyAdjustedForBuy(ctx, token)
+			if bond.GetHatchSupply().IsLT(adjustedSupply.Add(bo.Amount)) {
+				k.AddRestingBuyOrder(ctx, token, bo)
+				continue
+			}
+		}
+
+		// Buy keeps resting if it is still not fulfillable
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+		if err != nil {
+			k.AddRestingBuyOrder(ctx, token, bo)
+			continue
+		}
+		k.AddBuyOrder(ctx, token, bo, buyPrices, sellPrices)
+		k.CancelUnfulfillableOrders(ctx, token)
+
+		logger.Info(fmt.Sprintf("resumed buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderResume,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueBuyOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, bo.Address.String()),
+		))
+	}
+
+	for _, so := range restingSells {
+		// Sell keeps resting if it is still not fulfillable
+		buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterSell(ctx, token, so)
+		if err != nil {
+			k.AddRestingSellOrder(ctx, token, so)
+			continue
+		}
+		k.AddSellOrder(ctx, token, so, buyPrices, sellPrices)
+		k.CancelUnfulfillableOrders(ctx, token)
+
+		logger.Info(fmt.Sprintf("resumed sell order for %s from %s", so.Amount.String(), so.Address.String()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderResume,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, types.AttributeValueSellOrder),
+			sdk.NewAttribute(types.AttributeKeyAddress, so.Address.String()),
+		))
+	}
+}
+
+func (k Keeper) AddSwapCommit(ctx sdk.Context, token string, sc types.SwapCommit) {
+	batch := k.MustGetBatch(ctx, token)
+	batch.SwapCommits = append(batch.SwapCommits, sc)
+	k.SetBatch(ctx, token, batch)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("added swap commitment %s with deposit %s from %s reveal deadline %d",
+		sc.Hash, sc.Deposit.String(), sc.Address.String(), sc.RevealDeadline))
+}
+
+func (k Keeper) GetSwapCommit(ctx sdk.Context, token, hash string) (types.SwapCommit, bool) {
+	batch := k.MustGetBatch(ctx, token)
+	for _, sc := range batch.SwapCommits {
+		if sc.Hash == hash {
+			return sc, true
+		}
+	}
+	return types.SwapCommit{}, false
+}
+
+func (k Keeper) RemoveSwapCommit(ctx sdk.Context, token, hash string) {
+	batch := k.MustGetBatch(ctx, token)
+	var swapCommits []types.SwapCommit
+	for _, sc := range batch.SwapCommits {
+		if sc.Hash != hash {
+			swapCommits = append(swapCommits, sc)
+		}
+	}
+	batch.SwapCommits = swapCommits
+	k.SetBatch(ctx, token, batch)
+}
+
+func (k Keeper) ForfeitExpiredSwapCommits(ctx sdk.Context, token string) (forfeitedCommits int) {
+	logger := k.Logger(ctx)
+	bond := k.MustGetBond(ctx, token)
+	batch := k.MustGetBatch(ctx, token)
+	height := ctx.BlockHeight()
+
+	// Forfeit deposits of unrevealed expired commitments to the fee address
+	var swapCommits []types.SwapCommit
+	for _, sc := range batch.SwapCommits {
+		if !sc.HasExpired(height) {
+			swapCommits = append(swapCommits, sc)
+			continue
+		}
+		forfeitedCommits += 1
+
+		logger.Info(fmt.Sprintf("forfeited swap commitment %s with deposit %s from %s",
+			sc.Hash, sc.Deposit.String(), sc.Address.String()))
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeForfeitSwap,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyAddress, sc.Address.String()),
+			sdk.NewAttribute(types.AttributeKeyHash, sc.Hash),
+			sdk.NewAttribute(types.AttributeKeyDeposit, sc.Deposit.String()),
+		))
+
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, bond.FeeAddress, sc.Deposit)
 		if err != nil {
 			panic(err)
 		}
-		batch.BuyPrices = buyPrices
-		batch.SellPrices = sellPrices
 	}
 
-	// Save batch and return number of cancelled orders
+	// Save batch only if any commitment was forfeited
+	if forfeitedCommits > 0 {
+		batch.SwapCommits = swapCommits
+		k.SetBatch(ctx, token, batch)
+	}
+	return forfeitedCommits
+}
+
+func (k Keeper) CarryOverSwapCommits(ctx sdk.Context, token string, swapCommits []types.SwapCommit) {
+	// Commitments remain revealable until their reveal deadline
+	batch := k.MustGetBatch(ctx, token)
+	batch.SwapCommits = append(batch.SwapCommits, swapCommits...)
+	k.SetBatch(ctx, token, batch)
+}
+
+// DeferSwapsRevealedInBlock removes the swaps revealed in the current block
+// from the batch of a commit-reveal bond and returns them, so that these can
+// be carried over into the next batch instead of being performed in a
+// shuffled order that was predictable when they were revealed.
+func (k Keeper) DeferSwapsRevealedInBlock(ctx sdk.Context, token string) (deferred []types.SwapOrder) {
+	bond := k.MustGetBond(ctx, token)
+	if !bond.HasCommitRevealSwaps() {
+		return nil
+	}
+
+	batch := k.MustGetBatch(ctx, token)
+	var swaps []types.SwapOrder
+	for _, so := range batch.Swaps {
+		if so.Height == ctx.BlockHeight() && !so.IsCancelled() {
+			deferred = append(deferred, so)
+		} else {
+			swaps = append(swaps, so)
+		}
+	}
+
+	// Save batch only if any swap was deferred
+	if len(deferred) > 0 {
+		batch.Swaps = swaps
+		k.SetBatch(ctx, token, batch)
+	}
+	return deferred
+}
+
+func (k Keeper) CarryOverSwapOrders(ctx sdk.Context, token string, swaps []types.SwapOrder) {
+	// Swaps keep their order records, so these are not added as new orders
+	batch := k.MustGetBatch(ctx, token)
+	batch.Swaps = append(batch.Swaps, swaps...)
 	k.SetBatch(ctx, token, batch)
-	return cancelledOrders
+}
+
+func (k Keeper) CancelAllOrders(ctx sdk.Context, token string, reason sdk.Error) {
+	logger := k.Logger(ctx)
+	batch := k.MustGetBatch(ctx, token)
+
+	emitCancel := func(orderType string, address sdk.AccAddress) {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeOrderCancel,
+			sdk.NewAttribute(types.AttributeKeyBond, token),
+			sdk.NewAttribute(types.AttributeKeyOrderType, orderType),
+			sdk.NewAttribute(types.AttributeKeyAddress, address.String()),
+			sdk.NewAttribute(types.AttributeKeyCancelReason, reason.Error()),
+		))
+	}
+
+	// Cancel buys (including resting buys) and return reserve to buyers
+	for _, bo := range append(batch.Buys, batch.RestingBuys...) {
+		if bo.IsCancelled() {
+			continue
+		}
+		logger.Info(fmt.Sprintf("cancelled buy order for %s from %s", bo.Amount.String(), bo.Address.String()))
+		emitCancel(types.AttributeValueBuyOrder, bo.Address)
+		k.recordOrderCancelled(ctx, token, bo.BaseOrder, reason.Error())
+
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, bo.Address, bo.MaxPrices)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Cancel sells (including resting sells) and re-mint the burned tokens
+	for _, so := range append(batch.Sells, batch.RestingSells...) {
+		if so.IsCancelled() {
+			continue
+		}
+		logger.Info(fmt.Sprintf("cancelled sell order for %s from %s", so.Amount.String(), so.Address.String()))
+		emitCancel(types.AttributeValueSellOrder, so.Address)
+		k.recordOrderCancelled(ctx, token, so.BaseOrder, reason.Error())
+
+		err := k.SupplyKeeper.MintCoins(ctx, types.BondsMintBurnAccount, sdk.Coins{so.Amount})
+		if err != nil {
+			panic(err)
+		}
+		err = k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BondsMintBurnAccount, so.Address, sdk.Coins{so.Amount})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Cancel swaps and return from amount to swappers
+	for _, so := range batch.Swaps {
+		if so.IsCancelled() {
+			continue
+		}
+		logger.Info(fmt.Sprintf("cancelled swap order for %s to %s from %s", so.Amount.String(), so.ToToken, so.Address.String()))
+		emitCancel(types.AttributeValueSwapOrder, so.Address)
+		k.recordOrderCancelled(ctx, token, so.BaseOrder, reason.Error())
+
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, so.Address, sdk.Coins{so.Amount})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Return deposits of unrevealed swap commitments (these are not forfeited)
+	for _, sc := range batch.SwapCommits {
+		logger.Info(fmt.Sprintf("cancelled swap commitment %s from %s", sc.Hash, sc.Address.String()))
+		emitCancel(types.AttributeValueSwapOrder, sc.Address)
+
+		err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+			types.BatchesIntermediaryAccount, sc.Address, sc.Deposit)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Replace the batch with an empty one
+	bond := k.MustGetBond(ctx, token)
+	k.SetBatch(ctx, token, types.NewBatch(token, bond.BatchBlocks))
 }
@@ -50,10 +50,262 @@ func (k Keeper) SetBond(ctx sdk.Context, token string, bond types.Bond) {
 	store.Set(types.GetBondKey(token), k.cdc.MustMarshalBinaryBare(bond))
 }
 
-func (k Keeper) GetReserveBalances(ctx sdk.Context, token string) sdk.Coins {
-	// TODO: investigate ways to prevent reserve address from being reused since this affects calculations
-	bond := k.MustGetBond(ctx, token)
-	return k.CoinKeeper.GetCoins(ctx, bond.ReserveAddress)
+func (k Keeper) GetReserveBalances(ctx sdk.Context, token string) (balances sdk.Coins) {
+	// Reserves are held in the bonds reserve account and sub-accounted by bond
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetReserveBalancesKey(token))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &balances)
+	return balances
+}
+
+func (k Keeper) SetReserveBalances(ctx sdk.Context, token string, balances sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	if balances.IsZero() {
+		store.Delete(types.GetReserveBalancesKey(token))
+		return
+	}
+	store.Set(types.GetReserveBalancesKey(token), k.cdc.MustMarshalBinaryBare(balances))
+}
+
+func (k Keeper) GetReserveBalancesIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.ReserveBalancesKeyPrefix)
+}
+
+func (k Keeper) DepositReserve(ctx sdk.Context, token string,
+	from sdk.AccAddress, amount sdk.Coins) sdk.Error {
+
+	// Send tokens to bonds reserve account
+	err := k.SupplyKeeper.SendCoinsFromAccountToModule(
+		ctx, from, types.BondsReserveAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update bond reserve
+	k.SetReserveBalances(ctx, token, k.GetReserveBalances(ctx, token).Add(amount))
+	return nil
+}
+
+func (k Keeper) DepositReserveFromModule(ctx sdk.Context, token string,
+	fromModule string, amount sdk.Coins) sdk.Error {
+
+	// Send tokens to bonds reserve account
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, fromModule, types.BondsReserveAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update bond reserve
+	k.SetReserveBalances(ctx, token, k.GetReserveBalances(ctx, token).Add(amount))
+	return nil
+}
+
+func (k Keeper) WithdrawReserve(ctx sdk.Context, token string,
+	to sdk.AccAddress, amount sdk.Coins) sdk.Error {
+
+	// A bond can never withdraw more than its own sub-account holds
+	reserveBalances := k.GetReserveBalances(ctx, token)
+	if !reserveBalances.IsAllGTE(amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf(
+			"bond reserve %s is less than %s", reserveBalances, amount))
+	}
+
+	// Send tokens from bonds reserve account
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.BondsReserveAccount, to, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update bond reserve
+	k.SetReserveBalances(ctx, token, reserveBalances.Sub(amount))
+	return nil
+}
+
+// MigrateLegacyReserves moves the reserves of bonds created with a
+// user-supplied reserve address into the bonds reserve account. Only the
+// bonds' reserve tokens are moved and the bonds' reserve addresses are
+// cleared. Where bonds share a reserve address and reserve token, the balance
+// is split between the bonds in proportion to the reserve implied by each
+// bond's curve at its current supply (see splitLegacyReserve).
+func (k Keeper) MigrateLegacyReserves(ctx sdk.Context) sdk.Error {
+	type legacyReserve struct {
+		address sdk.AccAddress
+		denom   string
+	}
+
+	// Group bonds by reserve address and reserve token, in bond order
+	var legacyBonds []types.Bond
+	var reserves []legacyReserve
+	reserveBonds := make(map[string][]types.Bond)
+	iterator := k.GetBondIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		bond := k.MustGetBondByKey(ctx, iterator.Key())
+		if bond.ReserveAddress.Empty() {
+			continue
+		}
+		legacyBonds = append(legacyBonds, bond)
+		for _, rt := range bond.ReserveTokens {
+			key := bond.ReserveAddress.String() + "/" + rt
+			if _, ok := reserveBonds[key]; !ok {
+				reserves = append(reserves, legacyReserve{bond.ReserveAddress, rt})
+			}
+			reserveBonds[key] = append(reserveBonds[key], bond)
+		}
+	}
+	iterator.Close()
+
+	for _, r := range reserves {
+		balance := k.CoinKeeper.GetCoins(ctx, r.address).AmountOf(r.denom)
+		if !balance.IsPositive() {
+			continue
+		}
+
+		bonds := reserveBonds[r.address.String()+"/"+r.denom]
+		amounts, err := splitLegacyReserve(bonds, balance)
+		if err != nil {
+			return err
+		}
+
+		for i, bond := range bonds {
+			if !amounts[i].IsPositive() {
+				continue
+			}
+			amount := sdk.Coins{sdk.NewCoin(r.denom, amounts[i])}
+			err := k.DepositReserve(ctx, bond.Token, r.address, amount)
+			if err != nil {
+				return err
+			}
+
+			logger := k.Logger(ctx)
+			logger.Info(fmt.Sprintf("migrated reserve %s of %s from %s",
+				amount.String(), bond.Token, r.address.String()))
+		}
+	}
+
+	for _, bond := range legacyBonds {
+		bond.ReserveAddress = nil
+		k.SetBond(ctx, bond.Token, bond)
+	}
+	return nil
+}
+
+// splitLegacyReserve splits the balance of a reserve token held by a legacy
+// reserve address between the bonds that share it, in proportion to the
+// reserve implied by each bond's curve at its current supply. Each share is
+// rounded down, and the remainder goes to the first bond. Swappers have no
+// curve-implied reserve, so these cannot share a reserve with other bonds.
+func splitLegacyReserve(bonds []types.Bond, balance sdk.Int) ([]sdk.Int, sdk.Error) {
+	if len(bonds) == 1 {
+		return []sdk.Int{balance}, nil
+	}
+
+	implied := make([]sdk.Dec, len(bonds))
+	totalImplied := sdk.ZeroDec()
+	for i, bond := range bonds {
+		if !bond.HasCurveImpliedReserve() {
+			return nil, types.ErrSharedReserveCannotBeSplit(
+				types.DefaultCodespace, bond.ReserveAddress, bond.Token)
+		}
+		implied[i] = bond.CurveIntegral(bond.CurrentSupply.Amount)
+		totalImplied = totalImplied.Add(implied[i])
+	}
+
+	amounts := make([]sdk.Int, len(bonds))
+	remainder := balance
+	for i := range bonds {
+		amounts[i] = sdk.ZeroInt()
+		if totalImplied.IsPositive() {
+			amounts[i] = implied[i].MulInt(balance).Quo(totalImplied).TruncateInt()
+		}
+		remainder = remainder.Sub(amounts[i])
+	}
+	amounts[0] = amounts[0].Add(remainder)
+	return amounts, nil
+}
+
+func (k Keeper) GetFundingPoolBalances(ctx sdk.Context, token string) (balances sdk.Coins) {
+	// Funding pools are held in the bonds funding pool account and
+	// sub-accounted by bond, in the same way as reserves
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetFundingPoolBalancesKey(token))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &balances)
+	return balances
+}
+
+func (k Keeper) SetFundingPoolBalances(ctx sdk.Context, token string, balances sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	if balances.IsZero() {
+		store.Delete(types.GetFundingPoolBalancesKey(token))
+		return
+	}
+	store.Set(types.GetFundingPoolBalancesKey(token), k.cdc.MustMarshalBinaryBare(balances))
+}
+
+func (k Keeper) GetFundingPoolBalancesIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.FundingPoolBalancesKeyPrefix)
+}
+
+func (k Keeper) DepositFundingPoolFromModule(ctx sdk.Context, token string,
+	fromModule string, amount sdk.Coins) sdk.Error {
+
+	// Send tokens to bonds funding pool account
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, fromModule, types.BondsFundingPoolAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update bond funding pool
+	k.SetFundingPoolBalances(ctx, token, k.GetFundingPoolBalances(ctx, token).Add(amount))
+	return nil
+}
+
+// DepositFundingPoolFromReserve moves part of the bond's reserve (such as exit
+// tributes) into the bond's funding pool
+func (k Keeper) DepositFundingPoolFromReserve(ctx sdk.Context, token string, amount sdk.Coins) sdk.Error {
+
+	// A bond can never withdraw more than its own sub-account holds
+	reserveBalances := k.GetReserveBalances(ctx, token)
+	if !reserveBalances.IsAllGTE(amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf(
+			"bond reserve %s is less than %s", reserveBalances, amount))
+	}
+
+	err := k.DepositFundingPoolFromModule(ctx, token, types.BondsReserveAccount, amount)
+	if err != nil {
+		return err
+	}
+
+	// Update bond reserve
+	k.SetReserveBalances(ctx, token, reserveBalances.Sub(amount))
+	return nil
+}
+
+// WithdrawFundingPool sends the bond's whole funding pool to an address
+func (k Keeper) WithdrawFundingPool(ctx sdk.Context, token string, to sdk.AccAddress) (sdk.Coins, sdk.Error) {
+	balances := k.GetFundingPoolBalances(ctx, token)
+	if balances.IsZero() {
+		return nil, types.ErrFundingPoolEmpty(types.DefaultCodespace, token)
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.BondsFundingPoolAccount, to, balances)
+	if err != nil {
+		return nil, err
+	}
+
+	k.SetFundingPoolBalances(ctx, token, sdk.Coins{})
+	return balances, nil
 }
 
 func (k Keeper) GetSupplyAdjustedForBuy(ctx sdk.Context, token string) sdk.Coin {
@@ -67,7 +319,8 @@ func (k Keeper) GetSupplyAdjustedForSell(ctx sdk.Context, token string) sdk.Coin
 	bond := k.MustGetBond(ctx, token)
 	batch := k.MustGetBatch(ctx, token)
 	supply := bond.CurrentSupply
-	return supply.Sub(batch.TotalSellAmount)
+	// Resting sells were also already burned, so are subtracted as well
+	return supply.Sub(batch.TotalSellAmount).Sub(batch.GetRestingSellAmount())
 }
 
 func (k Keeper) SetCurrentSupply(ctx sdk.Context, token string, currentSupply sdk.Coin) {
@@ -78,3 +331,36 @@ func (k Keeper) SetCurrentSupply(ctx sdk.Context, token string, currentSupply sd
 	bond.CurrentSupply = currentSupply
 	k.SetBond(ctx, token, bond)
 }
+
+func (k Keeper) SetBondState(ctx sdk.Context, token string, newState string) {
+	bond := k.MustGetBond(ctx, token)
+	previousState := bond.State
+	bond.State = newState
+	k.SetBond(ctx, token, bond)
+
+	logger := k.Logger(ctx)
+	logger.Info(fmt.Sprintf("updated state for %s from %s to %s",
+		bond.Token, previousState, newState))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeStateChange,
+		sdk.NewAttribute(types.AttributeKeyBond, bond.Token),
+		sdk.NewAttribute(types.AttributeKeyOldState, previousState),
+		sdk.NewAttribute(types.AttributeKeyNewState, newState),
+	))
+}
+
+func (k Keeper) GetSettlementReturns(ctx sdk.Context, token string, amount sdk.Int) sdk.Coins {
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
+
+	// Pro-rata share of each reserve token: amount * balance / currentSupply
+	var returns sdk.Coins
+	for _, rt := range bond.ReserveTokens {
+		share := reserveBalances.AmountOf(rt).Mul(amount).Quo(bond.CurrentSupply.Amount)
+		if share.IsPositive() {
+			returns = returns.Add(sdk.Coins{sdk.NewCoin(rt, share)})
+		}
+	}
+	return returns
+}
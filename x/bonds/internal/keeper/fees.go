@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func (k Keeper) GetAccruedFees(ctx sdk.Context, token string, recipient sdk.AccAddress) (fees sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAccruedFeesKey(token, recipient))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &fees)
+	return fees
+}
+
+func (k Keeper) SetAccruedFees(ctx sdk.Context, token string, recipient sdk.AccAddress, fees sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	if fees.IsZero() {
+		store.Delete(types.GetAccruedFeesKey(token, recipient))
+		return
+	}
+	store.Set(types.GetAccruedFeesKey(token, recipient), k.cdc.MustMarshalBinaryBare(fees))
+}
+
+func (k Keeper) GetAccruedFeesIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.AccruedFeesKeyPrefix)
+}
+
+func (k Keeper) GetAccruedFeesByBond(ctx sdk.Context, token string) []types.BondFeeAccrual {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetAccruedFeesPrefix(token)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var accruals []types.BondFeeAccrual
+	for ; iterator.Valid(); iterator.Next() {
+		var fees sdk.Coins
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &fees)
+		recipient := sdk.AccAddress(iterator.Key()[len(prefix):])
+		accruals = append(accruals, types.NewBondFeeAccrual(token, recipient, fees))
+	}
+	return accruals
+}
+
+// ChargeFees sends the fees from a module account to the bond's fee address
+// or, if the bond has fee recipients, to the bonds fees account, where each
+// recipient's share accrues until it is withdrawn
+func (k Keeper) ChargeFees(ctx sdk.Context, token string, fromModule string, fees sdk.Coins) sdk.Error {
+	bond := k.MustGetBond(ctx, token)
+
+	if len(bond.FeeRecipients) == 0 {
+		return k.SupplyKeeper.SendCoinsFromModuleToAccount(
+			ctx, fromModule, bond.FeeAddress, fees)
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(
+		ctx, fromModule, types.BondsFeesAccount, fees)
+	if err != nil {
+		return err
+	}
+
+	shares := bond.FeeRecipients.Split(fees)
+	for i, fr := range bond.FeeRecipients {
+		if shares[i].IsZero() {
+			continue
+		}
+		accrued := k.GetAccruedFees(ctx, token, fr.Address)
+		k.SetAccruedFees(ctx, token, fr.Address, accrued.Add(shares[i]))
+	}
+	return nil
+}
+
+// ChargeFeesFromReserve takes the fees out of the bond's reserve and charges
+// them in the same way as ChargeFees
+func (k Keeper) ChargeFeesFromReserve(ctx sdk.Context, token string, fees sdk.Coins) sdk.Error {
+
+	// A bond can never withdraw more than its own sub-account holds
+	reserveBalances := k.GetReserveBalances(ctx, token)
+	if !reserveBalances.IsAllGTE(fees) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf(
+			"bond reserve %s is less than %s", reserveBalances, fees))
+	}
+
+	err := k.ChargeFees(ctx, token, types.BondsReserveAccount, fees)
+	if err != nil {
+		return err
+	}
+
+	// Update bond reserve
+	k.SetReserveBalances(ctx, token, reserveBalances.Sub(fees))
+	return nil
+}
+
+func (k Keeper) WithdrawAccruedFees(ctx sdk.Context, token string, recipient sdk.AccAddress) (sdk.Coins, sdk.Error) {
+	fees := k.GetAccruedFees(ctx, token, recipient)
+	if fees.IsZero() {
+		return nil, types.ErrNoAccruedFees(types.DefaultCodespace, token, recipient)
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(
+		ctx, types.BondsFeesAccount, recipient, fees)
+	if err != nil {
+		return nil, err
+	}
+
+	k.SetAccruedFees(ctx, token, recipient, sdk.Coins{})
+	return fees, nil
+}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"encoding/binary"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"sort"
+)
+
+func (k Keeper) GetPriceHistoryCounter(ctx sdk.Context, token string) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetPriceHistoryCounterKey(token))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) AddPriceHistoryEntry(ctx sdk.Context, token string, entry types.PriceHistoryEntry) {
+	store := ctx.KVStore(k.storeKey)
+
+	// Entries are kept in a ring buffer, so the oldest entry gets overwritten
+	counter := k.GetPriceHistoryCounter(ctx, token)
+	slot := counter % types.PriceHistoryLength
+	store.Set(types.GetPriceHistoryKey(token, slot), k.cdc.MustMarshalBinaryBare(entry))
+	store.Set(types.GetPriceHistoryCounterKey(token), sdk.Uint64ToBigEndian(counter+1))
+}
+
+// SetPriceHistory sets the counter and the most recent entries (sorted by
+// height) of a bond's price history, with the entries placed in the ring
+// buffer slots that they would have been added to
+func (k Keeper) SetPriceHistory(ctx sdk.Context, token string, counter uint64, entries []types.PriceHistoryEntry) {
+	store := ctx.KVStore(k.storeKey)
+	first := counter - uint64(len(entries))
+	for i, entry := range entries {
+		slot := (first + uint64(i)) % types.PriceHistoryLength
+		store.Set(types.GetPriceHistoryKey(token, slot), k.cdc.MustMarshalBinaryBare(entry))
+	}
+	store.Set(types.GetPriceHistoryCounterKey(token), sdk.Uint64ToBigEndian(counter))
+}
+
+func (k Keeper) RecordPriceHistory(ctx sdk.Context, token string, batch types.Batch) {
+	bond := k.MustGetBond(ctx, token)
+	reserveBalances := k.GetReserveBalances(ctx, token)
+	k.AddPriceHistoryEntry(ctx, token, types.NewPriceHistoryEntry(
+		ctx.BlockHeight(), ctx.BlockHeader().Time, batch,
+		bond.CurrentSupply, reserveBalances))
+}
+
+// GetPriceHistory returns the entries recorded between the two heights
+// (inclusive), sorted by height. A toHeight of 0 means no upper bound.
+func (k Keeper) GetPriceHistory(ctx sdk.Context, token string, fromHeight, toHeight int64) []types.PriceHistoryEntry {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetPriceHistoryPrefix(token))
+	defer iterator.Close()
+
+	entries := []types.PriceHistoryEntry{}
+	for ; iterator.Valid(); iterator.Next() {
+		var entry types.PriceHistoryEntry
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &entry)
+		if entry.Height < fromHeight || (toHeight != 0 && entry.Height > toHeight) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	// Slots are not in chronological order once the ring buffer wraps around
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Height < entries[j].Height
+	})
+	return entries
+}
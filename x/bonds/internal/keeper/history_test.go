@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func TestPriceHistory(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	length := int64(types.PriceHistoryLength)
+
+	newEntry := func(token string, height int64) types.PriceHistoryEntry {
+		return types.NewPriceHistoryEntry(height, time.Unix(height, 0).UTC(),
+			types.NewBatch(token, sdk.OneUint()), sdk.NewInt64Coin(token, height),
+			sdk.NewCoins(sdk.NewInt64Coin(types.ValidReserveToken, height)))
+	}
+	entries := func(token string, fromHeight, toHeight int64) (entries []types.PriceHistoryEntry) {
+		for h := fromHeight; h <= toHeight; h++ {
+			entries = append(entries, newEntry(token, h))
+		}
+		return entries
+	}
+
+	// Another bond whose token starts with the same letters keeps its own history
+	for _, entry := range entries("abcd", 1, 3) {
+		k.AddPriceHistoryEntry(ctx, "abcd", entry)
+	}
+
+	// Until the buffer is full, all entries are kept
+	require.Equal(t, uint64(0), k.GetPriceHistoryCounter(ctx, "abc"))
+	require.Empty(t, k.GetPriceHistory(ctx, "abc", 0, 0))
+	for _, entry := range entries("abc", 1, length) {
+		k.AddPriceHistoryEntry(ctx, "abc", entry)
+	}
+	require.Equal(t, uint64(length), k.GetPriceHistoryCounter(ctx, "abc"))
+	require.Equal(t, entries("abc", 1, length), k.GetPriceHistory(ctx, "abc", 0, 0))
+
+	// Once it is full, the counter keeps counting while the slots wrap around,
+	// so that the oldest entries are overwritten by the newest
+	for _, entry := range entries("abc", length+1, length+10) {
+		k.AddPriceHistoryEntry(ctx, "abc", entry)
+	}
+	require.Equal(t, uint64(length+10), k.GetPriceHistoryCounter(ctx, "abc"))
+	require.Equal(t, entries("abc", 11, length+10), k.GetPriceHistory(ctx, "abc", 0, 0))
+
+	// Ranges are sorted by height, also across the slot at which the buffer
+	// wraps around, and are limited to the entries that are still kept
+	require.Equal(t, entries("abc", length-5, length+5),
+		k.GetPriceHistory(ctx, "abc", length-5, length+5))
+	require.Equal(t, entries("abc", length+8, length+10),
+		k.GetPriceHistory(ctx, "abc", length+8, 0))
+	require.Equal(t, entries("abc", 11, 20), k.GetPriceHistory(ctx, "abc", 1, 20))
+	require.Empty(t, k.GetPriceHistory(ctx, "abc", 1, 10))
+	require.Empty(t, k.GetPriceHistory(ctx, "abc", length+11, 0))
+
+	require.Equal(t, uint64(3), k.GetPriceHistoryCounter(ctx, "abcd"))
+	require.Equal(t, entries("abcd", 1, 3), k.GetPriceHistory(ctx, "abcd", 0, 0))
+}
@@ -16,6 +16,14 @@ func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 		SupplyInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "bonds-reserve",
 		ReserveInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-reserve-custody",
+		ReserveCustodyInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-fee-custody",
+		FeeCustodyInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-vesting-custody",
+		VestingCustodyInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "bonds-funding-pool-custody",
+		FundingPoolCustodyInvariant(k))
 }
 
 // AllInvariants runs all invariants of the bonds module.
@@ -25,7 +33,23 @@ func AllInvariants(k Keeper) sdk.Invariant {
 		if stop {
 			return res, stop
 		}
-		return ReserveInvariant(k)(ctx)
+		res, stop = ReserveInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = ReserveCustodyInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = FeeCustodyInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = VestingCustodyInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return FundingPoolCustodyInvariant(k)(ctx)
 	}
 }
 
@@ -58,6 +82,10 @@ func SupplyInvariant(k Keeper) sdk.Invariant {
 						s.Amount)
 				}
 			}
+			for _, s := range batch.RestingSells {
+				supplyInBondsAndBatches = supplyInBondsAndBatches.Sub(
+					s.Amount)
+			}
 
 			// Check that amount matches supply in accounts
 			inAccounts := supplyInAccounts.AmountOf(bond.Token)
@@ -87,8 +115,12 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			bond := k.MustGetBondByKey(ctx, iterator.Key())
 			denom := bond.Token
 
-			if bond.FunctionType == types.SwapperFunction {
-				continue // Check does not apply to swapper function
+			if bond.FunctionType == types.SwapperFunction ||
+				bond.FunctionType == types.WeightedSwapperFunction {
+				continue // Check does not apply to swapper functions
+			}
+			if bond.State == types.SettledState {
+				continue // Check does not apply to settled bonds
 			}
 
 			expectedReserve := bond.CurveIntegral(bond.CurrentSupply.Amount)
@@ -112,3 +144,111 @@ func ReserveInvariant(k Keeper) sdk.Invariant {
 			"%d Bonds reserve invariants broken\n%s", count, msg)), broken
 	}
 }
+
+func ReserveCustodyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+
+		// Get sum of all bond reserves
+		sumOfReserves := sdk.Coins{}
+		iterator := k.GetReserveBalancesIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			var balances sdk.Coins
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &balances)
+			sumOfReserves = sumOfReserves.Add(balances)
+		}
+
+		// Check that sum matches coins held by the bonds reserve account
+		reserveAccAddr := k.SupplyKeeper.GetModuleAddress(types.BondsReserveAccount)
+		inReserveAcc := k.CoinKeeper.GetCoins(ctx, reserveAccAddr)
+
+		// (Coins.IsEqual panics on mismatching denoms, so compare both ways)
+		broken := !sumOfReserves.IsAllGTE(inReserveAcc) ||
+			!inReserveAcc.IsAllGTE(sumOfReserves)
+		return sdk.FormatInvariant(types.ModuleName, "reserve custody", fmt.Sprintf(
+			"\tsum of bond reserves: %s\n"+
+				"\tcoins in %s: %s\n", sumOfReserves.String(),
+			types.BondsReserveAccount, inReserveAcc.String())), broken
+	}
+}
+
+func FeeCustodyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+
+		// Get sum of all fees accrued to fee recipients
+		sumOfAccruedFees := sdk.Coins{}
+		iterator := k.GetAccruedFeesIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			var fees sdk.Coins
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &fees)
+			sumOfAccruedFees = sumOfAccruedFees.Add(fees)
+		}
+
+		// Check that sum matches coins held by the bonds fees account
+		feesAccAddr := k.SupplyKeeper.GetModuleAddress(types.BondsFeesAccount)
+		inFeesAcc := k.CoinKeeper.GetCoins(ctx, feesAccAddr)
+
+		// (Coins.IsEqual panics on mismatching denoms, so compare both ways)
+		broken := !sumOfAccruedFees.IsAllGTE(inFeesAcc) ||
+			!inFeesAcc.IsAllGTE(sumOfAccruedFees)
+		return sdk.FormatInvariant(types.ModuleName, "fee custody", fmt.Sprintf(
+			"\tsum of accrued fees: %s\n"+
+				"\tcoins in %s: %s\n", sumOfAccruedFees.String(),
+			types.BondsFeesAccount, inFeesAcc.String())), broken
+	}
+}
+
+func VestingCustodyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+
+		// Get sum of all bond tokens locked in vesting schedules
+		sumOfUnclaimed := sdk.Coins{}
+		iterator := k.GetVestingSchedulesIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			var schedules []types.VestingSchedule
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &schedules)
+			for _, s := range schedules {
+				sumOfUnclaimed = sumOfUnclaimed.Add(sdk.NewCoins(s.Unclaimed()))
+			}
+		}
+
+		// Check that sum matches coins held by the bonds vesting account
+		// (the locked tokens are still counted by the supply invariant,
+		// since the vesting account is one of the accounts iterated over)
+		vestingAccAddr := k.SupplyKeeper.GetModuleAddress(types.BondsVestingAccount)
+		inVestingAcc := k.CoinKeeper.GetCoins(ctx, vestingAccAddr)
+
+		// (Coins.IsEqual panics on mismatching denoms, so compare both ways)
+		broken := !sumOfUnclaimed.IsAllGTE(inVestingAcc) ||
+			!inVestingAcc.IsAllGTE(sumOfUnclaimed)
+		return sdk.FormatInvariant(types.ModuleName, "vesting custody", fmt.Sprintf(
+			"\tsum of unclaimed vesting tokens: %s\n"+
+				"\tcoins in %s: %s\n", sumOfUnclaimed.String(),
+			types.BondsVestingAccount, inVestingAcc.String())), broken
+	}
+}
+
+func FundingPoolCustodyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+
+		// Get sum of all bond funding pools
+		sumOfFundingPools := sdk.Coins{}
+		iterator := k.GetFundingPoolBalancesIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			var balances sdk.Coins
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &balances)
+			sumOfFundingPools = sumOfFundingPools.Add(balances)
+		}
+
+		// Check that sum matches coins held by the bonds funding pool account
+		fundingPoolAccAddr := k.SupplyKeeper.GetModuleAddress(types.BondsFundingPoolAccount)
+		inFundingPoolAcc := k.CoinKeeper.GetCoins(ctx, fundingPoolAccAddr)
+
+		// (Coins.IsEqual panics on mismatching denoms, so compare both ways)
+		broken := !sumOfFundingPools.IsAllGTE(inFundingPoolAcc) ||
+			!inFundingPoolAcc.IsAllGTE(sumOfFundingPools)
+		return sdk.FormatInvariant(types.ModuleName, "funding pool custody", fmt.Sprintf(
+			"\tsum of bond funding pools: %s\n"+
+				"\tcoins in %s: %s\n", sumOfFundingPools.String(),
+			types.BondsFundingPoolAccount, inFundingPoolAcc.String())), broken
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/ixofoundation/ixo-cosmos/x/did"
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -17,6 +18,7 @@ type Keeper struct {
 	SupplyKeeper  supply.Keeper
 	accountKeeper auth.AccountKeeper
 	StakingKeeper staking.Keeper
+	DidKeeper     did.Keeper
 
 	storeKey sdk.StoreKey
 
@@ -25,18 +27,39 @@ type Keeper struct {
 
 func NewKeeper(coinKeeper bank.Keeper, supplyKeeper supply.Keeper,
 	accountKeeper auth.AccountKeeper, stakingKeeper staking.Keeper,
-	storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+	didKeeper did.Keeper, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
 
 	// ensure batches module account is set
 	if addr := supplyKeeper.GetModuleAddress(types.BatchesIntermediaryAccount); addr == nil {
 		panic(fmt.Sprintf("%s module account has not been set", types.BatchesIntermediaryAccount))
 	}
 
+	// ensure reserve module account is set
+	if addr := supplyKeeper.GetModuleAddress(types.BondsReserveAccount); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.BondsReserveAccount))
+	}
+
+	// ensure fees module account is set
+	if addr := supplyKeeper.GetModuleAddress(types.BondsFeesAccount); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.BondsFeesAccount))
+	}
+
+	// ensure vesting module account is set
+	if addr := supplyKeeper.GetModuleAddress(types.BondsVestingAccount); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.BondsVestingAccount))
+	}
+
+	// ensure funding pool module account is set
+	if addr := supplyKeeper.GetModuleAddress(types.BondsFundingPoolAccount); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.BondsFundingPoolAccount))
+	}
+
 	return Keeper{
 		CoinKeeper:    coinKeeper,
 		SupplyKeeper:  supplyKeeper,
 		accountKeeper: accountKeeper,
 		StakingKeeper: stakingKeeper,
+		DidKeeper:     didKeeper,
 		storeKey:      storeKey,
 		cdc:           cdc,
 	}
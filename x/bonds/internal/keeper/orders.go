@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"encoding/binary"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func (k Keeper) GetNextOrderId(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.NextOrderIdKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) SetNextOrderId(ctx sdk.Context, orderId uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextOrderIdKey, sdk.Uint64ToBigEndian(orderId))
+}
+
+func (k Keeper) GetOrderRecord(ctx sdk.Context, address sdk.AccAddress, token string, height int64, orderId uint64) (record types.OrderRecord, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetOrderRecordKey(address, token, height, orderId))
+	if bz == nil {
+		return types.OrderRecord{}, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &record)
+	return record, true
+}
+
+func (k Keeper) MustGetOrderRecordByKey(ctx sdk.Context, key []byte) types.OrderRecord {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(key) {
+		panic("order record not found")
+	}
+	bz := store.Get(key)
+	var record types.OrderRecord
+	k.cdc.MustUnmarshalBinaryBare(bz, &record)
+	return record
+}
+
+func (k Keeper) SetOrderRecord(ctx sdk.Context, record types.OrderRecord) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetOrderRecordKey(record.Address, record.Token, record.Height, record.Id)
+	store.Set(key, k.cdc.MustMarshalBinaryBare(record))
+}
+
+func (k Keeper) GetOrderRecordsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.OrderRecordsKeyPrefix)
+}
+
+// GetOrderRecordsByAddressIterator iterates over the order records of an
+// address, optionally limited to a bond token, ordered by token and height
+func (k Keeper) GetOrderRecordsByAddressIterator(ctx sdk.Context, address sdk.AccAddress, token string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetOrderRecordsPrefix(address)
+	if token != "" {
+		prefix = types.GetOrderRecordsByBondPrefix(address, token)
+	}
+	return sdk.KVStorePrefixIterator(store, prefix)
+}
+
+// GetOrderRecords returns the specified page of the order records of an
+// address (optionally limited to a bond token) and the total number of records
+func (k Keeper) GetOrderRecords(ctx sdk.Context, address sdk.AccAddress, token string, page, limit int) (records []types.OrderRecord, total int) {
+	start := (page - 1) * limit
+	end := start + limit
+
+	iterator := k.GetOrderRecordsByAddressIterator(ctx, address, token)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if total >= start && total < end {
+			var record types.OrderRecord
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &record)
+			records = append(records, record)
+		}
+		total++
+	}
+	return records, total
+}
+
+// recordNewOrder assigns an ID and height to an order that is added to a
+// batch for the first time, and records the order as pending
+func (k Keeper) recordNewOrder(ctx sdk.Context, token, orderType string, order *types.BaseOrder, toToken string) {
+	order.Id = k.GetNextOrderId(ctx)
+	order.Height = ctx.BlockHeight()
+	k.SetNextOrderId(ctx, order.Id+1)
+	k.SetOrderRecord(ctx, types.NewOrderRecord(token, orderType, *order, toToken))
+}
+
+// updateOrderRecord applies the update to the record of the order. Orders
+// added to batches before order records were introduced have no record.
+func (k Keeper) updateOrderRecord(ctx sdk.Context, token string, order types.BaseOrder, update func(record *types.OrderRecord)) {
+	if order.Id == 0 {
+		return
+	}
+	record, found := k.GetOrderRecord(ctx, order.Address, token, order.Height, order.Id)
+	if !found {
+		return
+	}
+	update(&record)
+	k.SetOrderRecord(ctx, record)
+}
+
+func (k Keeper) recordOrderStatus(ctx sdk.Context, token string, order types.BaseOrder, status string) {
+	k.updateOrderRecord(ctx, token, order, func(record *types.OrderRecord) {
+		record.Status = status
+	})
+}
+
+func (k Keeper) recordOrderCancelled(ctx sdk.Context, token string, order types.BaseOrder, cancelReason string) {
+	k.updateOrderRecord(ctx, token, order, func(record *types.OrderRecord) {
+		record.Status = types.CancelledOrderStatus
+		record.CancelReason = cancelReason
+	})
+}
+
+func (k Keeper) recordOrderFulfilled(ctx sdk.Context, token string, order types.BaseOrder,
+	prices sdk.DecCoins, chargedPrices, chargedFees, returned sdk.Coins) {
+	k.updateOrderRecord(ctx, token, order, func(record *types.OrderRecord) {
+		record.Status = types.FulfilledOrderStatus
+		record.FulfilledHeight = ctx.BlockHeight()
+		record.Prices = prices
+		record.ChargedPrices = chargedPrices
+		record.ChargedFees = chargedFees
+		record.Returned = returned
+	})
+}
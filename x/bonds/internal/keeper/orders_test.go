@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func TestGetOrderRecords(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+
+	newRecord := func(address sdk.AccAddress, token string, height int64, id uint64) types.OrderRecord {
+		order := types.NewBaseOrder(address, sdk.NewInt64Coin(token, 10))
+		order.Height = height
+		order.Id = id
+		record := types.NewOrderRecord(token, types.AttributeValueBuyOrder, order, "")
+		k.SetOrderRecord(ctx, record)
+		return record
+	}
+	abcd10 := newRecord(buyer, "abcd", 10, 1)
+	abc1 := newRecord(buyer, "abc", 1, 2)
+	abcd2 := newRecord(buyer, "abcd", 2, 3)
+	other1 := newRecord(other, "abcd", 1, 4)
+
+	found, ok := k.GetOrderRecord(ctx, buyer, "abcd", 2, 3)
+	require.True(t, ok)
+	require.Equal(t, abcd2, found)
+	_, ok = k.GetOrderRecord(ctx, other, "abcd", 2, 3)
+	require.False(t, ok)
+
+	// Records are ordered by token and then by height, and pages start from 1
+	records, total := k.GetOrderRecords(ctx, buyer, "", 1, 2)
+	require.Equal(t, []types.OrderRecord{abc1, abcd2}, records)
+	require.Equal(t, 3, total)
+	records, total = k.GetOrderRecords(ctx, buyer, "", 2, 2)
+	require.Equal(t, []types.OrderRecord{abcd10}, records)
+	require.Equal(t, 3, total)
+	records, total = k.GetOrderRecords(ctx, buyer, "", 3, 2)
+	require.Empty(t, records)
+	require.Equal(t, 3, total)
+
+	// Records can be limited to a bond token, which does not include the
+	// records of tokens that it is a prefix of
+	records, total = k.GetOrderRecords(ctx, buyer, "abcd", 1, 10)
+	require.Equal(t, []types.OrderRecord{abcd2, abcd10}, records)
+	require.Equal(t, 2, total)
+	records, total = k.GetOrderRecords(ctx, buyer, "abc", 1, 10)
+	require.Equal(t, []types.OrderRecord{abc1}, records)
+	require.Equal(t, 1, total)
+	records, total = k.GetOrderRecords(ctx, other, "", 1, 10)
+	require.Equal(t, []types.OrderRecord{other1}, records)
+	require.Equal(t, 1, total)
+}
@@ -0,0 +1,310 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func (k Keeper) GetNextProposalId(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.NextProposalIdKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) SetNextProposalId(ctx sdk.Context, proposalId uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.NextProposalIdKey, sdk.Uint64ToBigEndian(proposalId))
+}
+
+func (k Keeper) GetProposalIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.ProposalsKeyPrefix)
+}
+
+func (k Keeper) GetActiveProposalIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.ActiveProposalsKeyPrefix)
+}
+
+func (k Keeper) GetActiveProposalIds(ctx sdk.Context) (proposalIds []uint64) {
+	iterator := k.GetActiveProposalIterator(ctx)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[len(types.ActiveProposalsKeyPrefix):]
+		proposalIds = append(proposalIds, binary.BigEndian.Uint64(key))
+	}
+	return proposalIds
+}
+
+func (k Keeper) GetProposal(ctx sdk.Context, proposalId uint64) (proposal types.BondProposal, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetProposalKey(proposalId))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposal)
+	return proposal, true
+}
+
+func (k Keeper) MustGetProposal(ctx sdk.Context, proposalId uint64) types.BondProposal {
+	proposal, found := k.GetProposal(ctx, proposalId)
+	if !found {
+		panic(fmt.Sprintf("bond proposal %d not found\n", proposalId))
+	}
+	return proposal
+}
+
+func (k Keeper) MustGetProposalByKey(ctx sdk.Context, key []byte) types.BondProposal {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(key) {
+		panic("bond proposal not found")
+	}
+	bz := store.Get(key)
+	var proposal types.BondProposal
+	k.cdc.MustUnmarshalBinaryBare(bz, &proposal)
+	return proposal
+}
+
+func (k Keeper) SetProposal(ctx sdk.Context, proposal types.BondProposal) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProposalKey(proposal.ProposalId), k.cdc.MustMarshalBinaryBare(proposal))
+
+	// Only proposals that are still being voted on are kept in the index
+	if proposal.IsVoting() {
+		store.Set(types.GetActiveProposalKey(proposal.ProposalId), []byte{})
+	} else {
+		store.Delete(types.GetActiveProposalKey(proposal.ProposalId))
+	}
+}
+
+func (k Keeper) GetProposalsByBond(ctx sdk.Context, token string) []types.BondProposal {
+	iterator := k.GetProposalIterator(ctx)
+	defer iterator.Close()
+
+	proposals := []types.BondProposal{}
+	for ; iterator.Valid(); iterator.Next() {
+		var proposal types.BondProposal
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &proposal)
+		if proposal.Token == token {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals
+}
+
+func (k Keeper) GetVotingPower(ctx sdk.Context, proposalId uint64, voter sdk.AccAddress) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetVotingPowerKey(proposalId, voter))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var power sdk.Int
+	k.cdc.MustUnmarshalBinaryBare(bz, &power)
+	return power
+}
+
+func (k Keeper) SetVotingPower(ctx sdk.Context, proposalId uint64, voter sdk.AccAddress, power sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetVotingPowerKey(proposalId, voter), k.cdc.MustMarshalBinaryBare(power))
+}
+
+// GetTotalVotingPower returns the amount of the bond's tokens that can vote,
+// which is the current supply less the tokens held by the bonds module's
+// accounts (i.e. locked vesting tokens and tokens of pending sell orders)
+func (k Keeper) GetTotalVotingPower(ctx sdk.Context, token string) sdk.Int {
+	bond := k.MustGetBond(ctx, token)
+	total := bond.CurrentSupply.Amount
+	for _, moduleAccount := range []string{
+		types.BatchesIntermediaryAccount, types.BondsVestingAccount} {
+		moduleAccAddr := k.SupplyKeeper.GetModuleAddress(moduleAccount)
+		total = total.Sub(k.CoinKeeper.GetCoins(ctx, moduleAccAddr).AmountOf(token))
+	}
+	if total.IsNegative() {
+		return sdk.ZeroInt()
+	}
+	return total
+}
+
+// GetActiveProposalCountByBond returns the number of the bond's proposals
+// that are still being voted on
+func (k Keeper) GetActiveProposalCountByBond(ctx sdk.Context, token string) (count int) {
+	for _, proposalId := range k.GetActiveProposalIds(ctx) {
+		if k.MustGetProposal(ctx, proposalId).Token == token {
+			count++
+		}
+	}
+	return count
+}
+
+func (k Keeper) GetVote(ctx sdk.Context, proposalId uint64, voter sdk.AccAddress) (vote types.BondProposalVote, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetProposalVoteKey(proposalId, voter))
+	if bz == nil {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &vote)
+	return vote, true
+}
+
+func (k Keeper) SetVote(ctx sdk.Context, proposalId uint64, vote types.BondProposalVote) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProposalVoteKey(proposalId, vote.Voter), k.cdc.MustMarshalBinaryBare(vote))
+}
+
+func (k Keeper) GetVotes(ctx sdk.Context, proposalId uint64) []types.BondProposalVote {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetProposalVotesPrefix(proposalId))
+	defer iterator.Close()
+
+	votes := []types.BondProposalVote{}
+	for ; iterator.Valid(); iterator.Next() {
+		var vote types.BondProposalVote
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+// Tally returns the votes of the proposal weighted by the voting powers
+// recorded when the votes were cast. A voter that no longer holds as many
+// tokens only has the tokens still held counted, so that tokens transferred
+// to another voter after voting are not counted twice.
+func (k Keeper) Tally(ctx sdk.Context, proposalId uint64) types.TallyResult {
+	proposal := k.MustGetProposal(ctx, proposalId)
+	result := types.EmptyTallyResult()
+	for _, vote := range k.GetVotes(ctx, proposalId) {
+		balance := k.CoinKeeper.GetCoins(ctx, vote.Voter).AmountOf(proposal.Token)
+		power := sdk.MinInt(k.GetVotingPower(ctx, proposalId, vote.Voter), balance)
+		switch vote.Option {
+		case types.YesVoteOption:
+			result.Yes = result.Yes.Add(power)
+		case types.NoVoteOption:
+			result.No = result.No.Add(power)
+		case types.AbstainVoteOption:
+			result.Abstain = result.Abstain.Add(power)
+		}
+	}
+	return result
+}
+
+func (k Keeper) deleteVotesAndVotingPowers(ctx sdk.Context, proposalId uint64) {
+	store := ctx.KVStore(k.storeKey)
+	for _, prefix := range [][]byte{
+		types.GetProposalVotesPrefix(proposalId),
+		types.GetVotingPowersPrefix(proposalId)} {
+
+		// Collect keys first since deleting while iterating is not safe
+		var keys [][]byte
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, iterator.Key())
+		}
+		iterator.Close()
+
+		for _, key := range keys {
+			store.Delete(key)
+		}
+	}
+}
+
+// ApplyBondParamChanges applies the changes to the bond and re-prices the
+// current batch. Nothing is changed if an error is returned, including if
+// the changed bond cannot be priced (e.g. if its prices overflow), since
+// nothing is written before the cached context and the recover below turns
+// any such panic into an error.
+func (k Keeper) ApplyBondParamChanges(ctx sdk.Context, token string, changes types.BondParamChanges) (err sdk.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = types.ErrBondParamChangesCannotBeApplied(types.DefaultCodespace, fmt.Sprintf("%v", r))
+		}
+	}()
+
+	bond, found := k.GetBond(ctx, token)
+	if !found {
+		return types.ErrBondDoesNotExist(types.DefaultCodespace, token)
+	} else if bond.State == types.SettledState {
+		return types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State)
+	}
+
+	bond, err = changes.ApplyTo(bond)
+	if err != nil {
+		return err
+	}
+
+	// Curve changes must keep the reserve enough to buy back the supply
+	if changes.FunctionParameters != types.DoNotModifyField &&
+		bond.FunctionType != types.SwapperFunction &&
+		bond.FunctionType != types.WeightedSwapperFunction {
+		required := bond.CurveIntegral(bond.CurrentSupply.Amount).Ceil().TruncateInt()
+		reserve := k.GetReserveBalances(ctx, token)
+		for _, r := range bond.ReserveTokens {
+			if reserve.AmountOf(r).LT(required) {
+				requiredCoins, _ := bond.GetNewReserveDecCoins(
+					sdk.NewDecFromInt(required)).TruncateDecimal()
+				return types.ErrReserveDoesNotCoverChangedCurve(
+					types.DefaultCodespace, requiredCoins, reserve)
+			}
+		}
+	}
+
+	// Prices are changed in a cached context so that nothing is written if
+	// the changed bond cannot price the current batch
+	cacheCtx, writeCache := ctx.CacheContext()
+	k.SetBond(cacheCtx, token, bond)
+	batch := k.MustGetBatch(cacheCtx, token)
+	if !batch.TotalBuyAmount.IsZero() || !batch.TotalSellAmount.IsZero() {
+		buyPrices, sellPrices, err := k.GetBatchBuySellPrices(cacheCtx, token, batch)
+		if err != nil {
+			return err
+		}
+		batch.BuyPrices = buyPrices
+		batch.SellPrices = sellPrices
+		k.SetBatch(cacheCtx, token, batch)
+		k.CancelUnfulfillableOrders(cacheCtx, token)
+	}
+	writeCache()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+	return nil
+}
+
+// EndProposal tallies the votes of the proposal, applies its changes if it
+// passed and records the final status of the proposal
+func (k Keeper) EndProposal(ctx sdk.Context, proposal types.BondProposal) types.BondProposal {
+	logger := k.Logger(ctx)
+
+	proposal.TallyResult = k.Tally(ctx, proposal.ProposalId)
+	proposal.Status = types.RejectedProposalStatus
+	failReason := ""
+	if proposal.TallyResult.Passes(proposal.TotalVotingPower) {
+		err := k.ApplyBondParamChanges(ctx, proposal.Token, proposal.Changes)
+		if err != nil {
+			proposal.Status = types.FailedProposalStatus
+			failReason = err.Error()
+		} else {
+			proposal.Status = types.PassedProposalStatus
+		}
+	}
+
+	k.SetProposal(ctx, proposal)
+	k.deleteVotesAndVotingPowers(ctx, proposal.ProposalId)
+
+	logger.Info(fmt.Sprintf("bond proposal %d for %s ended with status %s",
+		proposal.ProposalId, proposal.Token, proposal.Status))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeEndBondProposal,
+		sdk.NewAttribute(types.AttributeKeyProposalId, fmt.Sprintf("%d", proposal.ProposalId)),
+		sdk.NewAttribute(types.AttributeKeyBond, proposal.Token),
+		sdk.NewAttribute(types.AttributeKeyProposalStatus, proposal.Status),
+		sdk.NewAttribute(types.AttributeKeyFailReason, failReason),
+	))
+
+	return proposal
+}
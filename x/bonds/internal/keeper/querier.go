@@ -6,11 +6,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"strconv"
 	"strings"
 )
 
 const (
 	QueryBonds          = "bonds"
+	QueryBondsDetailed  = "bonds_detailed"
 	QueryBond           = "bond"
 	QueryBatch          = "batch"
 	QueryLastBatch      = "last_batch"
@@ -20,6 +22,16 @@ const (
 	QueryBuyPrice       = "buy_price"
 	QuerySellReturn     = "sell_return"
 	QuerySwapReturn     = "swap_return"
+	QueryState          = "state"
+	QueryPoolBalances   = "pool_balances"
+	QueryRestingOrders  = "resting_orders"
+	QueryPriceHistory   = "price_history"
+	QueryBondProposals  = "bond_proposals"
+	QueryBondProposal   = "bond_proposal"
+	QueryAccruedFees    = "accrued_fees"
+	QuerySimulateBatch  = "simulate_batch"
+	QueryVesting        = "vesting"
+	QueryOrders         = "orders"
 )
 
 // NewQuerier is the module level router for state queries
@@ -28,6 +40,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 		switch path[0] {
 		case QueryBonds:
 			return queryBonds(ctx, keeper)
+		case QueryBondsDetailed:
+			return queryBondsDetailed(ctx, keeper)
 		case QueryBond:
 			return queryBond(ctx, path[1:], keeper)
 		case QueryBatch:
@@ -46,6 +60,26 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return querySellReturn(ctx, path[1:], keeper)
 		case QuerySwapReturn:
 			return querySwapReturn(ctx, path[1:], keeper)
+		case QueryState:
+			return queryState(ctx, path[1:], keeper)
+		case QueryPoolBalances:
+			return queryPoolBalances(ctx, path[1:], keeper)
+		case QueryRestingOrders:
+			return queryRestingOrders(ctx, path[1:], keeper)
+		case QueryPriceHistory:
+			return queryPriceHistory(ctx, path[1:], keeper)
+		case QueryBondProposals:
+			return queryBondProposals(ctx, path[1:], keeper)
+		case QueryBondProposal:
+			return queryBondProposal(ctx, path[1:], keeper)
+		case QueryAccruedFees:
+			return queryAccruedFees(ctx, path[1:], keeper)
+		case QuerySimulateBatch:
+			return querySimulateBatch(ctx, path[1:], req, keeper)
+		case QueryVesting:
+			return queryVesting(ctx, path[1:], keeper)
+		case QueryOrders:
+			return queryOrders(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown bonds query endpoint")
 		}
@@ -69,6 +103,27 @@ func queryBonds(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
 	return bz, nil
 }
 
+func queryBondsDetailed(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	var bondsList types.QueryBondsDetailed
+	iterator := keeper.GetBondIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		var bond types.Bond
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &bond)
+		bondsList = append(bondsList, types.BondDetails{
+			Token:         bond.Token,
+			State:         bond.State,
+			CurrentSupply: bond.CurrentSupply,
+		})
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, bondsList)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 func queryBond(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
 	bondToken := path[0]
 
@@ -145,12 +200,11 @@ func queryCurrentPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byt
 func queryCurrentReserve(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
 	bondToken := path[0]
 
-	bond, found := keeper.GetBond(ctx, bondToken)
-	if !found {
+	if !keeper.BondExists(ctx, bondToken) {
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
 	}
 
-	reserveBalances := keeper.CoinKeeper.GetCoins(ctx, bond.ReserveAddress)
+	reserveBalances := keeper.GetReserveBalances(ctx, bondToken)
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, reserveBalances)
 	if err2 != nil {
 		panic("could not marshal result to JSON")
@@ -218,7 +272,8 @@ func queryBuyPrice(ctx sdk.Context, path []string, keeper Keeper) (res []byte, e
 	result.AdjustedSupply = adjustedSupply
 	result.Prices = reservePricesRounded
 	result.TxFees = txFee
-	result.TotalFees = result.TxFees // used in next line
+	result.EntryTributes = bond.GetEntryTributes(reservePrices)
+	result.TotalFees = result.TxFees.Add(result.EntryTributes) // used in next line
 	result.TotalPrices = result.Prices.Add(result.TotalFees)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
@@ -247,6 +302,10 @@ func querySellReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 		return nil, types.ErrBondDoesNotAllowSelling(types.DefaultCodespace)
 	}
 
+	if bond.State == types.HatchState {
+		return nil, types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State)
+	}
+
 	// Cannot burn more tokens than what exists
 	adjustedSupply := keeper.GetSupplyAdjustedForSell(ctx, bondToken)
 	if adjustedSupply.IsLT(bondCoin) {
@@ -310,3 +369,272 @@ func querySwapReturn(ctx sdk.Context, path []string, keeper Keeper) (res []byte,
 
 	return bz, nil
 }
+
+func queryState(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+
+	bond, found := keeper.GetBond(ctx, bondToken)
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	var result types.QueryBondState
+	result.State = bond.State
+	result.CurrentSupply = bond.CurrentSupply
+	if bond.FunctionType == types.AugmentedFunction {
+		result.HatchSupply = bond.GetHatchSupply()
+	} else {
+		result.HatchSupply = sdk.NewCoin(bond.Token, sdk.ZeroInt())
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryPoolBalances(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	var result types.QueryPoolBalances
+	result.ReserveBalances = keeper.GetReserveBalances(ctx, bondToken)
+	result.FundingPoolBalances = keeper.GetFundingPoolBalances(ctx, bondToken)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryRestingOrders(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	address, err2 := sdk.AccAddressFromBech32(path[0])
+	if err2 != nil {
+		return nil, sdk.ErrInvalidAddress(err2.Error())
+	}
+
+	// Collect the address' resting orders from the current batch of every bond
+	restingOrders := types.QueryRestingOrders{
+		Buys:  []types.BuyOrder{},
+		Sells: []types.SellOrder{},
+	}
+	iterator := keeper.GetBondIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		bond := keeper.MustGetBondByKey(ctx, iterator.Key())
+		batch := keeper.MustGetBatch(ctx, bond.Token)
+		for _, bo := range batch.RestingBuys {
+			if bo.Address.Equals(address) {
+				restingOrders.Buys = append(restingOrders.Buys, bo)
+			}
+		}
+		for _, so := range batch.RestingSells {
+			if so.Address.Equals(address) {
+				restingOrders.Sells = append(restingOrders.Sells, so)
+			}
+		}
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, restingOrders)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryPriceHistory(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+	fromHeightStr := path[1]
+	toHeightStr := path[2]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	fromHeight, err2 := strconv.ParseInt(fromHeightStr, 10, 64)
+	if err2 != nil || fromHeight < 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid from height '%s'", fromHeightStr))
+	}
+
+	toHeight, err2 := strconv.ParseInt(toHeightStr, 10, 64)
+	if err2 != nil || toHeight < 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid to height '%s'", toHeightStr))
+	}
+
+	priceHistory := types.QueryPriceHistory(
+		keeper.GetPriceHistory(ctx, bondToken, fromHeight, toHeight))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, priceHistory)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBondProposals(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	proposals := types.QueryBondProposals(keeper.GetProposalsByBond(ctx, bondToken))
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposals)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryBondProposal(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	proposalIdStr := path[0]
+
+	proposalId, err2 := strconv.ParseUint(proposalIdStr, 10, 64)
+	if err2 != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid proposal id '%s'", proposalIdStr))
+	}
+
+	proposal, found := keeper.GetProposal(ctx, proposalId)
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond proposal '%d' does not exist", proposalId))
+	}
+
+	// Live tally while voting, since votes are only kept until the end
+	if proposal.IsVoting() {
+		proposal.TallyResult = keeper.Tally(ctx, proposalId)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposal)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryAccruedFees(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+	recipientStr := path[1]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	recipient, err2 := sdk.AccAddressFromBech32(recipientStr)
+	if err2 != nil {
+		return nil, sdk.ErrInvalidAddress(err2.Error())
+	}
+
+	accruedFees := keeper.GetAccruedFees(ctx, bondToken, recipient)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, accruedFees)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func querySimulateBatch(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	// Hypothetical orders are optional
+	var params types.QuerySimulateBatchParams
+	if len(req.Data) != 0 {
+		err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+		if err2 != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("failed to parse params: %s", err2))
+		}
+	}
+
+	result, err := keeper.SimulateBatch(ctx, bondToken, params)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryVesting(ctx sdk.Context, path []string, keeper Keeper) (res []byte, err sdk.Error) {
+	bondToken := path[0]
+	holderStr := path[1]
+
+	if !keeper.BondExists(ctx, bondToken) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", bondToken))
+	}
+
+	holder, err2 := sdk.AccAddressFromBech32(holderStr)
+	if err2 != nil {
+		return nil, sdk.ErrInvalidAddress(err2.Error())
+	}
+
+	vesting := types.QueryVesting{
+		Schedules: keeper.GetVestingSchedules(ctx, bondToken, holder),
+		Claimable: keeper.GetClaimableVestedTokens(ctx, bondToken, holder),
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, vesting)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+func queryOrders(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	addressStr := path[0]
+
+	address, err2 := sdk.AccAddressFromBech32(addressStr)
+	if err2 != nil {
+		return nil, sdk.ErrInvalidAddress(err2.Error())
+	}
+
+	// Bond token and pagination are optional
+	var params types.QueryOrdersParams
+	if len(req.Data) != 0 {
+		err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+		if err2 != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("failed to parse params: %s", err2))
+		}
+	}
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.Limit <= 0 {
+		params.Limit = types.DefaultOrdersQueryLimit
+	}
+
+	if params.Token != "" && !keeper.BondExists(ctx, params.Token) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("bond '%s' does not exist", params.Token))
+	}
+
+	var orders types.QueryOrders
+	orders.Orders, orders.Total = keeper.GetOrderRecords(
+		ctx, address, params.Token, params.Page, params.Limit)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, orders)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"strings"
+)
+
+// simulatedOrderRef identifies the record of an order in a simulated batch
+type simulatedOrderRef struct {
+	orderType    string
+	order        types.BaseOrder
+	hypothetical bool
+}
+
+// SimulateBatch returns what would happen to the orders in the bond's current
+// batch, together with the hypothetical orders, if the batch were performed
+// now. The batch is performed by the end-blocker's own logic on a cache of
+// the state that is never written, and the outcome of each order is read from
+// its order record in the cache, so nothing is written to state.
+func (k Keeper) SimulateBatch(ctx sdk.Context, token string, params types.QuerySimulateBatchParams) (result types.QuerySimulateBatch, err sdk.Error) {
+	bond := k.MustGetBond(ctx, token)
+	if !bond.IsAcceptingOrders() {
+		return result, types.ErrInvalidStateForAction(types.DefaultCodespace, bond.State)
+	}
+
+	// Hypothetical orders can be anything, so any panic while performing the
+	// batch is returned as an error rather than failing the query
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdk.ErrInternal(fmt.Sprintf("batch cannot be simulated: %v", r))
+		}
+	}()
+
+	// Changes to the cache context are never written
+	cacheCtx, _ := ctx.CacheContext()
+
+	// Add the hypothetical orders to the current batch
+	batch := k.MustGetBatch(cacheCtx, token)
+	firstHypotheticalBuy := len(batch.Buys)
+	firstHypotheticalSell := len(batch.Sells)
+	firstHypotheticalSwap := len(batch.Swaps)
+	var hypotheticalDeposits sdk.Coins
+	for _, bo := range params.Buys {
+		if bo.Amount.Denom != token {
+			return result, types.ErrInvalidCoinDenomination(types.DefaultCodespace, bo.Amount.Denom)
+		} else if !bond.ReserveDenomsEqualTo(bo.MaxPrices) {
+			return result, types.ErrReserveDenomsMismatch(types.DefaultCodespace, bo.MaxPrices, bond.ReserveTokens)
+		}
+		bo.BaseOrder = types.NewBaseOrder(bo.Address, bo.Amount)
+		batch.TotalBuyAmount = batch.TotalBuyAmount.Add(bo.Amount)
+		batch.Buys = append(batch.Buys, bo)
+		hypotheticalDeposits = hypotheticalDeposits.Add(bo.MaxPrices)
+	}
+	if len(params.Sells) > 0 && strings.ToLower(bond.AllowSells) == types.FALSE {
+		return result, types.ErrBondDoesNotAllowSelling(types.DefaultCodespace)
+	}
+	for _, so := range params.Sells {
+		if so.Amount.Denom != token {
+			return result, types.ErrInvalidCoinDenomination(types.DefaultCodespace, so.Amount.Denom)
+		}
+		so.BaseOrder = types.NewBaseOrder(so.Address, so.Amount)
+		batch.TotalSellAmount = batch.TotalSellAmount.Add(so.Amount)
+		batch.Sells = append(batch.Sells, so)
+	}
+	for _, so := range params.Swaps {
+		so.BaseOrder = types.NewBaseOrder(so.Address, so.Amount)
+		batch.Swaps = append(batch.Swaps, so)
+		hypotheticalDeposits = hypotheticalDeposits.Add(sdk.Coins{so.Amount})
+	}
+
+	// Max supply cannot be less than supply (max supply >= supply)
+	if bond.MaxSupply.IsLT(bond.CurrentSupply.Add(batch.TotalBuyAmount)) {
+		return result, types.ErrCannotMintMoreThanMaxSupply(types.DefaultCodespace)
+	}
+
+	// Cannot burn more tokens than what exists
+	burned := batch.TotalSellAmount.Add(batch.GetRestingSellAmount())
+	if bond.CurrentSupply.IsLT(burned) {
+		return result, types.ErrCannotBurnMoreThanSupply(types.DefaultCodespace)
+	}
+
+	batch.BuyPrices, batch.SellPrices, err = k.GetBatchBuySellPrices(cacheCtx, token, batch)
+	if err != nil {
+		return result, err
+	}
+
+	// Hypothetical buys and swaps are funded as if their max prices and amounts
+	// had been sent to the batches intermediary account (hypothetical sells do
+	// not need to be funded, since their tokens would already have been burned)
+	if !hypotheticalDeposits.IsZero() {
+		err = k.SupplyKeeper.MintCoins(cacheCtx, types.BondsMintBurnAccount, hypotheticalDeposits)
+		if err != nil {
+			return result, err
+		}
+		err = k.SupplyKeeper.SendCoinsFromModuleToModule(cacheCtx,
+			types.BondsMintBurnAccount, types.BatchesIntermediaryAccount, hypotheticalDeposits)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// Every order needs a record, from which its outcome is read
+	var orders []simulatedOrderRef
+	recordOrder := func(orderType string, order *types.BaseOrder, toToken string, hypothetical bool) {
+		if order.Id == 0 {
+			k.recordNewOrder(cacheCtx, token, orderType, order, toToken)
+			if order.IsCancelled() {
+				k.recordOrderCancelled(cacheCtx, token, *order, order.CancelReason)
+			}
+		}
+		orders = append(orders, simulatedOrderRef{orderType, *order, hypothetical})
+	}
+	for i := range batch.Buys {
+		recordOrder(types.AttributeValueBuyOrder, &batch.Buys[i].BaseOrder, "",
+			i >= firstHypotheticalBuy)
+	}
+	for i := range batch.Sells {
+		recordOrder(types.AttributeValueSellOrder, &batch.Sells[i].BaseOrder, "",
+			i >= firstHypotheticalSell)
+	}
+	for i := range batch.Swaps {
+		recordOrder(types.AttributeValueSwapOrder, &batch.Swaps[i].BaseOrder, batch.Swaps[i].ToToken,
+			i >= firstHypotheticalSwap)
+	}
+	k.SetBatch(cacheCtx, token, batch)
+
+	// Perform the batch in the same way as the end-blocker
+	k.CancelUnfulfillableOrders(cacheCtx, token)
+	batch = k.MustGetBatch(cacheCtx, token)
+	k.PerformOrders(cacheCtx, token)
+
+	for _, ref := range orders {
+		record, found := k.GetOrderRecord(cacheCtx, ref.order.Address, token, ref.order.Height, ref.order.Id)
+		if !found {
+			return result, sdk.ErrInternal(fmt.Sprintf("order record %d not found", ref.order.Id))
+		}
+		order := types.SimulatedOrder{
+			OrderType:    ref.orderType,
+			Address:      ref.order.Address,
+			Amount:       ref.order.Amount,
+			Hypothetical: ref.hypothetical,
+			Status:       record.Status,
+			CancelReason: record.CancelReason,
+		}
+		if record.Status == types.FulfilledOrderStatus {
+			order.Prices = record.ChargedPrices
+			order.Fees = record.ChargedFees
+			if ref.orderType != types.AttributeValueBuyOrder {
+				order.Returns = record.Returned
+			}
+		}
+		result.Orders = append(result.Orders, order)
+	}
+
+	result.BuyPrices = batch.BuyPrices
+	result.SellPrices = batch.SellPrices
+	result.CurrentSupply = k.MustGetBond(cacheCtx, token).CurrentSupply
+	result.ReserveBalances = k.GetReserveBalances(cacheCtx, token)
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func reserveCoins(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.ValidReserveToken, amount))
+}
+
+func TestSimulateBatch(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	token := types.ValidToken
+	buyer := types.ValidBuyerAddress
+	other := types.ValidOtherAddress
+
+	// Power function bond with price x + 1, so minting s tokens from zero
+	// costs s^2/2 + s
+	msg := types.ValidCreateBondMsg
+	bond := types.NewBond(msg.Token, msg.Name, msg.Description, msg.Creator,
+		msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
+		msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress, msg.FeeRecipients,
+		msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
+		msg.SanityMarginPercentage, msg.AllowSells, msg.Signers, msg.BatchBlocks,
+		msg.HatchWhitelist, msg.CommitRevealSwaps, msg.Vesting, msg.TransferPolicy,
+		msg.TransferAllowList)
+	k.SetBond(ctx, token, bond)
+	k.SetBatch(ctx, token, types.NewBatch(token, bond.BatchBlocks))
+
+	// Add a buy of 100 tokens to the batch, as its handler would
+	_, err := k.CoinKeeper.AddCoins(ctx, buyer, reserveCoins(7000))
+	require.Nil(t, err)
+	err = k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, buyer,
+		types.BatchesIntermediaryAccount, reserveCoins(7000))
+	require.Nil(t, err)
+	bo := types.NewBuyOrder(buyer, sdk.NewInt64Coin(token, 100), reserveCoins(7000), 0)
+	buyPrices, sellPrices, err := k.GetUpdatedBatchPricesAfterBuy(ctx, token, bo)
+	require.Nil(t, err)
+	k.AddBuyOrder(ctx, token, bo, buyPrices, sellPrices)
+
+	batch := k.MustGetBatch(ctx, token)
+	nextOrderId := k.GetNextOrderId(ctx)
+	totalSupply := k.SupplyKeeper.GetSupply(ctx).GetTotal()
+
+	// Hypothetical buys of 10 tokens that cannot pay the batch price are
+	// cancelled or rest, and the rest are fulfilled at 56 per token (the price
+	// of minting 110 tokens)
+	params := types.NewQuerySimulateBatchParams([]types.BuyOrder{
+		types.NewBuyOrder(other, sdk.NewInt64Coin(token, 10), reserveCoins(1), 0),
+		types.NewBuyOrder(other, sdk.NewInt64Coin(token, 10), reserveCoins(1), 10),
+		types.NewBuyOrder(other, sdk.NewInt64Coin(token, 10), reserveCoins(1000), 0),
+	}, nil, nil)
+	result, err := k.SimulateBatch(ctx, token, params)
+	require.Nil(t, err)
+
+	require.Equal(t, 4, len(result.Orders))
+	require.False(t, result.Orders[0].Hypothetical)
+	require.Equal(t, types.FulfilledOrderStatus, result.Orders[0].Status)
+	require.Equal(t, reserveCoins(5600), result.Orders[0].Prices)
+	require.True(t, result.Orders[1].Hypothetical)
+	require.Equal(t, types.CancelledOrderStatus, result.Orders[1].Status)
+	require.NotEmpty(t, result.Orders[1].CancelReason)
+	require.Equal(t, types.RestingOrderStatus, result.Orders[2].Status)
+	require.Equal(t, types.FulfilledOrderStatus, result.Orders[3].Status)
+	require.Equal(t, reserveCoins(560), result.Orders[3].Prices)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewCoins(sdk.NewInt64Coin(types.ValidReserveToken, 56))),
+		result.BuyPrices)
+	require.Equal(t, sdk.NewInt64Coin(token, 110), result.CurrentSupply)
+	require.Equal(t, reserveCoins(6160), result.ReserveBalances)
+
+	// Nothing is written to state
+	require.Equal(t, batch, k.MustGetBatch(ctx, token))
+	require.Equal(t, nextOrderId, k.GetNextOrderId(ctx))
+	require.Equal(t, totalSupply, k.SupplyKeeper.GetSupply(ctx).GetTotal())
+	require.True(t, k.GetReserveBalances(ctx, token).IsZero())
+	require.True(t, k.MustGetBond(ctx, token).CurrentSupply.IsZero())
+
+	// Without hypothetical orders, the simulation matches performing the batch
+	result, err = k.SimulateBatch(ctx, token, types.NewQuerySimulateBatchParams(nil, nil, nil))
+	require.Nil(t, err)
+	k.CancelUnfulfillableOrders(ctx, token)
+	k.PerformOrders(ctx, token)
+	record, found := k.GetOrderRecord(ctx, buyer, token, batch.Buys[0].Height, batch.Buys[0].Id)
+	require.True(t, found)
+	require.Equal(t, record.Status, result.Orders[0].Status)
+	require.Equal(t, record.ChargedPrices, result.Orders[0].Prices)
+	require.Equal(t, record.ChargedFees, result.Orders[0].Fees)
+	require.Equal(t, k.MustGetBond(ctx, token).CurrentSupply, result.CurrentSupply)
+	require.Equal(t, k.GetReserveBalances(ctx, token), result.ReserveBalances)
+}
@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-cosmos/x/did"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmDB "github.com/tendermint/tm-db"
+)
+
+func CreateTestInput() (sdk.Context, Keeper, *codec.Codec) {
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyDid := sdk.NewKVStoreKey(did.StoreKey)
+
+	db := tmDB.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, nil)
+	ms.MountStoreWithDB(keyDid, sdk.StoreTypeIAVL, nil)
+	_ = ms.LoadLatestVersion()
+
+	ctx := sdk.NewContext(ms, abciTypes.Header{}, false, log.NewNopLogger())
+	cdc := MakeTestCodec()
+
+	maccPerms := map[string][]string{
+		staking.BondedPoolName:           {supply.Burner, supply.Staking},
+		staking.NotBondedPoolName:        {supply.Burner, supply.Staking},
+		types.BondsMintBurnAccount:       {supply.Minter, supply.Burner},
+		types.BatchesIntermediaryAccount: nil,
+		types.BondsReserveAccount:        nil,
+		types.BondsFeesAccount:           nil,
+		types.BondsVestingAccount:        nil,
+		types.BondsFundingPoolAccount:    nil,
+	}
+
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc,
+		paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper,
+		paramsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	stakingKeeper := staking.NewKeeper(cdc, keyStaking, tkeyStaking, supplyKeeper,
+		paramsKeeper.Subspace(staking.DefaultParamspace), staking.DefaultCodespace)
+	didKeeper := did.NewKeeper(cdc, keyDid)
+
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.Coins{}))
+	stakingKeeper.SetParams(ctx, staking.DefaultParams())
+
+	keeper := NewKeeper(bankKeeper, supplyKeeper, accountKeeper, stakingKeeper,
+		didKeeper, storeKey, cdc)
+
+	return ctx, keeper, cdc
+}
+
+func MakeTestCodec() *codec.Codec {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	did.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	return cdc
+}
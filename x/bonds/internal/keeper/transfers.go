@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+	"github.com/ixofoundation/ixo-cosmos/x/did"
+)
+
+// IsKYCValidated returns whether the address is linked to a DID with a
+// KYC-validated credential. Account addresses and DIDs have unrelated keys, so
+// an address only has a DID once both have signed a did LinkAccountMsg.
+func (k Keeper) IsKYCValidated(ctx sdk.Context, address sdk.AccAddress) bool {
+	accountDid, found := k.DidKeeper.GetAccountDid(ctx, address)
+	if !found {
+		return false
+	}
+
+	didDoc, err := k.DidKeeper.GetDidDoc(ctx, accountDid)
+	if err != nil {
+		return false
+	}
+
+	baseDidDoc, ok := didDoc.(did.BaseDidDoc)
+	if !ok {
+		return false
+	}
+
+	for _, c := range baseDidDoc.GetCredentials() {
+		if c.Claim.KYCValidated {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransferAllowed returns an error if the bond's transfer policy does not
+// allow the address to hold the bond's tokens
+func (k Keeper) CheckTransferAllowed(ctx sdk.Context, bond types.Bond, address sdk.AccAddress) sdk.Error {
+	var allowed bool
+	switch {
+	case bond.HasOpenTransferPolicy():
+		allowed = true
+	case bond.TransferPolicy == types.AllowListTransferPolicy:
+		allowed = bond.IsOnTransferAllowList(address)
+	case bond.TransferPolicy == types.CredentialRequiredTransferPolicy:
+		allowed = k.IsKYCValidated(ctx, address)
+	}
+
+	if !allowed {
+		return types.ErrTransferNotAllowed(types.DefaultCodespace, bond.Token, address, bond.TransferPolicy)
+	}
+	return nil
+}
+
+// CheckTransfersAllowed returns an error if any of the coins are bond tokens
+// that the bond's transfer policy does not allow the address to hold
+func (k Keeper) CheckTransfersAllowed(ctx sdk.Context, address sdk.AccAddress, coins sdk.Coins) sdk.Error {
+	for _, c := range coins {
+		bond, found := k.GetBond(ctx, c.Denom)
+		if !found {
+			continue
+		}
+		if err := k.CheckTransferAllowed(ctx, bond, address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
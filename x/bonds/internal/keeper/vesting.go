@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds/internal/types"
+)
+
+func (k Keeper) GetVestingSchedules(ctx sdk.Context, token string, holder sdk.AccAddress) (schedules []types.VestingSchedule) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetVestingSchedulesKey(token, holder))
+	if bz == nil {
+		return nil
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &schedules)
+	return schedules
+}
+
+func (k Keeper) SetVestingSchedules(ctx sdk.Context, token string, holder sdk.AccAddress, schedules []types.VestingSchedule) {
+	store := ctx.KVStore(k.storeKey)
+	if len(schedules) == 0 {
+		store.Delete(types.GetVestingSchedulesKey(token, holder))
+		return
+	}
+	store.Set(types.GetVestingSchedulesKey(token, holder), k.cdc.MustMarshalBinaryBare(schedules))
+}
+
+func (k Keeper) GetVestingSchedulesIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.VestingSchedulesKeyPrefix)
+}
+
+func (k Keeper) GetVestingSchedulesByBond(ctx sdk.Context, token string) []types.AccountVestingSchedules {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetVestingSchedulesPrefix(token)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var accountSchedules []types.AccountVestingSchedules
+	for ; iterator.Valid(); iterator.Next() {
+		var schedules []types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &schedules)
+		holder := sdk.AccAddress(iterator.Key()[len(prefix):])
+		accountSchedules = append(accountSchedules,
+			types.NewAccountVestingSchedules(token, holder, schedules))
+	}
+	return accountSchedules
+}
+
+// LockVestingTokens sends newly minted bond tokens to the bonds vesting
+// account and adds a vesting schedule for them, starting at the current block
+func (k Keeper) LockVestingTokens(ctx sdk.Context, token string, holder sdk.AccAddress, amount sdk.Coin) sdk.Error {
+	bond := k.MustGetBond(ctx, token)
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(ctx,
+		types.BondsMintBurnAccount, types.BondsVestingAccount, sdk.Coins{amount})
+	if err != nil {
+		return err
+	}
+
+	schedule := types.NewVestingSchedule(bond.Vesting, ctx.BlockHeight(), amount)
+	schedules := k.GetVestingSchedules(ctx, token, holder)
+	k.SetVestingSchedules(ctx, token, holder, append(schedules, schedule))
+	return nil
+}
+
+// GetClaimableVestedTokens returns the amount of the holder's bond tokens
+// that have vested by the current block but have not been claimed yet
+func (k Keeper) GetClaimableVestedTokens(ctx sdk.Context, token string, holder sdk.AccAddress) sdk.Coin {
+	claimable := sdk.NewCoin(token, sdk.ZeroInt())
+	for _, s := range k.GetVestingSchedules(ctx, token, holder) {
+		claimable = claimable.Add(s.ClaimableAt(ctx.BlockHeight()))
+	}
+	return claimable
+}
+
+// ClaimVestedTokens sends the holder's claimable bond tokens from the bonds
+// vesting account to the holder and removes any fully claimed schedules
+func (k Keeper) ClaimVestedTokens(ctx sdk.Context, token string, holder sdk.AccAddress) (sdk.Coin, sdk.Error) {
+	claimed := sdk.NewCoin(token, sdk.ZeroInt())
+	var remaining []types.VestingSchedule
+	for _, s := range k.GetVestingSchedules(ctx, token, holder) {
+		claimable := s.ClaimableAt(ctx.BlockHeight())
+		claimed = claimed.Add(claimable)
+		s.Claimed = s.Claimed.Add(claimable)
+		if !s.IsFullyClaimed() {
+			remaining = append(remaining, s)
+		}
+	}
+
+	if claimed.IsZero() {
+		return sdk.Coin{}, types.ErrNoVestedTokens(types.DefaultCodespace, token, holder)
+	}
+
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx,
+		types.BondsVestingAccount, holder, sdk.Coins{claimed})
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	k.SetVestingSchedules(ctx, token, holder, remaining)
+	return claimed, nil
+}
+
+// RebaseVestingSchedules shifts the heights of all vesting schedules back by
+// the specified number of blocks, so that the blocks remaining until each
+// schedule's cliff and end are preserved when the chain restarts at a lower
+// height (e.g. from a zero-height genesis)
+func (k Keeper) RebaseVestingSchedules(ctx sdk.Context, blocks int64) {
+	// Collect all records first, so that the store is not written to while
+	// it is being iterated over
+	var keys [][]byte
+	var allSchedules [][]types.VestingSchedule
+	iterator := k.GetVestingSchedulesIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		var schedules []types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &schedules)
+		keys = append(keys, iterator.Key())
+		allSchedules = append(allSchedules, schedules)
+	}
+	iterator.Close()
+
+	store := ctx.KVStore(k.storeKey)
+	for i, schedules := range allSchedules {
+		for j := range schedules {
+			schedules[j].StartHeight -= blocks
+			schedules[j].CliffHeight -= blocks
+			schedules[j].EndHeight -= blocks
+		}
+		store.Set(keys[i], k.cdc.MustMarshalBinaryBare(schedules))
+	}
+}
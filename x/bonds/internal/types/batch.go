@@ -1,6 +1,9 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -14,12 +17,23 @@ type Batch struct {
 	Buys            []BuyOrder   `json:"buys" yaml:"buys"`
 	Sells           []SellOrder  `json:"sells" yaml:"sells"`
 	Swaps           []SwapOrder  `json:"swaps" yaml:"swaps"`
+	RestingBuys     []BuyOrder   `json:"resting_buys" yaml:"resting_buys"`
+	RestingSells    []SellOrder  `json:"resting_sells" yaml:"resting_sells"`
+	SwapCommits     []SwapCommit `json:"swap_commits" yaml:"swap_commits"`
 }
 
 func (b Batch) MoreBuysThanSells() bool { return b.TotalSellAmount.IsLT(b.TotalBuyAmount) }
 func (b Batch) MoreSellsThanBuys() bool { return b.TotalBuyAmount.IsLT(b.TotalSellAmount) }
 func (b Batch) EqualBuysAndSells() bool { return b.TotalBuyAmount.IsEqual(b.TotalSellAmount) }
 
+func (b Batch) GetRestingSellAmount() sdk.Coin {
+	total := sdk.NewInt64Coin(b.Token, 0)
+	for _, so := range b.RestingSells {
+		total = total.Add(so.Amount)
+	}
+	return total
+}
+
 func NewBatch(token string, blocks sdk.Uint) Batch {
 	return Batch{
 		Token:           token,
@@ -29,7 +43,11 @@ func NewBatch(token string, blocks sdk.Uint) Batch {
 	}
 }
 
+// BaseOrder is the part common to all orders. The ID and height are assigned
+// when the order is first added to a batch and identify the order's record.
 type BaseOrder struct {
+	Id           uint64         `json:"id" yaml:"id"`
+	Height       int64          `json:"height" yaml:"height"`
 	Address      sdk.AccAddress `json:"address" yaml:"address"`
 	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
 	Cancelled    string         `json:"cancelled" yaml:"cancelled"`
@@ -49,36 +67,93 @@ func (bo BaseOrder) IsCancelled() bool {
 	return bo.Cancelled == TRUE
 }
 
+func isGoodTillBlock(goodTillBlock int64) bool {
+	return goodTillBlock != 0
+}
+
+func hasExpired(goodTillBlock, height int64) bool {
+	// An order is good until the end of its good-till-block (inclusive)
+	return isGoodTillBlock(goodTillBlock) && goodTillBlock <= height
+}
+
 type BuyOrder struct {
 	BaseOrder
-	MaxPrices sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	MaxPrices     sdk.Coins `json:"max_prices" yaml:"max_prices"`
+	GoodTillBlock int64     `json:"good_till_block" yaml:"good_till_block"`
 }
 
-func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) BuyOrder {
+func NewBuyOrder(address sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins, goodTillBlock int64) BuyOrder {
 	return BuyOrder{
-		BaseOrder: NewBaseOrder(address, amount),
-		MaxPrices: maxPrices,
+		BaseOrder:     NewBaseOrder(address, amount),
+		MaxPrices:     maxPrices,
+		GoodTillBlock: goodTillBlock,
 	}
 }
 
+func (bo BuyOrder) IsGoodTillBlock() bool        { return isGoodTillBlock(bo.GoodTillBlock) }
+func (bo BuyOrder) HasExpired(height int64) bool { return hasExpired(bo.GoodTillBlock, height) }
+
 type SellOrder struct {
 	BaseOrder
+	MinReturns    sdk.Coins `json:"min_returns" yaml:"min_returns"`
+	GoodTillBlock int64     `json:"good_till_block" yaml:"good_till_block"`
 }
 
-func NewSellOrder(address sdk.AccAddress, amount sdk.Coin) SellOrder {
+func NewSellOrder(address sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins,
+	goodTillBlock int64) SellOrder {
 	return SellOrder{
-		BaseOrder: NewBaseOrder(address, amount),
+		BaseOrder:     NewBaseOrder(address, amount),
+		MinReturns:    minReturns,
+		GoodTillBlock: goodTillBlock,
 	}
 }
 
+func (so SellOrder) IsGoodTillBlock() bool        { return isGoodTillBlock(so.GoodTillBlock) }
+func (so SellOrder) HasExpired(height int64) bool { return hasExpired(so.GoodTillBlock, height) }
+
 type SwapOrder struct {
 	BaseOrder
-	ToToken string `json:"to_token" yaml:"to_token"`
+	ToToken    string    `json:"to_token" yaml:"to_token"`
+	MinReturns sdk.Coins `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string) SwapOrder {
+func NewSwapOrder(address sdk.AccAddress, from sdk.Coin, toToken string,
+	minReturns sdk.Coins) SwapOrder {
 	return SwapOrder{
-		BaseOrder: NewBaseOrder(address, from),
-		ToToken:   toToken,
+		BaseOrder:  NewBaseOrder(address, from),
+		ToToken:    toToken,
+		MinReturns: minReturns,
 	}
 }
+
+type SwapCommit struct {
+	Address        sdk.AccAddress `json:"address" yaml:"address"`
+	Hash           string         `json:"hash" yaml:"hash"`
+	Deposit        sdk.Coins      `json:"deposit" yaml:"deposit"`
+	RevealDeadline int64          `json:"reveal_deadline" yaml:"reveal_deadline"`
+}
+
+func NewSwapCommit(address sdk.AccAddress, hash string, deposit sdk.Coins,
+	revealDeadline int64) SwapCommit {
+	return SwapCommit{
+		Address:        address,
+		Hash:           hash,
+		Deposit:        deposit,
+		RevealDeadline: revealDeadline,
+	}
+}
+
+func (sc SwapCommit) HasExpired(height int64) bool {
+	// A commitment can be revealed until the end of its reveal deadline (inclusive)
+	return sc.RevealDeadline <= height
+}
+
+// GetSwapCommitHash returns the hex-encoded SHA-256 hash that a swapper
+// commits to and that is later checked against the revealed swap details.
+func GetSwapCommitHash(swapper sdk.AccAddress, bondToken string, from sdk.Coin,
+	toToken string, minReturns sdk.Coins, salt string) string {
+	preimage := fmt.Sprintf("%s|%s|%s|%s|%s|%s", swapper.String(), bondToken,
+		from.String(), toToken, minReturns.String(), salt)
+	hash := sha256.Sum256([]byte(preimage))
+	return hex.EncodeToString(hash[:])
+}
@@ -7,34 +7,66 @@ import (
 )
 
 const (
-	PowerFunction    = "power_function"
-	SigmoidFunction  = "sigmoid_function"
-	SwapperFunction  = "swapper_function"
-	DoNotModifyField = "[do-not-modify]"
+	PowerFunction       = "power_function"
+	SigmoidFunction     = "sigmoid_function"
+	ExponentialFunction = "exponential_function"
+	LogarithmicFunction = "logarithmic_function"
+	AugmentedFunction   = "augmented_function"
+	SwapperFunction     = "swapper_function"
+	DoNotModifyField    = "[do-not-modify]"
+
+	WeightedSwapperFunction = "weighted_swapper_function"
 
 	AnyNumberOfReserveTokens = -1
+
+	MinWeightedSwapperReserveTokens = 2
+	MaxWeightedSwapperReserveTokens = 8
+
+	HatchState  = "hatch"
+	OpenState   = "open"
+	ClosedState = "closed"
+
+	PausedState  = "paused"
+	SettledState = "settled"
+
+	OpenTransferPolicy               = "open"
+	AllowListTransferPolicy          = "allow_list"
+	CredentialRequiredTransferPolicy = "credential_required"
 )
 
 var (
 	RequiredParamsForFunctionType = map[string][]string{
-		PowerFunction:   {"m", "n", "c"},
-		SigmoidFunction: {"a", "b", "c"},
-		SwapperFunction: nil,
+		PowerFunction:       {"m", "n", "c"},
+		SigmoidFunction:     {"a", "b", "c"},
+		ExponentialFunction: {"a", "b", "c"},
+		LogarithmicFunction: {"a", "b", "c"},
+		AugmentedFunction:   {"d0", "p0", "theta", "kappa"},
+		SwapperFunction:     nil,
+
+		// The weighted swapper's parameters are the weights of its reserve
+		// tokens, keyed by reserve token (see ValidateWeights)
+		WeightedSwapperFunction: nil,
 	}
 
 	NoOfReserveTokensForFunctionType = map[string]int{
-		PowerFunction:   AnyNumberOfReserveTokens,
-		SigmoidFunction: AnyNumberOfReserveTokens,
-		SwapperFunction: 2,
+		PowerFunction:       AnyNumberOfReserveTokens,
+		SigmoidFunction:     AnyNumberOfReserveTokens,
+		ExponentialFunction: AnyNumberOfReserveTokens,
+		LogarithmicFunction: AnyNumberOfReserveTokens,
+		AugmentedFunction:   AnyNumberOfReserveTokens,
+		SwapperFunction:     2,
+
+		// Checked against the min and max weighted swapper reserve tokens
+		WeightedSwapperFunction: AnyNumberOfReserveTokens,
 	}
 )
 
 type FunctionParam struct {
 	Param string  `json:"param" yaml:"param"`
-	Value sdk.Int `json:"value" yaml:"value"`
+	Value sdk.Dec `json:"value" yaml:"value"`
 }
 
-func NewFunctionParam(param string, value sdk.Int) FunctionParam {
+func NewFunctionParam(param string, value sdk.Dec) FunctionParam {
 	return FunctionParam{
 		Param: param,
 		Value: value,
@@ -55,14 +87,181 @@ func (fps FunctionParams) String() (result string) {
 	return result + "}"
 }
 
-func (fps FunctionParams) AsMap() (paramsMap map[string]sdk.Int) {
-	paramsMap = make(map[string]sdk.Int)
+func (fps FunctionParams) AsMap() (paramsMap map[string]sdk.Dec) {
+	paramsMap = make(map[string]sdk.Dec)
 	for _, fp := range fps {
 		paramsMap[fp.Param] = fp.Value
 	}
 	return paramsMap
 }
 
+func (fps FunctionParams) Validate(functionType string) sdk.Error {
+	// Come up with list of expected parameters
+	expectedParams, ok := RequiredParamsForFunctionType[functionType]
+	if !ok {
+		return ErrUnrecognizedFunctionType(DefaultCodespace)
+	}
+
+	// Weights are keyed by reserve token, so whichever parameters are present
+	// are expected (these are checked against the reserve tokens separately)
+	if functionType == WeightedSwapperFunction {
+		for _, fp := range fps {
+			expectedParams = append(expectedParams, fp.Param)
+		}
+	}
+
+	// Check that the number of parameters is correct
+	if len(fps) != len(expectedParams) {
+		return ErrIncorrectNumberOfFunctionParameters(DefaultCodespace, len(expectedParams))
+	}
+
+	// Check that all expected parameters are present and valid
+	paramsMap := fps.AsMap()
+	for _, p := range expectedParams {
+		value, ok := paramsMap[p]
+		if !ok || value.IsNil() {
+			return ErrFunctionParameterMissingOrNonFloat(DefaultCodespace, p)
+		}
+
+		// TODO: consider allowing negative function parameters where possible
+		if value.IsNegative() {
+			return ErrArgumentCannotBeNegative(DefaultCodespace, "FunctionParams:"+p)
+		} else if value.IsZero() {
+			return ErrArgumentMustBePositive(DefaultCodespace, "FunctionParams:"+p)
+		}
+	}
+
+	// Check that the funding pool fraction of an augmented function is a
+	// fraction, and that kappa is at least 1, since the price k*s^(k-1)/v0
+	// would otherwise have a negative exponent and be unbounded near zero
+	if functionType == AugmentedFunction {
+		if paramsMap["theta"].GTE(sdk.OneDec()) {
+			return ErrInvalidFunctionParameter(DefaultCodespace, "theta")
+		} else if paramsMap["kappa"].LT(sdk.OneDec()) {
+			return ErrInvalidFunctionParameter(DefaultCodespace, "kappa")
+		}
+	}
+
+	return nil
+}
+
+func (fps FunctionParams) ValidateForMaxSupply(functionType string, maxSupply sdk.Int) (err sdk.Error) {
+	// Check that e^(bx) of the exponential function can be calculated (see
+	// ExpDec) for any supply x up to the max supply, i.e. that b*maxSupply
+	// does not exceed the max exponent (compared without multiplying, since
+	// the product itself can overflow for very large max supplies)
+	if functionType == ExponentialFunction {
+		b := fps.AsMap()["b"]
+		if b.GT(MaxExpDecExponent.QuoInt(maxSupply)) {
+			return ErrFunctionParameterTooLargeForMaxSupply(DefaultCodespace, "b", maxSupply)
+		}
+	}
+
+	// Swappers are not defined by a curve, so there is nothing to calculate
+	if functionType == SwapperFunction || functionType == WeightedSwapperFunction {
+		return nil
+	}
+
+	// Since the curves increase with the supply, the price and integral can
+	// be calculated for any supply up to the max supply if they can be
+	// calculated at the max supply, which is checked by calculating them.
+	// (The augmented function is checked as it is once the hatch is over.)
+	defer func() {
+		if r := recover(); r != nil {
+			err = ErrFunctionCannotBeCalculatedAtMaxSupply(DefaultCodespace, maxSupply)
+		}
+	}()
+	bond := Bond{FunctionType: functionType, FunctionParameters: fps, State: OpenState}
+	if _, err := bond.GetPricesAtSupply(maxSupply); err != nil {
+		return err
+	}
+	bond.CurveIntegral(maxSupply)
+
+	return nil
+}
+
+func (fps FunctionParams) ValidateWeights(reserveTokens []string) sdk.Error {
+	// Check that the number of reserve tokens is within the allowed range
+	if len(reserveTokens) < MinWeightedSwapperReserveTokens ||
+		len(reserveTokens) > MaxWeightedSwapperReserveTokens {
+		return ErrNumberOfReserveTokensOutOfRange(DefaultCodespace,
+			MinWeightedSwapperReserveTokens, MaxWeightedSwapperReserveTokens)
+	}
+
+	// Check that there is exactly one weight for each reserve token
+	paramsMap := fps.AsMap()
+	if len(fps) != len(reserveTokens) || len(paramsMap) != len(reserveTokens) {
+		return ErrWeightsDoNotMatchReserveTokens(DefaultCodespace)
+	}
+	for _, r := range reserveTokens {
+		if _, ok := paramsMap[r]; !ok {
+			return ErrWeightsDoNotMatchReserveTokens(DefaultCodespace)
+		}
+	}
+
+	return nil
+}
+
+func CheckReserveTokenNames(resTokens []string, token string) sdk.Error {
+	// Check that no token is the same as the main token, no token
+	// is duplicate, and that the token is a valid denomination
+	uniqueReserveTokens := make(map[string]string)
+	for _, r := range resTokens {
+		// Check if same as main token
+		if r == token {
+			return ErrBondTokenCannotAlsoBeReserveToken(DefaultCodespace)
+		}
+
+		// Check if duplicate
+		if _, ok := uniqueReserveTokens[r]; ok {
+			return ErrDuplicateReserveToken(DefaultCodespace)
+		} else {
+			uniqueReserveTokens[r] = ""
+		}
+
+		// Check if can be parsed as coin
+		_, err := sdk.ParseCoin("0" + r)
+		if err != nil {
+			return ErrInvalidCoinDenomination(DefaultCodespace, r)
+		}
+	}
+	return nil
+}
+
+func CheckNoOfReserveTokens(resTokens []string, fnType string) sdk.Error {
+	// Come up with number of expected reserve tokens
+	expectedNoOfTokens, ok := NoOfReserveTokensForFunctionType[fnType]
+	if !ok {
+		return ErrUnrecognizedFunctionType(DefaultCodespace)
+	}
+
+	// Check that number of reserve tokens is correct (if expecting a specific number of tokens)
+	if expectedNoOfTokens != AnyNumberOfReserveTokens && len(resTokens) != expectedNoOfTokens {
+		return ErrIncorrectNumberOfReserveTokens(DefaultCodespace, expectedNoOfTokens)
+	}
+
+	// Check that number of reserve tokens is within range for the weighted swapper
+	if fnType == WeightedSwapperFunction &&
+		(len(resTokens) < MinWeightedSwapperReserveTokens ||
+			len(resTokens) > MaxWeightedSwapperReserveTokens) {
+		return ErrNumberOfReserveTokensOutOfRange(DefaultCodespace,
+			MinWeightedSwapperReserveTokens, MaxWeightedSwapperReserveTokens)
+	}
+
+	return nil
+}
+
+func (fps FunctionParams) ReplaceParam(param string, value sdk.Dec) FunctionParams {
+	result := make(FunctionParams, len(fps))
+	for i, fp := range fps {
+		if fp.Param == param {
+			fp.Value = value
+		}
+		result[i] = fp
+	}
+	return result
+}
+
 type Bond struct {
 	Token                  string           `json:"token" yaml:"token"`
 	Name                   string           `json:"name" yaml:"name"`
@@ -71,10 +270,11 @@ type Bond struct {
 	FunctionType           string           `json:"function_type" yaml:"function_type"`
 	FunctionParameters     FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
 	ReserveTokens          []string         `json:"reserve_tokens" yaml:"reserve_tokens"`
-	ReserveAddress         sdk.AccAddress   `json:"reserve_address" yaml:"reserve_address"`
+	ReserveAddress         sdk.AccAddress   `json:"reserve_address" yaml:"reserve_address"` // Deprecated: reserves are held by the module
 	TxFeePercentage        sdk.Dec          `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
 	ExitFeePercentage      sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
 	FeeAddress             sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
+	FeeRecipients          FeeRecipients    `json:"fee_recipients" yaml:"fee_recipients"`
 	MaxSupply              sdk.Coin         `json:"max_supply" yaml:"max_supply"`
 	OrderQuantityLimits    sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
@@ -83,20 +283,33 @@ type Bond struct {
 	AllowSells             string           `json:"allow_sells" yaml:"allow_sells"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
+	HatchWhitelist         []sdk.AccAddress `json:"hatch_whitelist" yaml:"hatch_whitelist"`
+	State                  string           `json:"state" yaml:"state"`
+	CommitRevealSwaps      string           `json:"commit_reveal_swaps" yaml:"commit_reveal_swaps"`
+	Vesting                VestingConfig    `json:"vesting" yaml:"vesting"`
+	TransferPolicy         string           `json:"transfer_policy" yaml:"transfer_policy"`
+	TransferAllowList      []sdk.AccAddress `json:"transfer_allow_list" yaml:"transfer_allow_list"`
 }
 
 func NewBond(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams,
-	reserveTokens []string, reserveAdddress sdk.AccAddress,
-	txFeePercentage, exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress,
-	maxSupply sdk.Coin, orderQuantityLimits sdk.Coins, sanityRate,
-	sanityMarginPercentage sdk.Dec, allowSells string, signers []sdk.AccAddress,
-	batchBlocks sdk.Uint) Bond {
+	reserveTokens []string, txFeePercentage, exitFeePercentage sdk.Dec,
+	feeAddress sdk.AccAddress, feeRecipients FeeRecipients, maxSupply sdk.Coin,
+	orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec, allowSells string, signers []sdk.AccAddress,
+	batchBlocks sdk.Uint, hatchWhitelist []sdk.AccAddress,
+	commitRevealSwaps string, vesting VestingConfig, transferPolicy string,
+	transferAllowList []sdk.AccAddress) Bond {
 
 	// Ensure tokens and coins are sorted
 	sort.Strings(reserveTokens)
 	orderQuantityLimits = orderQuantityLimits.Sort()
 
+	// Augmented function bonds start off in the hatch phase
+	state := OpenState
+	if functionType == AugmentedFunction {
+		state = HatchState
+	}
+
 	return Bond{
 		Token:                  token,
 		Name:                   name,
@@ -105,10 +318,10 @@ func NewBond(token, name, description string, creator sdk.AccAddress,
 		FunctionType:           functionType,
 		FunctionParameters:     functionParameters,
 		ReserveTokens:          reserveTokens,
-		ReserveAddress:         reserveAdddress,
 		TxFeePercentage:        txFeePercentage,
 		ExitFeePercentage:      exitFeePercentage,
 		FeeAddress:             feeAddress,
+		FeeRecipients:          feeRecipients,
 		MaxSupply:              maxSupply,
 		OrderQuantityLimits:    orderQuantityLimits,
 		SanityRate:             sanityRate,
@@ -117,9 +330,114 @@ func NewBond(token, name, description string, creator sdk.AccAddress,
 		AllowSells:             allowSells,
 		Signers:                signers,
 		BatchBlocks:            batchBlocks,
+		HatchWhitelist:         hatchWhitelist,
+		State:                  state,
+		CommitRevealSwaps:      commitRevealSwaps,
+		Vesting:                vesting,
+		TransferPolicy:         transferPolicy,
+		TransferAllowList:      transferAllowList,
 	}
 }
 
+func (bond Bond) getAugmentedFunctionValues() (s0, v0 sdk.Dec) {
+	args := bond.FunctionParameters.AsMap()
+	d0 := args["d0"]
+	p0 := args["p0"]
+	theta := args["theta"]
+	kappa := args["kappa"]
+
+	// The hatch raises d0, of which (1-theta) goes to the reserve (r0) and the
+	// rest to the funding pool, minting s0 tokens. The invariant v0 = s0^k/r0
+	// is then used to define the reserve function r(s) = s^k/v0 once open.
+	r0 := d0.Mul(sdk.OneDec().Sub(theta))
+	s0 = d0.Quo(p0)
+	v0 = PowerDec(s0, kappa).Quo(r0)
+	return s0, v0
+}
+
+func (bond Bond) GetHatchSupply() sdk.Coin {
+	if bond.FunctionType != AugmentedFunction {
+		panic("invalid function for function type")
+	}
+	s0, _ := bond.getAugmentedFunctionValues()
+	return sdk.NewCoin(bond.Token, s0.TruncateInt())
+}
+
+func (bond Bond) IsWhitelistedForHatch(address sdk.AccAddress) bool {
+	for _, a := range bond.HatchWhitelist {
+		if a.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasOpenTransferPolicy returns whether the bond's tokens can be held by any
+// address (bonds created before transfer policies were added have none)
+func (bond Bond) HasOpenTransferPolicy() bool {
+	return bond.TransferPolicy == "" || bond.TransferPolicy == OpenTransferPolicy
+}
+
+func (bond Bond) IsOnTransferAllowList(address sdk.AccAddress) bool {
+	for _, a := range bond.TransferAllowList {
+		if a.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func (bond Bond) GetFundingPoolShares(reservePrices sdk.Coins) (shares sdk.Coins) {
+	// Only augmented function bonds in the hatch phase route a fraction of
+	// the reserve prices to the funding pool (rounded down per coin)
+	if bond.FunctionType != AugmentedFunction || bond.State != HatchState {
+		return sdk.Coins{}
+	}
+	theta := bond.FunctionParameters.AsMap()["theta"]
+	for _, p := range reservePrices {
+		share := theta.MulInt(p.Amount).TruncateInt()
+		if share.IsPositive() {
+			shares = shares.Add(sdk.Coins{sdk.NewCoin(p.Denom, share)})
+		}
+	}
+	return shares
+}
+
+// GetEntryTributeRate returns the rate at which entry tributes are charged on
+// top of the reserve prices of buys. Only open augmented function bonds charge
+// entry tributes, at theta/(1-theta), so that theta of what is paid (excluding
+// fees) goes to the funding pool as during the hatch, while the reserve still
+// receives the full reserve prices implied by the curve.
+func (bond Bond) GetEntryTributeRate() sdk.Dec {
+	if bond.FunctionType != AugmentedFunction || bond.State == HatchState {
+		return sdk.ZeroDec()
+	}
+	theta := bond.FunctionParameters.AsMap()["theta"]
+	return theta.Quo(sdk.OneDec().Sub(theta))
+}
+
+//noinspection GoNilness
+func (bond Bond) GetEntryTributes(reservePrices sdk.DecCoins) (tributes sdk.Coins) {
+	rate := bond.GetEntryTributeRate()
+	if rate.IsZero() {
+		return sdk.Coins{}
+	}
+	for _, r := range reservePrices {
+		// Tributes are rounded up, in the same way as fees
+		tribute := RoundFee(sdk.NewDecCoinFromDec(r.Denom, rate.Mul(r.Amount)))
+		if tribute.IsPositive() {
+			tributes = tributes.Add(sdk.Coins{tribute})
+		}
+	}
+	return tributes
+}
+
+// ChargesExitTributes returns whether the bond's exit fees are exit tributes,
+// which are sent to the funding pool instead of the fee address or recipients
+func (bond Bond) ChargesExitTributes() bool {
+	return bond.FunctionType == AugmentedFunction
+}
+
 //noinspection GoNilness
 func (bond Bond) GetNewReserveDecCoins(amount sdk.Dec) (coins sdk.DecCoins) {
 	for _, r := range bond.ReserveTokens {
@@ -134,27 +452,47 @@ func (bond Bond) GetPricesAtSupply(supply sdk.Int) (result sdk.DecCoins, err sdk
 	}
 
 	args := bond.FunctionParameters.AsMap()
-	x := supply
+	x := sdk.NewDecFromInt(supply)
 	switch bond.FunctionType {
 	case PowerFunction:
-		// TODO: should try using simpler approach, especially
-		//       if function params are changed to decimals
 		m := args["m"]
-		n64 := args["n"].Int64()
+		n := args["n"]
 		c := args["c"]
-		temp := x
-		for i := n64; i > 1; i-- {
-			temp = temp.Mul(x)
-		}
-		result = bond.GetNewReserveDecCoins(sdk.NewDecFromInt(temp.Mul(m).Add(c)))
+		temp1 := PowerDec(x, n)
+		result = bond.GetNewReserveDecCoins(temp1.Mul(m).Add(c))
 	case SigmoidFunction:
-		aDec := sdk.NewDecFromInt(args["a"])
+		a := args["a"]
 		b := args["b"]
 		c := args["c"]
 		temp1 := x.Sub(b)
 		temp2 := temp1.Mul(temp1).Add(c)
-		temp3 := SquareRootInt(temp2)
-		result = bond.GetNewReserveDecCoins(aDec.Mul(sdk.NewDecFromInt(temp1).Quo(temp3).Add(sdk.OneDec())))
+		temp3 := SquareRootDec(temp2)
+		result = bond.GetNewReserveDecCoins(a.Mul(temp1.Quo(temp3).Add(sdk.OneDec())))
+	case ExponentialFunction:
+		a := args["a"]
+		b := args["b"]
+		c := args["c"]
+		temp1 := ExpDec(b.Mul(x))
+		result = bond.GetNewReserveDecCoins(a.Mul(temp1).Add(c))
+	case LogarithmicFunction:
+		a := args["a"]
+		b := args["b"]
+		c := args["c"]
+		temp1 := LnDec(b.Mul(x).Add(sdk.OneDec()))
+		result = bond.GetNewReserveDecCoins(a.Mul(temp1).Add(c))
+	case AugmentedFunction:
+		if bond.State == HatchState {
+			// Fixed price during the hatch phase
+			result = bond.GetNewReserveDecCoins(args["p0"])
+		} else {
+			// Derivative of the reserve function: k*s^(k-1)/v0
+			kappa := args["kappa"]
+			_, v0 := bond.getAugmentedFunctionValues()
+			temp1 := PowerDec(x, kappa.Sub(sdk.OneDec()))
+			result = bond.GetNewReserveDecCoins(kappa.Mul(temp1).Quo(v0))
+		}
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
 		return nil, ErrFunctionNotAvailableForFunctionType(DefaultCodespace)
 	default:
@@ -174,7 +512,15 @@ func (bond Bond) GetCurrentPricesPT(reserveBalances sdk.Coins) (sdk.DecCoins, sd
 	case PowerFunction:
 		fallthrough
 	case SigmoidFunction:
+		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case AugmentedFunction:
 		return bond.GetPricesAtSupply(bond.CurrentSupply.Amount)
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
 		return bond.GetPricesToMint(sdk.OneInt(), reserveBalances)
 	default:
@@ -182,37 +528,73 @@ func (bond Bond) GetCurrentPricesPT(reserveBalances sdk.Coins) (sdk.DecCoins, sd
 	}
 }
 
+// HasCurveImpliedReserve returns whether the bond's reserve, in each of its
+// reserve tokens, is implied by its curve at its current supply, which is not
+// the case for swappers, whose prices are instead implied by their reserve
+func (bond Bond) HasCurveImpliedReserve() bool {
+	return bond.FunctionType != SwapperFunction && bond.FunctionType != WeightedSwapperFunction
+}
+
 func (bond Bond) CurveIntegral(supply sdk.Int) (result sdk.Dec) {
 	if supply.IsNegative() {
 		panic(fmt.Sprintf("negative supply for bond %s", bond))
 	}
 
 	args := bond.FunctionParameters.AsMap()
-	x, xDec := supply, sdk.NewDecFromInt(supply)
+	x := sdk.NewDecFromInt(supply)
 	switch bond.FunctionType {
 	case PowerFunction:
-		// TODO: should try using simpler approach, especially
-		//       if function params are changed to decimals
 		m := args["m"]
-		n, n64 := args["n"], args["n"].Int64()
+		n := args["n"]
 		c := args["c"]
-		temp1 := x
-		for i := n64 + 1; i > 1; i-- {
-			temp1 = temp1.Mul(x)
-		}
-		temp2 := sdk.NewDecFromInt(temp1.Mul(m)).Quo(sdk.NewDecFromInt(n.Add(sdk.OneInt())))
-		temp3 := sdk.NewDecFromInt(x.Mul(c))
+		nPlusOne := n.Add(sdk.OneDec())
+		temp1 := PowerDec(x, nPlusOne)
+		temp2 := temp1.Mul(m).Quo(nPlusOne)
+		temp3 := x.Mul(c)
 		result = temp2.Add(temp3)
 	case SigmoidFunction:
-		aDec := sdk.NewDecFromInt(args["a"])
-		b, bDec := args["b"], sdk.NewDecFromInt(args["b"])
-		c, cDec := args["c"], sdk.NewDecFromInt(args["c"])
+		a := args["a"]
+		b := args["b"]
+		c := args["c"]
 		temp1 := x.Sub(b)
 		temp2 := temp1.Mul(temp1).Add(c)
-		temp3 := SquareRootInt(temp2)
-		temp5 := aDec.Mul(temp3.Add(xDec))
-		constant := aDec.Mul(SquareRootDec(bDec.Mul(bDec).Add(cDec)))
+		temp3 := SquareRootDec(temp2)
+		temp5 := a.Mul(temp3.Add(x))
+		constant := a.Mul(SquareRootDec(b.Mul(b).Add(c)))
 		result = temp5.Sub(constant)
+	case ExponentialFunction:
+		// Integral of a*e^(bx)+c is (a/b)*(e^(bx)-1) + cx
+		a := args["a"]
+		b := args["b"]
+		c := args["c"]
+		temp1 := ExpDec(b.Mul(x)).Sub(sdk.OneDec())
+		temp2 := a.Quo(b).Mul(temp1)
+		temp3 := x.Mul(c)
+		result = temp2.Add(temp3)
+	case LogarithmicFunction:
+		// Integral of a*ln(bx+1)+c is (a/b)*((bx+1)*ln(bx+1)-bx) + cx
+		a := args["a"]
+		b := args["b"]
+		c := args["c"]
+		bx := b.Mul(x)
+		bxPlusOne := bx.Add(sdk.OneDec())
+		temp1 := bxPlusOne.Mul(LnDec(bxPlusOne)).Sub(bx)
+		temp2 := a.Quo(b).Mul(temp1)
+		temp3 := x.Mul(c)
+		result = temp2.Add(temp3)
+	case AugmentedFunction:
+		if bond.State == HatchState {
+			// Reserve only keeps the (1-theta) fraction of hatch prices
+			p0 := args["p0"]
+			theta := args["theta"]
+			result = x.Mul(p0).Mul(sdk.OneDec().Sub(theta))
+		} else {
+			// Reserve function: s^k/v0
+			_, v0 := bond.getAugmentedFunctionValues()
+			result = PowerDec(x, args["kappa"]).Quo(v0)
+		}
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
 		panic("invalid function for function type")
 	default:
@@ -237,23 +619,31 @@ func (bond Bond) GetReserveDeltaForLiquidityDelta(mintOrBurn sdk.Int, reserveBal
 	case PowerFunction:
 		fallthrough
 	case SigmoidFunction:
+		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case AugmentedFunction:
 		panic("invalid function for function type")
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
-		resToken1 := bond.ReserveTokens[0]
-		resToken2 := bond.ReserveTokens[1]
-		resBalance1 := sdk.NewDecFromInt(reserveBalances.AmountOf(resToken1))
-		resBalance2 := sdk.NewDecFromInt(reserveBalances.AmountOf(resToken2))
 		mintOrBurnDec := sdk.NewDecFromInt(mintOrBurn)
 
 		// Using Uniswap formulae: x' = (1+-α)x = x +- Δx, where α = Δx/x
-		// Where x is any of the two reserve balances or the current supply
+		// Where x is any of the reserve balances or the current supply
 		// and x' is any of the updated reserve balances or the updated supply
 		// By making Δx subject of the formula: Δx = αx
+		// (Since all reserves change by the same proportion, this holds for
+		// the weighted swapper as well, regardless of the weights)
 		alpha := mintOrBurnDec.Quo(sdk.NewDecFromInt(bond.CurrentSupply.Amount))
 
-		result := sdk.DecCoins{
-			sdk.NewDecCoinFromDec(resToken1, alpha.Mul(resBalance1)),
-			sdk.NewDecCoinFromDec(resToken2, alpha.Mul(resBalance2)),
+		var result sdk.DecCoins
+		for _, r := range bond.ReserveTokens {
+			resBalance := sdk.NewDecFromInt(reserveBalances.AmountOf(r))
+			result = result.Add(sdk.DecCoins{
+				sdk.NewDecCoinFromDec(r, alpha.Mul(resBalance))})
 		}
 		if result.IsAnyNegative() {
 			panic(fmt.Sprintf("negative reserve delta result for bond %s", bond))
@@ -272,9 +662,20 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 	}
 
 	switch bond.FunctionType {
+	case AugmentedFunction:
+		if bond.State == HatchState {
+			// Fixed price during the hatch phase
+			p0 := bond.FunctionParameters.AsMap()["p0"]
+			return bond.GetNewReserveDecCoins(p0.MulInt(mint)), nil
+		}
+		fallthrough
 	case PowerFunction:
 		fallthrough
 	case SigmoidFunction:
+		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
 		var priceToMint sdk.Dec
 		result := bond.CurveIntegral(bond.CurrentSupply.Amount.Add(mint))
 		if reserveBalances.Empty() {
@@ -292,6 +693,8 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 			priceToMint = sdk.OneDec()
 		}
 		return bond.GetNewReserveDecCoins(priceToMint), nil
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
 		if bond.CurrentSupply.Amount.IsZero() {
 			return nil, ErrFunctionRequiresNonZeroCurrentSupply(DefaultCodespace)
@@ -303,6 +706,109 @@ func (bond Bond) GetPricesToMint(mint sdk.Int, reserveBalances sdk.Coins) (sdk.D
 	// Note: fees have to be added to these prices to get actual prices
 }
 
+func (bond Bond) GetMaxMintForBudget(budget sdk.Coins, reserveBalances sdk.Coins) (sdk.Int, sdk.Error) {
+	if budget.IsAnyNegative() {
+		panic(fmt.Sprintf("negative budget for bond %s", bond))
+	} else if reserveBalances.IsAnyNegative() {
+		panic(fmt.Sprintf("negative reserve balance for bond %s", bond))
+	}
+
+	// Since the price is the same amount in each reserve token for all
+	// functions except the swapper, the smallest budget amount is the limit
+	minBudget := budget.AmountOf(bond.ReserveTokens[0])
+	for _, r := range bond.ReserveTokens {
+		minBudget = sdk.MinInt(minBudget, budget.AmountOf(r))
+	}
+
+	// The amount is capped by the max supply (or hatch supply), regardless
+	// of the budget, so the curve is never evaluated beyond the max supply
+	supplyLimit := bond.MaxSupply.Amount
+	if bond.FunctionType == AugmentedFunction && bond.State == HatchState {
+		supplyLimit = sdk.MinInt(supplyLimit, bond.GetHatchSupply().Amount)
+	}
+	if !supplyLimit.GT(bond.CurrentSupply.Amount) {
+		return sdk.ZeroInt(), nil
+	}
+	maxMint := supplyLimit.Sub(bond.CurrentSupply.Amount)
+
+	switch bond.FunctionType {
+	case AugmentedFunction:
+		if bond.State == HatchState {
+			// Fixed price during the hatch phase
+			p0 := bond.FunctionParameters.AsMap()["p0"]
+			mint := sdk.NewDecFromInt(minBudget).Quo(p0).TruncateInt()
+			return sdk.MinInt(mint, maxMint), nil
+		}
+		fallthrough
+	case PowerFunction:
+		fallthrough
+	case SigmoidFunction:
+		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		// The price to mint n tokens is CurveIntegral(supply+n) minus the
+		// (common) reserve balance, as in GetPricesToMint. Since the integral
+		// has no general inverse, it is inverted by searching for the largest
+		// n for which CurveIntegral(supply+n) does not exceed budget+reserve.
+		target := sdk.NewDecFromInt(minBudget)
+		if !reserveBalances.Empty() {
+			target = target.Add(sdk.NewDecFromInt(reserveBalances[0].Amount))
+		}
+		supply := bond.CurrentSupply.Amount
+		withinBudget := func(mint sdk.Int) bool {
+			return bond.CurveIntegral(supply.Add(mint)).LTE(target)
+		}
+
+		// Double the upper bound (up to the max mint) until it is out of
+		// budget, or return the max mint if even that is within budget
+		lo, hi := sdk.ZeroInt(), sdk.OneInt()
+		for withinBudget(hi) {
+			if hi.Equal(maxMint) {
+				return maxMint, nil
+			}
+			lo, hi = hi, sdk.MinInt(hi.MulRaw(2), maxMint)
+		}
+
+		// Binary search between the last amount within budget and the bound
+		for hi.Sub(lo).GT(sdk.OneInt()) {
+			mid := lo.Add(hi).QuoRaw(2)
+			if withinBudget(mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return lo, nil
+	case WeightedSwapperFunction:
+		fallthrough
+	case SwapperFunction:
+		if bond.CurrentSupply.Amount.IsZero() {
+			return sdk.ZeroInt(), ErrFunctionRequiresNonZeroCurrentSupply(DefaultCodespace)
+		}
+
+		// Inverting the Uniswap formula Δx = αx gives α = Δx/x for each of
+		// the reserve balances, where Δx is the budget. The smallest α is the
+		// limit, and the mint amount is αx with x being the current supply.
+		supply := bond.CurrentSupply.Amount
+		var swapperMint sdk.Int
+		for i, r := range bond.ReserveTokens {
+			resBalance := reserveBalances.AmountOf(r)
+			if resBalance.IsZero() {
+				return sdk.ZeroInt(), ErrFunctionRequiresNonZeroCurrentSupply(DefaultCodespace)
+			}
+			mint := budget.AmountOf(r).Mul(supply).Quo(resBalance)
+			if i == 0 || mint.LT(swapperMint) {
+				swapperMint = mint
+			}
+		}
+		return sdk.MinInt(swapperMint, maxMint), nil
+	default:
+		panic("unrecognized function type")
+	}
+	// Note: fees have to be excluded from the budget before calling this
+}
+
 func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.DecCoins {
 	if burn.IsNegative() {
 		panic(fmt.Sprintf("negative burn amount for bond %s", bond))
@@ -314,6 +820,12 @@ func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.
 	case PowerFunction:
 		fallthrough
 	case SigmoidFunction:
+		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case AugmentedFunction:
 		var returnForBurn sdk.Dec
 		result := bond.CurveIntegral(bond.CurrentSupply.Amount.Sub(burn))
 		if reserveBalances.Empty() {
@@ -326,6 +838,8 @@ func (bond Bond) GetReturnsForBurn(burn sdk.Int, reserveBalances sdk.Coins) sdk.
 		}
 		// TODO: investigate possibility of negative returnForBurn
 		return bond.GetNewReserveDecCoins(returnForBurn)
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
 		return bond.GetReserveDeltaForLiquidityDelta(burn, reserveBalances)
 	default:
@@ -345,12 +859,20 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 	case PowerFunction:
 		fallthrough
 	case SigmoidFunction:
+		fallthrough
+	case ExponentialFunction:
+		fallthrough
+	case LogarithmicFunction:
+		fallthrough
+	case AugmentedFunction:
 		return nil, sdk.Coin{}, ErrFunctionNotAvailableForFunctionType(DefaultCodespace)
+	case WeightedSwapperFunction:
+		fallthrough
 	case SwapperFunction:
 		// Check that from and to are reserve tokens
-		if from.Denom != bond.ReserveTokens[0] && from.Denom != bond.ReserveTokens[1] {
+		if !bond.IsReserveToken(from.Denom) {
 			return nil, sdk.Coin{}, ErrTokenIsNotAValidReserveToken(DefaultCodespace, from.Denom)
-		} else if toToken != bond.ReserveTokens[0] && toToken != bond.ReserveTokens[1] {
+		} else if !bond.IsReserveToken(toToken) {
 			return nil, sdk.Coin{}, ErrTokenIsNotAValidReserveToken(DefaultCodespace, toToken)
 		}
 
@@ -367,11 +889,23 @@ func (bond Bond) GetReturnsForSwap(from sdk.Coin, toToken string, reserveBalance
 			return nil, sdk.Coin{}, ErrSwapAmountTooSmallToGiveAnyReturn(DefaultCodespace, from.Denom, toToken)
 		}
 
-		// Calculate output amount using Uniswap formula: Δy = (Δx*y)/(x+Δx)
-		outAmt := inAmt.Mul(outRes).Quo(inRes.Add(inAmt))
+		var outAmt sdk.Int
+		if bond.FunctionType == WeightedSwapperFunction {
+			// Calculate output amount using Balancer formula:
+			// Δy = y*(1-(x/(x+Δx))^(wx/wy)), rounded down
+			weights := bond.GetReserveWeights()
+			inResDec := sdk.NewDecFromInt(inRes)
+			ratio := inResDec.Quo(inResDec.Add(sdk.NewDecFromInt(inAmt)))
+			exponent := weights[from.Denom].Quo(weights[toToken])
+			temp1 := sdk.OneDec().Sub(PowerDec(ratio, exponent))
+			outAmt = temp1.MulInt(outRes).TruncateInt()
+		} else {
+			// Calculate output amount using Uniswap formula: Δy = (Δx*y)/(x+Δx)
+			outAmt = inAmt.Mul(outRes).Quo(inRes.Add(inAmt))
+		}
 
 		// Check that not giving out all of the available outRes or nothing at all
-		if outAmt.Equal(outRes) {
+		if outAmt.GTE(outRes) {
 			return nil, sdk.Coin{}, ErrSwapAmountCausesReserveDepletion(DefaultCodespace, from.Denom, toToken)
 		} else if outAmt.IsZero() {
 			return nil, sdk.Coin{}, ErrSwapAmountTooSmallToGiveAnyReturn(DefaultCodespace, from.Denom, toToken)
@@ -440,6 +974,56 @@ func (bond Bond) ReserveDenomsEqualTo(coins sdk.Coins) bool {
 	return true
 }
 
+func (bond Bond) ReserveDenomsInclude(coins sdk.Coins) bool {
+	reserveTokens := make(map[string]bool)
+	for _, r := range bond.ReserveTokens {
+		reserveTokens[r] = true
+	}
+
+	for _, c := range coins {
+		if !reserveTokens[c.Denom] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (bond Bond) IsReserveToken(denom string) bool {
+	for _, r := range bond.ReserveTokens {
+		if r == denom {
+			return true
+		}
+	}
+	return false
+}
+
+func (bond Bond) GetReserveWeights() map[string]sdk.Dec {
+	switch bond.FunctionType {
+	case WeightedSwapperFunction:
+		// Weights are relative, so they do not need to add up to any value
+		return bond.FunctionParameters.AsMap()
+	case SwapperFunction:
+		// All reserve tokens are equally weighted
+		weights := make(map[string]sdk.Dec)
+		for _, r := range bond.ReserveTokens {
+			weights[r] = sdk.OneDec()
+		}
+		return weights
+	default:
+		panic("invalid function for function type")
+	}
+}
+
+func (bond Bond) IsAcceptingOrders() bool {
+	// Paused and settled bonds do not accept any new orders
+	return bond.State != PausedState && bond.State != SettledState
+}
+
+func (bond Bond) HasCommitRevealSwaps() bool {
+	return bond.CommitRevealSwaps == TRUE
+}
+
 func (bond Bond) AnyOrderQuantityLimitsExceeded(amounts sdk.Coins) bool {
 	return amounts.IsAnyGT(bond.OrderQuantityLimits)
 }
@@ -450,13 +1034,6 @@ func (bond Bond) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 		return false
 	}
 
-	// Get new rate from new balances
-	resToken1 := bond.ReserveTokens[0]
-	resToken2 := bond.ReserveTokens[1]
-	resBalance1 := sdk.NewDecFromInt(newReserves.AmountOf(resToken1))
-	resBalance2 := sdk.NewDecFromInt(newReserves.AmountOf(resToken2))
-	exchangeRate := resBalance1.Quo(resBalance2)
-
 	// Get max and min acceptable rates
 	sanityMarginDecimal := bond.SanityMarginPercentage.Quo(sdk.NewDec(100))
 	upperPercentage := sdk.OneDec().Add(sanityMarginDecimal)
@@ -469,5 +1046,19 @@ func (bond Bond) ReservesViolateSanityRate(newReserves sdk.Coins) bool {
 		minRate = sdk.ZeroDec()
 	}
 
-	return exchangeRate.LT(minRate) || exchangeRate.GT(maxRate)
+	// Get new rates, of the first reserve token per each of the other reserve
+	// tokens, from new balances. Each balance is divided by its weight, which
+	// gives the plain balance ratio for the (equally weighted) swapper.
+	weights := bond.GetReserveWeights()
+	resToken1 := bond.ReserveTokens[0]
+	resBalance1 := sdk.NewDecFromInt(newReserves.AmountOf(resToken1))
+	weightedBalance1 := resBalance1.Quo(weights[resToken1])
+	for _, r := range bond.ReserveTokens[1:] {
+		resBalance := sdk.NewDecFromInt(newReserves.AmountOf(r))
+		exchangeRate := weightedBalance1.Quo(resBalance.Quo(weights[r]))
+		if exchangeRate.LT(minRate) || exchangeRate.GT(maxRate) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,361 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func functionParams(params ...string) (fps FunctionParams) {
+	for i := 0; i < len(params); i += 2 {
+		fps = append(fps, NewFunctionParam(params[i], sdk.MustNewDecFromStr(params[i+1])))
+	}
+	return fps
+}
+
+func testBond(functionType string, fps FunctionParams, maxSupply int64) Bond {
+	return Bond{
+		Token:              "token",
+		FunctionType:       functionType,
+		FunctionParameters: fps,
+		ReserveTokens:      []string{"res"},
+		MaxSupply:          sdk.NewInt64Coin("token", maxSupply),
+		CurrentSupply:      sdk.NewInt64Coin("token", 0),
+		TxFeePercentage:    sdk.ZeroDec(),
+		ExitFeePercentage:  sdk.ZeroDec(),
+		State:              OpenState,
+	}
+}
+
+func TestCurveIntegrals(t *testing.T) {
+	testCases := []struct {
+		functionType string
+		params       FunctionParams
+		supply       int64
+		price        string
+		integral     string
+	}{
+		// a*e^(bx)+c and (a/b)*(e^(bx)-1)+cx
+		{ExponentialFunction, functionParams("a", "1", "b", "0.001", "c", "1"),
+			0, "2", "0"},
+		{ExponentialFunction, functionParams("a", "1", "b", "0.001", "c", "1"),
+			1000, "3.718281828459045235", "2718.281828459045235"},
+		{ExponentialFunction, functionParams("a", "2", "b", "0.0001", "c", "0.5"),
+			100000, "44053.431589613433034", "440559315.896134330339158"},
+		// a*ln(bx+1)+c and (a/b)*((bx+1)*ln(bx+1)-bx)+cx
+		{LogarithmicFunction, functionParams("a", "1", "b", "1", "c", "1"),
+			0, "1", "0"},
+		{LogarithmicFunction, functionParams("a", "2", "b", "0.5", "c", "1"),
+			2, "2.386294361119890619", "3.545177444479562475"},
+		{LogarithmicFunction, functionParams("a", "1", "b", "0.001", "c", "0"),
+			1000000, "6.908754779315220585", "5915663.534094535805806"},
+	}
+	for _, tc := range testCases {
+		bond := testBond(tc.functionType, tc.params, 1000000)
+		supply := sdk.NewInt(tc.supply)
+
+		prices, err := bond.GetPricesAtSupply(supply)
+		require.Nil(t, err)
+		requireApproxEqual(t, sdk.MustNewDecFromStr(tc.price), prices.AmountOf("res"))
+		requireApproxEqual(t, sdk.MustNewDecFromStr(tc.integral), bond.CurveIntegral(supply))
+	}
+}
+
+func TestValidateForMaxSupply(t *testing.T) {
+	exponential := functionParams("a", "1", "b", "0.001", "c", "1")
+
+	// b*maxSupply = 1000 exceeds the max exponent, so e^(b*maxSupply) cannot
+	// be calculated, but b*maxSupply = 100 is the max exponent itself
+	require.NotNil(t, exponential.ValidateForMaxSupply(ExponentialFunction, sdk.NewInt(1000000)))
+	require.Nil(t, exponential.ValidateForMaxSupply(ExponentialFunction, sdk.NewInt(100000)))
+
+	// Max supplies for which b*maxSupply itself would overflow are rejected
+	require.NotNil(t, exponential.ValidateForMaxSupply(ExponentialFunction,
+		sdk.NewIntWithDecimal(1, 76)))
+
+	// Any accepted bond can be priced up to its max supply
+	bond := testBond(ExponentialFunction, exponential, 100000)
+	require.NotPanics(t, func() {
+		_, _ = bond.GetPricesAtSupply(bond.MaxSupply.Amount)
+		bond.CurveIntegral(bond.MaxSupply.Amount)
+	})
+
+	// Other functions are rejected if they cannot be calculated at the max
+	// supply, e.g. non-integer powers x^n = e^(n*ln(x)) with n*ln(x) > 100
+	power := functionParams("m", "1", "n", "2", "c", "1")
+	require.Nil(t, power.ValidateForMaxSupply(PowerFunction, sdk.NewIntWithDecimal(1, 18)))
+	power = functionParams("m", "1", "n", "2.5", "c", "1")
+	require.Nil(t, power.ValidateForMaxSupply(PowerFunction, sdk.NewIntWithDecimal(1, 12)))
+	require.NotNil(t, power.ValidateForMaxSupply(PowerFunction, sdk.NewIntWithDecimal(1, 20)))
+	augmented := functionParams("d0", "500", "p0", "0.01", "theta", "0.4", "kappa", "2.5")
+	require.Nil(t, augmented.ValidateForMaxSupply(AugmentedFunction, sdk.NewIntWithDecimal(1, 12)))
+	require.NotNil(t, augmented.ValidateForMaxSupply(AugmentedFunction, sdk.NewIntWithDecimal(1, 20)))
+	logarithmic := functionParams("a", "1", "b", "1000", "c", "1")
+	require.Nil(t, logarithmic.ValidateForMaxSupply(LogarithmicFunction, sdk.NewIntWithDecimal(1, 30)))
+
+	// Swappers are not restricted by the max supply
+	require.Nil(t, FunctionParams{}.ValidateForMaxSupply(SwapperFunction, sdk.NewIntWithDecimal(1, 70)))
+}
+
+func TestAugmentedFunctionParamsValidate(t *testing.T) {
+	testCases := []struct {
+		params FunctionParams
+		valid  bool
+	}{
+		{functionParams("d0", "500", "p0", "0.01", "theta", "0.4", "kappa", "3"), true},
+		{functionParams("d0", "500", "p0", "0.01", "theta", "0.4", "kappa", "1"), true},
+		{functionParams("d0", "500", "p0", "0.01", "theta", "0.4", "kappa", "1.5"), true},
+		{functionParams("d0", "500", "p0", "0.01", "theta", "0.4", "kappa", "0.5"), false},
+		{functionParams("d0", "500", "p0", "0.01", "theta", "1", "kappa", "3"), false},
+	}
+	for _, tc := range testCases {
+		err := tc.params.Validate(AugmentedFunction)
+		require.Equal(t, tc.valid, err == nil, "%s", tc.params)
+
+		// Any accepted augmented function can be priced once open
+		if tc.valid {
+			bond := testBond(AugmentedFunction, tc.params, 1000000)
+			require.NotPanics(t, func() {
+				_, _ = bond.GetPricesAtSupply(sdk.ZeroInt())
+				_, _ = bond.GetPricesAtSupply(bond.GetHatchSupply().Amount)
+				bond.CurveIntegral(bond.GetHatchSupply().Amount)
+			})
+		}
+	}
+}
+
+func TestGetMaxMintForBudget(t *testing.T) {
+	power := testBond(PowerFunction, functionParams("m", "1", "n", "1", "c", "0"), 1000)
+	power.CurrentSupply = sdk.NewInt64Coin("token", 100)
+	reserve := sdk.NewCoins(sdk.NewInt64Coin("res", 5000)) // 100^2/2
+
+	// Minting n tokens costs ((100+n)^2-100^2)/2, so 2200 buys 20 tokens
+	budget := sdk.NewCoins(sdk.NewInt64Coin("res", 2200))
+	mint, err := power.GetMaxMintForBudget(budget, reserve)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(20), mint)
+
+	// A budget that covers more than the max supply is capped by it
+	budget = sdk.NewCoins(sdk.NewInt64Coin("res", 1000000000))
+	mint, err = power.GetMaxMintForBudget(budget, reserve)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(900), mint)
+
+	// Nothing can be minted once the max supply is reached
+	power.CurrentSupply = power.MaxSupply
+	mint, err = power.GetMaxMintForBudget(budget, reserve)
+	require.Nil(t, err)
+	require.True(t, mint.IsZero())
+
+	// Hatch phase buys are capped by the hatch supply (d0/p0 = 100)
+	augmented := testBond(AugmentedFunction, functionParams(
+		"d0", "1000", "p0", "10", "theta", "0.4", "kappa", "3"), 1000)
+	augmented.State = HatchState
+	mint, err = augmented.GetMaxMintForBudget(budget, nil)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(100), mint)
+
+	// Exponential function with b*maxSupply at the max exponent never
+	// evaluates the curve beyond the max supply, regardless of budget
+	exponential := testBond(ExponentialFunction, functionParams(
+		"a", "1", "b", "0.001", "c", "1"), 100000)
+	budget = sdk.NewCoins(sdk.NewCoin("res", sdk.NewIntWithDecimal(1, 60)))
+	mint, err = exponential.GetMaxMintForBudget(budget, nil)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(100000), mint)
+
+	// Swapper mints are capped by the max supply as well
+	swapper := testBond(SwapperFunction, nil, 1500)
+	swapper.ReserveTokens = []string{"resa", "resb"}
+	swapper.CurrentSupply = sdk.NewInt64Coin("token", 1000)
+	reserves := sdk.NewCoins(sdk.NewInt64Coin("resa", 1000), sdk.NewInt64Coin("resb", 2000))
+	budget = sdk.NewCoins(sdk.NewInt64Coin("resa", 100), sdk.NewInt64Coin("resb", 100))
+	mint, err = swapper.GetMaxMintForBudget(budget, reserves)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(50), mint)
+	budget = sdk.NewCoins(sdk.NewInt64Coin("resa", 5000), sdk.NewInt64Coin("resb", 5000))
+	mint, err = swapper.GetMaxMintForBudget(budget, reserves)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(500), mint)
+}
+
+func mustParseCoins(coinsStr string) sdk.Coins {
+	coins, err := sdk.ParseCoins(coinsStr)
+	if err != nil {
+		panic(err)
+	}
+	return coins
+}
+
+// weightedSwapper returns a weighted swapper with a supply of 1000 and a
+// weight for each reserve token, given as alternating tokens and weights
+func weightedSwapper(weights ...string) Bond {
+	bond := testBond(WeightedSwapperFunction, functionParams(weights...), 1000000)
+	bond.ReserveTokens = nil
+	for i := 0; i < len(weights); i += 2 {
+		bond.ReserveTokens = append(bond.ReserveTokens, weights[i])
+	}
+	bond.CurrentSupply = sdk.NewInt64Coin("token", 1000)
+	return bond
+}
+
+func TestWeightedSwapperGetReturnsForSwap(t *testing.T) {
+	eightTokens := []string{"res1", "1", "res2", "2", "res3", "3", "res4", "4",
+		"res5", "5", "res6", "6", "res7", "7", "res8", "8"}
+	eightReserves := mustParseCoins(
+		"1000res1,1000res2,1000res3,1000res4,1000res5,1000res6,1000res7,1000res8")
+	testCases := []struct {
+		weights  []string
+		reserves sdk.Coins
+		from     sdk.Coin
+		toToken  string
+		returns  int64
+	}{
+		// Equal weights: Δy = (Δx*y)/(x+Δx) = 100*1000/1100 = 90.9
+		{[]string{"resa", "1", "resb", "1"}, mustParseCoins("1000resa,1000resb"),
+			sdk.NewInt64Coin("resa", 100), "resb", 90},
+		{[]string{"resa", "0.5", "resb", "0.5"}, mustParseCoins("1000resa,1000resb"),
+			sdk.NewInt64Coin("resa", 100), "resb", 90},
+		// 80/20 weights: Δy = y*(1-(x/(x+Δx))^(wx/wy)), with an integer ratio
+		// of 4 in one direction and a fractional ratio of 0.25 in the other
+		{[]string{"resa", "0.8", "resb", "0.2"}, mustParseCoins("1000resa,1000resb"),
+			sdk.NewInt64Coin("resa", 100), "resb", 316}, // 1000*(1-(10/11)^4) = 316.99
+		{[]string{"resa", "0.8", "resb", "0.2"}, mustParseCoins("1000resa,1000resb"),
+			sdk.NewInt64Coin("resb", 100), "resa", 23}, // 1000*(1-(10/11)^0.25) = 23.55
+		{[]string{"resa", "0.8", "resb", "0.2"}, mustParseCoins("4000resa,2000resb"),
+			sdk.NewInt64Coin("resa", 100), "resb", 188}, // 2000*(1-(40/41)^4) = 188.10
+		{[]string{"resa", "0.8", "resb", "0.2"}, mustParseCoins("2000resa,4000resb"),
+			sdk.NewInt64Coin("resb", 100), "resa", 12}, // 2000*(1-(40/41)^0.25) = 12.31
+		// Fractional ratios of 2/3 and 3/2
+		{[]string{"resa", "2", "resb", "3"}, mustParseCoins("1000resa,1000resb"),
+			sdk.NewInt64Coin("resa", 100), "resb", 61}, // 1000*(1-(10/11)^(2/3)) = 61.56
+		{[]string{"resa", "2", "resb", "3"}, mustParseCoins("1000resa,1000resb"),
+			sdk.NewInt64Coin("resb", 100), "resa", 133}, // 1000*(1-(10/11)^1.5) = 133.22
+		// Eight reserve tokens, only two of which are involved in the swap
+		{eightTokens, eightReserves,
+			sdk.NewInt64Coin("res2", 100), "res4", 46}, // 1000*(1-(10/11)^0.5) = 46.54
+		{eightTokens, eightReserves,
+			sdk.NewInt64Coin("res8", 100), "res2", 316}, // 1000*(1-(10/11)^4) = 316.99
+	}
+	for _, tc := range testCases {
+		bond := weightedSwapper(tc.weights...)
+		require.Nil(t, bond.FunctionParameters.Validate(WeightedSwapperFunction))
+		require.Nil(t, bond.FunctionParameters.ValidateWeights(bond.ReserveTokens))
+
+		returns, txFee, err := bond.GetReturnsForSwap(tc.from, tc.toToken, tc.reserves)
+		require.Nil(t, err)
+		require.True(t, txFee.IsZero())
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(tc.toToken, tc.returns)), returns, "%s", tc.weights)
+	}
+}
+
+func TestWeightedSwapperWithEqualWeightsMatchesSwapper(t *testing.T) {
+	swapper := testBond(SwapperFunction, nil, 1000000)
+	swapper.ReserveTokens = []string{"resa", "resb"}
+	reserves := mustParseCoins("1234resa,5678resb")
+
+	for _, weight := range []string{"1", "0.3", "7"} {
+		weighted := weightedSwapper("resa", weight, "resb", weight)
+		for _, amount := range []int64{5, 10, 99, 1000, 12345} {
+			for _, from := range []sdk.Coin{
+				sdk.NewInt64Coin("resa", amount), sdk.NewInt64Coin("resb", amount)} {
+				toToken := "resa"
+				if from.Denom == "resa" {
+					toToken = "resb"
+				}
+				expected, _, err := swapper.GetReturnsForSwap(from, toToken, reserves)
+				require.Nil(t, err)
+				returns, _, err := weighted.GetReturnsForSwap(from, toToken, reserves)
+				require.Nil(t, err)
+				require.Equal(t, expected, returns, "%s weights, %s", weight, from)
+			}
+		}
+	}
+}
+
+func TestGetReserveWeights(t *testing.T) {
+	weighted := weightedSwapper("resa", "0.8", "resb", "0.2")
+	require.Equal(t, map[string]sdk.Dec{
+		"resa": sdk.MustNewDecFromStr("0.8"),
+		"resb": sdk.MustNewDecFromStr("0.2"),
+	}, weighted.GetReserveWeights())
+
+	// The (unweighted) swapper weighs all reserve tokens equally
+	swapper := testBond(SwapperFunction, nil, 1000000)
+	swapper.ReserveTokens = []string{"resa", "resb"}
+	require.Equal(t, map[string]sdk.Dec{
+		"resa": sdk.OneDec(),
+		"resb": sdk.OneDec(),
+	}, swapper.GetReserveWeights())
+}
+
+func TestValidateWeights(t *testing.T) {
+	testCases := []struct {
+		weights       FunctionParams
+		reserveTokens []string
+		valid         bool
+	}{
+		{functionParams("resa", "1", "resb", "1"), []string{"resa", "resb"}, true},
+		{functionParams("resa", "0.8", "resb", "0.2"), []string{"resa", "resb"}, true},
+		{functionParams("res1", "1", "res2", "2", "res3", "3", "res4", "4",
+			"res5", "5", "res6", "6", "res7", "7", "res8", "8"),
+			[]string{"res1", "res2", "res3", "res4", "res5", "res6", "res7", "res8"}, true},
+		// Too few or too many reserve tokens
+		{functionParams("resa", "1"), []string{"resa"}, false},
+		{functionParams("res1", "1", "res2", "2", "res3", "3", "res4", "4",
+			"res5", "5", "res6", "6", "res7", "7", "res8", "8", "res9", "9"),
+			[]string{"res1", "res2", "res3", "res4", "res5", "res6", "res7", "res8", "res9"}, false},
+		// Weights that do not match the reserve tokens
+		{functionParams("resa", "1"), []string{"resa", "resb"}, false},
+		{functionParams("resa", "1", "resb", "1", "resc", "1"), []string{"resa", "resb"}, false},
+		{functionParams("resa", "1", "resc", "1"), []string{"resa", "resb"}, false},
+		{functionParams("resa", "1", "resa", "2"), []string{"resa", "resb"}, false},
+	}
+	for _, tc := range testCases {
+		err := tc.weights.ValidateWeights(tc.reserveTokens)
+		require.Equal(t, tc.valid, err == nil, "%s", tc.weights)
+	}
+
+	// Weights have to be positive
+	require.Nil(t, functionParams("resa", "1", "resb", "1").Validate(WeightedSwapperFunction))
+	require.NotNil(t, functionParams("resa", "1", "resb", "0").Validate(WeightedSwapperFunction))
+	require.NotNil(t, functionParams("resa", "1", "resb", "-1").Validate(WeightedSwapperFunction))
+}
+
+func TestWeightedSwapperLiquidityDelta(t *testing.T) {
+	testCases := []struct {
+		weights  []string
+		reserves sdk.Coins
+	}{
+		{[]string{"resa", "0.8", "resb", "0.2"}, mustParseCoins("1000resa,2000resb")},
+		{[]string{"res1", "1", "res2", "2", "res3", "3", "res4", "4",
+			"res5", "5", "res6", "6", "res7", "7", "res8", "8"},
+			mustParseCoins("1000res1,2000res2,3000res3,4000res4,5000res5,6000res6,7000res7,8000res8")},
+	}
+	for _, tc := range testCases {
+		bond := weightedSwapper(tc.weights...)
+
+		// Minting or burning 10% of the supply of 1000 adds or removes 10% of
+		// each reserve, regardless of the weights
+		expected := sdk.NewDecCoins(tc.reserves).QuoDec(sdk.NewDec(10))
+		require.Equal(t, expected, bond.GetReserveDeltaForLiquidityDelta(sdk.NewInt(100), tc.reserves))
+		prices, err := bond.GetPricesToMint(sdk.NewInt(100), tc.reserves)
+		require.Nil(t, err)
+		require.Equal(t, expected, prices)
+		require.Equal(t, expected, bond.GetReturnsForBurn(sdk.NewInt(100), tc.reserves))
+
+		// The smallest budget relative to its reserve limits the mint, so a
+		// budget of 10% of each reserve mints 100 tokens, but one of 5% of
+		// any reserve mints 50
+		budget := RoundReservePrices(expected)
+		mint, err := bond.GetMaxMintForBudget(budget, tc.reserves)
+		require.Nil(t, err)
+		require.Equal(t, sdk.NewInt(100), mint)
+		last := bond.ReserveTokens[len(bond.ReserveTokens)-1]
+		budget = budget.Sub(sdk.NewCoins(sdk.NewCoin(last, tc.reserves.AmountOf(last).QuoRaw(20))))
+		mint, err = bond.GetMaxMintForBudget(budget, tc.reserves)
+		require.Nil(t, err)
+		require.Equal(t, sdk.NewInt(50), mint)
+	}
+}
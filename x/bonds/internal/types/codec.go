@@ -20,9 +20,27 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&BuyOrder{}, "cosmos-sdk/BuyOrder", nil)
 	cdc.RegisterConcrete(&SellOrder{}, "cosmos-sdk/SellOrder", nil)
 	cdc.RegisterConcrete(&SwapOrder{}, "cosmos-sdk/SwapOrder", nil)
+	cdc.RegisterConcrete(&SwapCommit{}, "cosmos-sdk/SwapCommit", nil)
+	cdc.RegisterConcrete(&PriceHistoryEntry{}, "cosmos-sdk/PriceHistoryEntry", nil)
+	cdc.RegisterConcrete(&BondProposal{}, "cosmos-sdk/BondProposal", nil)
+	cdc.RegisterConcrete(&BondProposalVote{}, "cosmos-sdk/BondProposalVote", nil)
+	cdc.RegisterConcrete(&FeeRecipient{}, "cosmos-sdk/FeeRecipient", nil)
+	cdc.RegisterConcrete(&FeeRecipients{}, "cosmos-sdk/FeeRecipients", nil)
 	cdc.RegisterConcrete(MsgCreateBond{}, "cosmos-sdk/MsgCreateBond", nil)
 	cdc.RegisterConcrete(MsgEditBond{}, "cosmos-sdk/MsgEditBond", nil)
 	cdc.RegisterConcrete(MsgBuy{}, "cosmos-sdk/MsgBuy", nil)
+	cdc.RegisterConcrete(MsgBuyWithBudget{}, "cosmos-sdk/MsgBuyWithBudget", nil)
 	cdc.RegisterConcrete(MsgSell{}, "cosmos-sdk/MsgSell", nil)
 	cdc.RegisterConcrete(MsgSwap{}, "cosmos-sdk/MsgSwap", nil)
+	cdc.RegisterConcrete(MsgCommitSwap{}, "cosmos-sdk/MsgCommitSwap", nil)
+	cdc.RegisterConcrete(MsgRevealSwap{}, "cosmos-sdk/MsgRevealSwap", nil)
+	cdc.RegisterConcrete(MsgUpdateBondState{}, "cosmos-sdk/MsgUpdateBondState", nil)
+	cdc.RegisterConcrete(MsgPauseBond{}, "cosmos-sdk/MsgPauseBond", nil)
+	cdc.RegisterConcrete(MsgResumeBond{}, "cosmos-sdk/MsgResumeBond", nil)
+	cdc.RegisterConcrete(MsgCloseBond{}, "cosmos-sdk/MsgCloseBond", nil)
+	cdc.RegisterConcrete(MsgSubmitBondProposal{}, "cosmos-sdk/MsgSubmitBondProposal", nil)
+	cdc.RegisterConcrete(MsgVoteBondProposal{}, "cosmos-sdk/MsgVoteBondProposal", nil)
+	cdc.RegisterConcrete(MsgWithdrawBondFees{}, "cosmos-sdk/MsgWithdrawBondFees", nil)
+	cdc.RegisterConcrete(MsgWithdrawFundingPool{}, "cosmos-sdk/MsgWithdrawFundingPool", nil)
+	cdc.RegisterConcrete(MsgClaimVested{}, "cosmos-sdk/MsgClaimVested", nil)
 }
@@ -45,6 +45,47 @@ const (
 	CodeOrderLimitExceeded     CodeType = 322
 	CodeSanityRateViolated     CodeType = 323
 	CodeFeeTooLarge            CodeType = 324
+
+	// Bond states
+	CodeUnrecognizedBondState  CodeType = 325
+	CodeInvalidStateForAction  CodeType = 326
+	CodeInvalidStateTransition CodeType = 327
+	CodeAddressNotWhitelisted  CodeType = 328
+
+	// Orders
+	CodeOrderExpired     CodeType = 329
+	CodeMinReturnsNotMet CodeType = 330
+	CodeBudgetTooSmall   CodeType = 331
+
+	// Swap commitments
+	CodeCommitRevealSwaps  CodeType = 332
+	CodeSwapCommitNotFound CodeType = 333
+	CodeSwapCommitInvalid  CodeType = 334
+
+	// Bond proposals
+	CodeProposalNotFound   CodeType = 335
+	CodeProposalInvalid    CodeType = 336
+	CodeNoVotingPower      CodeType = 337
+	CodeProposalNotApplied CodeType = 338
+
+	// Fees
+	CodeInvalidFeeRecipients CodeType = 339
+	CodeNoAccruedFees        CodeType = 340
+
+	// Vesting
+	CodeInvalidVestingConfig CodeType = 341
+	CodeNoVestedTokens       CodeType = 342
+
+	// Transfer policies
+	CodeInvalidTransferPolicy CodeType = 343
+	CodeTransferNotAllowed    CodeType = 344
+
+	// Funding pools
+	CodeFundingPoolEmpty      CodeType = 345
+	CodeFundingPoolNotAllowed CodeType = 346
+
+	// Legacy reserves
+	CodeSharedReserveCannotBeSplit CodeType = 347
 )
 
 func ErrArgumentCannotBeEmpty(codespace sdk.CodespaceType, argument string) sdk.Error {
@@ -67,6 +108,11 @@ func ErrFunctionParameterMissingOrNonInteger(codespace sdk.CodespaceType, param
 	return sdk.NewError(codespace, CodeArgumentMissingOrIncorrectType, errMsg)
 }
 
+func ErrFunctionParameterMissingOrNonFloat(codespace sdk.CodespaceType, param string) sdk.Error {
+	errMsg := fmt.Sprintf("%s parameter is missing or is not a float", param)
+	return sdk.NewError(codespace, CodeArgumentMissingOrIncorrectType, errMsg)
+}
+
 func ErrArgumentMissingOrNonFloat(codespace sdk.CodespaceType, arg string) sdk.Error {
 	errMsg := fmt.Sprintf("%s argument is missing or is not a float", arg)
 	return sdk.NewError(codespace, CodeArgumentMissingOrIncorrectType, errMsg)
@@ -92,6 +138,11 @@ func ErrIncorrectNumberOfReserveTokens(codespace sdk.CodespaceType, expected int
 	return sdk.NewError(codespace, CodeIncorrectNumberOfValues, errMsg)
 }
 
+func ErrNumberOfReserveTokensOutOfRange(codespace sdk.CodespaceType, min, max int) sdk.Error {
+	errMsg := fmt.Sprintf("Number of reserve tokens must be between %d and %d", min, max)
+	return sdk.NewError(codespace, CodeIncorrectNumberOfValues, errMsg)
+}
+
 func ErrIncorrectNumberOfFunctionParameters(codespace sdk.CodespaceType, expected int) sdk.Error {
 	errMsg := fmt.Sprintf("Incorrect number of function parameters; expected: %d", expected)
 	return sdk.NewError(codespace, CodeIncorrectNumberOfValues, errMsg)
@@ -127,11 +178,6 @@ func ErrDuplicateReserveToken(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidBond, errMsg)
 }
 
-func ErrReserveAddrCannotBeFeeAddr(codespace sdk.CodespaceType) sdk.Error {
-	errMsg := "Reserve address cannot be the fee address"
-	return sdk.NewError(codespace, CodeInvalidBond, errMsg)
-}
-
 func ErrUnrecognizedFunctionType(codespace sdk.CodespaceType) sdk.Error {
 	errMsg := "Unrecognized function type"
 	return sdk.NewError(codespace, CodeUnrecognizedFunctionType, errMsg)
@@ -142,6 +188,21 @@ func ErrInvalidFunctionParameter(codespace sdk.CodespaceType, parameter string)
 	return sdk.NewError(codespace, CodeInvalidFunctionParameter, errMsg)
 }
 
+func ErrFunctionParameterTooLargeForMaxSupply(codespace sdk.CodespaceType, parameter string, maxSupply sdk.Int) sdk.Error {
+	errMsg := fmt.Sprintf("Function parameter '%s' is too large for max supply %s", parameter, maxSupply)
+	return sdk.NewError(codespace, CodeInvalidFunctionParameter, errMsg)
+}
+
+func ErrFunctionCannotBeCalculatedAtMaxSupply(codespace sdk.CodespaceType, maxSupply sdk.Int) sdk.Error {
+	errMsg := fmt.Sprintf("Function cannot be calculated at max supply %s", maxSupply)
+	return sdk.NewError(codespace, CodeInvalidFunctionParameter, errMsg)
+}
+
+func ErrWeightsDoNotMatchReserveTokens(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Function parameters must be exactly one weight per reserve token"
+	return sdk.NewError(codespace, CodeInvalidFunctionParameter, errMsg)
+}
+
 func ErrFunctionNotAvailableForFunctionType(codespace sdk.CodespaceType) sdk.Error {
 	errMsg := "Function is not available for the function type"
 	return sdk.NewError(codespace, CodeFunctionNotAvailableForFunctionType, errMsg)
@@ -192,6 +253,16 @@ func ErrCannotBurnMoreThanSupply(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidResultantSupply, errMsg)
 }
 
+func ErrCannotMintMoreThanHatchSupply(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Cannot mint more tokens than the hatch supply"
+	return sdk.NewError(codespace, CodeInvalidResultantSupply, errMsg)
+}
+
+func ErrMaxSupplyBelowCurrentSupply(codespace sdk.CodespaceType, maxSupply, currentSupply sdk.Coin) sdk.Error {
+	errMsg := fmt.Sprintf("Max supply %s is less than the current supply %s", maxSupply.String(), currentSupply.String())
+	return sdk.NewError(codespace, CodeInvalidResultantSupply, errMsg)
+}
+
 func ErrMaxPriceExceeded(codespace sdk.CodespaceType, totalPrice, maxPrice sdk.Coins) sdk.Error {
 	errMsg := fmt.Sprintf("Actual prices %s exceed max prices %s", totalPrice.String(), maxPrice.String())
 	return sdk.NewError(codespace, CodeMaxPriceExceeded, errMsg)
@@ -221,3 +292,198 @@ func ErrFeesCannotBeOrExceed100Percent(codespace sdk.CodespaceType) sdk.Error {
 	errMsg := "Sum of fees is or exceeds 100 percent"
 	return sdk.NewError(codespace, CodeFeeTooLarge, errMsg)
 }
+
+func ErrUnrecognizedBondState(codespace sdk.CodespaceType, state string) sdk.Error {
+	errMsg := fmt.Sprintf("Unrecognized bond state '%s'", state)
+	return sdk.NewError(codespace, CodeUnrecognizedBondState, errMsg)
+}
+
+func ErrInvalidStateForAction(codespace sdk.CodespaceType, state string) sdk.Error {
+	errMsg := fmt.Sprintf("Cannot perform that action while bond is in state '%s'", state)
+	return sdk.NewError(codespace, CodeInvalidStateForAction, errMsg)
+}
+
+func ErrOrderCancelledByBondClosure(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Order cancelled since the bond was closed"
+	return sdk.NewError(codespace, CodeInvalidStateForAction, errMsg)
+}
+
+func ErrOrderCancelledByZeroHeightExport(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Order cancelled since the chain was exported for a zero-height genesis"
+	return sdk.NewError(codespace, CodeInvalidStateForAction, errMsg)
+}
+
+func ErrInvalidStateTransition(codespace sdk.CodespaceType, from, to string) sdk.Error {
+	errMsg := fmt.Sprintf("Cannot change bond state from '%s' to '%s'", from, to)
+	return sdk.NewError(codespace, CodeInvalidStateTransition, errMsg)
+}
+
+func ErrCannotChangeStateWithPendingBuys(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Cannot change bond state while the current batch has pending buys"
+	return sdk.NewError(codespace, CodeInvalidStateTransition, errMsg)
+}
+
+func ErrAddressNotWhitelisted(codespace sdk.CodespaceType, address sdk.AccAddress) sdk.Error {
+	errMsg := fmt.Sprintf("Address %s is not whitelisted to buy during the hatch", address.String())
+	return sdk.NewError(codespace, CodeAddressNotWhitelisted, errMsg)
+}
+
+func ErrOrderAlreadyExpired(codespace sdk.CodespaceType, goodTillBlock int64) sdk.Error {
+	errMsg := fmt.Sprintf("Good-till-block %d has already passed", goodTillBlock)
+	return sdk.NewError(codespace, CodeOrderExpired, errMsg)
+}
+
+func ErrOrderExpired(codespace sdk.CodespaceType, goodTillBlock int64) sdk.Error {
+	errMsg := fmt.Sprintf("Order expired at good-till-block %d", goodTillBlock)
+	return sdk.NewError(codespace, CodeOrderExpired, errMsg)
+}
+
+func ErrMinReturnsNotMet(codespace sdk.CodespaceType, totalReturns, minReturns sdk.Coins) sdk.Error {
+	errMsg := fmt.Sprintf("Actual returns %s are less than min returns %s", totalReturns.String(), minReturns.String())
+	return sdk.NewError(codespace, CodeMinReturnsNotMet, errMsg)
+}
+
+func ErrBudgetTooSmallToBuyAnyTokens(codespace sdk.CodespaceType, budget sdk.Coins, token string) sdk.Error {
+	errMsg := fmt.Sprintf("Budget %s is too small to buy any %s", budget.String(), token)
+	return sdk.NewError(codespace, CodeBudgetTooSmall, errMsg)
+}
+
+func ErrSwapsRequireCommitReveal(codespace sdk.CodespaceType, token string) sdk.Error {
+	errMsg := fmt.Sprintf("Bond %s only accepts committed and revealed swaps", token)
+	return sdk.NewError(codespace, CodeCommitRevealSwaps, errMsg)
+}
+
+func ErrCommitRevealSwapsNotEnabled(codespace sdk.CodespaceType, token string) sdk.Error {
+	errMsg := fmt.Sprintf("Bond %s does not have commit-reveal swaps enabled", token)
+	return sdk.NewError(codespace, CodeCommitRevealSwaps, errMsg)
+}
+
+func ErrSwapCommitNotFound(codespace sdk.CodespaceType, hash string) sdk.Error {
+	errMsg := fmt.Sprintf("No pending swap commitment found for hash %s", hash)
+	return sdk.NewError(codespace, CodeSwapCommitNotFound, errMsg)
+}
+
+func ErrInvalidSwapCommitHash(codespace sdk.CodespaceType, hash string) sdk.Error {
+	errMsg := fmt.Sprintf("Swap commitment hash %s is not a hex-encoded SHA-256 hash", hash)
+	return sdk.NewError(codespace, CodeSwapCommitInvalid, errMsg)
+}
+
+func ErrSwapCommitAlreadyExists(codespace sdk.CodespaceType, hash string) sdk.Error {
+	errMsg := fmt.Sprintf("Swap commitment with hash %s already exists", hash)
+	return sdk.NewError(codespace, CodeSwapCommitInvalid, errMsg)
+}
+
+func ErrSwapExceedsCommitDeposit(codespace sdk.CodespaceType, from sdk.Coin, deposit sdk.Coins) sdk.Error {
+	errMsg := fmt.Sprintf("Swap amount %s exceeds committed deposit %s", from.String(), deposit.String())
+	return sdk.NewError(codespace, CodeSwapCommitInvalid, errMsg)
+}
+
+func ErrBondProposalNotFound(codespace sdk.CodespaceType, proposalId uint64) sdk.Error {
+	errMsg := fmt.Sprintf("Bond proposal %d does not exist", proposalId)
+	return sdk.NewError(codespace, CodeProposalNotFound, errMsg)
+}
+
+func ErrBondProposalNotVoting(codespace sdk.CodespaceType, proposalId uint64, status string) sdk.Error {
+	errMsg := fmt.Sprintf("Bond proposal %d is not open for voting, its status is '%s'", proposalId, status)
+	return sdk.NewError(codespace, CodeProposalInvalid, errMsg)
+}
+
+func ErrInvalidVoteOption(codespace sdk.CodespaceType, option string) sdk.Error {
+	errMsg := fmt.Sprintf("Invalid vote option '%s'", option)
+	return sdk.NewError(codespace, CodeProposalInvalid, errMsg)
+}
+
+func ErrVotingPeriodTooShort(codespace sdk.CodespaceType, min uint64) sdk.Error {
+	errMsg := fmt.Sprintf("Voting period must be at least %d blocks", min)
+	return sdk.NewError(codespace, CodeProposalInvalid, errMsg)
+}
+
+func ErrNoVotingPower(codespace sdk.CodespaceType, address sdk.AccAddress, token string) sdk.Error {
+	errMsg := fmt.Sprintf("Address %s holds no %s voting power", address.String(), token)
+	return sdk.NewError(codespace, CodeNoVotingPower, errMsg)
+}
+
+func ErrProposerVotingPowerTooLow(codespace sdk.CodespaceType, address sdk.AccAddress, min sdk.Dec) sdk.Error {
+	errMsg := fmt.Sprintf("Address %s holds less than %s of the total voting power", address.String(), min.String())
+	return sdk.NewError(codespace, CodeNoVotingPower, errMsg)
+}
+
+func ErrTooManyActiveProposals(codespace sdk.CodespaceType, token string, max int) sdk.Error {
+	errMsg := fmt.Sprintf("Bond %s already has the maximum of %d proposals being voted on", token, max)
+	return sdk.NewError(codespace, CodeProposalInvalid, errMsg)
+}
+
+func ErrReserveDoesNotCoverChangedCurve(codespace sdk.CodespaceType, required, reserve sdk.Coins) sdk.Error {
+	errMsg := fmt.Sprintf("Reserve %s does not cover the %s required by the changed curve", reserve.String(), required.String())
+	return sdk.NewError(codespace, CodeProposalNotApplied, errMsg)
+}
+
+func ErrBondParamChangesCannotBeApplied(codespace sdk.CodespaceType, reason string) sdk.Error {
+	errMsg := fmt.Sprintf("Bond parameter changes cannot be applied: %s", reason)
+	return sdk.NewError(codespace, CodeProposalNotApplied, errMsg)
+}
+
+func ErrTooManyFeeRecipients(codespace sdk.CodespaceType, max int) sdk.Error {
+	errMsg := fmt.Sprintf("Cannot have more than %d fee recipients", max)
+	return sdk.NewError(codespace, CodeInvalidFeeRecipients, errMsg)
+}
+
+func ErrDuplicateFeeRecipient(codespace sdk.CodespaceType, address sdk.AccAddress) sdk.Error {
+	errMsg := fmt.Sprintf("Fee recipient %s is listed more than once", address.String())
+	return sdk.NewError(codespace, CodeInvalidFeeRecipients, errMsg)
+}
+
+func ErrFeeRecipientPercentagesDoNotAddUpTo100(codespace sdk.CodespaceType, total sdk.Dec) sdk.Error {
+	errMsg := fmt.Sprintf("Fee recipient percentages add up to %s instead of 100", total.String())
+	return sdk.NewError(codespace, CodeInvalidFeeRecipients, errMsg)
+}
+
+func ErrNoAccruedFees(codespace sdk.CodespaceType, token string, recipient sdk.AccAddress) sdk.Error {
+	errMsg := fmt.Sprintf("No %s fees accrued to %s", token, recipient.String())
+	return sdk.NewError(codespace, CodeNoAccruedFees, errMsg)
+}
+
+func ErrVestingCliffExceedsDuration(codespace sdk.CodespaceType, cliff, duration uint64) sdk.Error {
+	errMsg := fmt.Sprintf("Vesting cliff of %d blocks exceeds vesting duration of %d blocks", cliff, duration)
+	return sdk.NewError(codespace, CodeInvalidVestingConfig, errMsg)
+}
+
+func ErrVestingSupplyDenomDoesNotMatchTokenDenom(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Vesting applies-until supply denom does not match token denom"
+	return sdk.NewError(codespace, CodeInvalidVestingConfig, errMsg)
+}
+
+func ErrVestingSupplyExceedsMaxSupply(codespace sdk.CodespaceType) sdk.Error {
+	errMsg := "Vesting applies-until supply exceeds max supply"
+	return sdk.NewError(codespace, CodeInvalidVestingConfig, errMsg)
+}
+
+func ErrNoVestedTokens(codespace sdk.CodespaceType, token string, address sdk.AccAddress) sdk.Error {
+	errMsg := fmt.Sprintf("No vested %s tokens to claim for %s", token, address.String())
+	return sdk.NewError(codespace, CodeNoVestedTokens, errMsg)
+}
+
+func ErrUnrecognizedTransferPolicy(codespace sdk.CodespaceType, policy string) sdk.Error {
+	errMsg := fmt.Sprintf("Unrecognized transfer policy '%s'", policy)
+	return sdk.NewError(codespace, CodeInvalidTransferPolicy, errMsg)
+}
+
+func ErrTransferNotAllowed(codespace sdk.CodespaceType, token string, address sdk.AccAddress, policy string) sdk.Error {
+	errMsg := fmt.Sprintf("Address %s is not allowed to hold %s under its %s transfer policy", address.String(), token, policy)
+	return sdk.NewError(codespace, CodeTransferNotAllowed, errMsg)
+}
+
+func ErrFundingPoolEmpty(codespace sdk.CodespaceType, token string) sdk.Error {
+	errMsg := fmt.Sprintf("Funding pool of %s is empty", token)
+	return sdk.NewError(codespace, CodeFundingPoolEmpty, errMsg)
+}
+
+func ErrNotFundingPoolRecipient(codespace sdk.CodespaceType, address sdk.AccAddress, token string) sdk.Error {
+	errMsg := fmt.Sprintf("%s is not the fee address of %s, which receives its funding pool", address.String(), token)
+	return sdk.NewError(codespace, CodeFundingPoolNotAllowed, errMsg)
+}
+
+func ErrSharedReserveCannotBeSplit(codespace sdk.CodespaceType, address sdk.AccAddress, token string) sdk.Error {
+	errMsg := fmt.Sprintf("reserve at %s cannot be split with %s since it has no curve-implied reserve", address.String(), token)
+	return sdk.NewError(codespace, CodeSharedReserveCannotBeSplit, errMsg)
+}
@@ -1,14 +1,28 @@
 package types
 
 const (
-	EventTypeCreateBond   = "create_bond"
-	EventTypeEditBond     = "edit_bond"
-	EventTypeInitSwapper  = "init_swapper"
-	EventTypeBuy          = "buy"
-	EventTypeSell         = "sell"
-	EventTypeSwap         = "swap"
-	EventTypeOrderCancel  = "order_cancel"
-	EventTypeOrderFulfill = "order_fulfill"
+	EventTypeCreateBond          = "create_bond"
+	EventTypeEditBond            = "edit_bond"
+	EventTypeInitSwapper         = "init_swapper"
+	EventTypeBuy                 = "buy"
+	EventTypeSell                = "sell"
+	EventTypeSwap                = "swap"
+	EventTypeOrderCancel         = "order_cancel"
+	EventTypeOrderFulfill        = "order_fulfill"
+	EventTypeStateChange         = "state_change"
+	EventTypeOrderRest           = "order_rest"
+	EventTypeOrderResume         = "order_resume"
+	EventTypeBuyWithBudget       = "buy_with_budget"
+	EventTypeCommitSwap          = "commit_swap"
+	EventTypeRevealSwap          = "reveal_swap"
+	EventTypeForfeitSwap         = "forfeit_swap"
+	EventTypeSubmitBondProposal  = "submit_bond_proposal"
+	EventTypeVoteBondProposal    = "vote_bond_proposal"
+	EventTypeEndBondProposal     = "end_bond_proposal"
+	EventTypeWithdrawBondFees    = "withdraw_bond_fees"
+	EventTypeWithdrawFundingPool = "withdraw_funding_pool"
+	EventTypeClaimVested         = "claim_vested"
+	EventTypeBatchPerformed      = "batch_performed"
 
 	AttributeKeyBond                   = "bond"
 	AttributeKeyName                   = "name"
@@ -16,7 +30,6 @@ const (
 	AttributeKeyFunctionType           = "function_type"
 	AttributeKeyFunctionParameters     = "function_parameters"
 	AttributeKeyReserveTokens          = "reserve_tokens"
-	AttributeKeyReserveAddress         = "reserve_address"
 	AttributeKeyTxFeePercentage        = "tx_fee_percentage"
 	AttributeKeyExitFeePercentage      = "exit_fee_percentage"
 	AttributeKeyFeeAddress             = "fee_address"
@@ -39,6 +52,36 @@ const (
 	AttributeKeyChargedPrices          = "charged_prices"
 	AttributeKeyChargedFees            = "charged_fees"
 	AttributeKeyReturnedToAddress      = "returned_to_address"
+	AttributeKeyHatchWhitelist         = "hatch_whitelist"
+	AttributeKeyState                  = "state"
+	AttributeKeyOldState               = "old_state"
+	AttributeKeyNewState               = "new_state"
+	AttributeKeyChargedFundingPool     = "charged_funding_pool"
+	AttributeKeyGoodTillBlock          = "good_till_block"
+	AttributeKeyMinReturns             = "min_returns"
+	AttributeKeyBudget                 = "budget"
+	AttributeKeyCommitRevealSwaps      = "commit_reveal_swaps"
+	AttributeKeyHash                   = "hash"
+	AttributeKeyDeposit                = "deposit"
+	AttributeKeyRevealDeadline         = "reveal_deadline"
+	AttributeKeyProposalId             = "proposal_id"
+	AttributeKeyVotingPeriod           = "voting_period"
+	AttributeKeyVoteOption             = "vote_option"
+	AttributeKeyVotingPower            = "voting_power"
+	AttributeKeyProposalStatus         = "proposal_status"
+	AttributeKeyFailReason             = "fail_reason"
+	AttributeKeyFeeRecipients          = "fee_recipients"
+	AttributeKeyAmount                 = "amount"
+	AttributeKeyVestingCliffBlocks     = "vesting_cliff_blocks"
+	AttributeKeyVestingDurationBlocks  = "vesting_duration_blocks"
+	AttributeKeyVestingUntilSupply     = "vesting_applies_until_supply"
+	AttributeKeyTokensVesting          = "tokens_vesting"
+	AttributeKeyTransferPolicy         = "transfer_policy"
+	AttributeKeyTransferAllowList      = "transfer_allow_list"
+	AttributeKeyTotalBuyAmount         = "total_buy_amount"
+	AttributeKeyTotalSellAmount        = "total_sell_amount"
+	AttributeKeyBuyPrices              = "buy_prices"
+	AttributeKeySellPrices             = "sell_prices"
 
 	AttributeValueBuyOrder  = "buy"
 	AttributeValueSellOrder = "sell"
@@ -0,0 +1,94 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const MaxFeeRecipients = 10
+
+type FeeRecipient struct {
+	Address    sdk.AccAddress `json:"address" yaml:"address"`
+	Percentage sdk.Dec        `json:"percentage" yaml:"percentage"`
+}
+
+func NewFeeRecipient(address sdk.AccAddress, percentage sdk.Dec) FeeRecipient {
+	return FeeRecipient{
+		Address:    address,
+		Percentage: percentage,
+	}
+}
+
+type FeeRecipients []FeeRecipient
+
+func (frs FeeRecipients) String() (result string) {
+	result = "{"
+	for _, fr := range frs {
+		result += fr.Address.String() + ":" + fr.Percentage.String() + ","
+	}
+	if len(frs) > 0 {
+		// Remove last comma
+		result = result[:len(result)-1]
+	}
+	return result + "}"
+}
+
+func (frs FeeRecipients) Validate() sdk.Error {
+	// Fee recipients are optional
+	if len(frs) == 0 {
+		return nil
+	} else if len(frs) > MaxFeeRecipients {
+		return ErrTooManyFeeRecipients(DefaultCodespace, MaxFeeRecipients)
+	}
+
+	total := sdk.ZeroDec()
+	seen := make(map[string]bool)
+	for _, fr := range frs {
+		if fr.Address.Empty() {
+			return ErrArgumentCannotBeEmpty(DefaultCodespace, "Fee recipient address")
+		} else if fr.Percentage.IsNil() || !fr.Percentage.IsPositive() {
+			return ErrArgumentMustBePositive(DefaultCodespace, "Fee recipient percentage")
+		} else if seen[fr.Address.String()] {
+			return ErrDuplicateFeeRecipient(DefaultCodespace, fr.Address)
+		}
+		seen[fr.Address.String()] = true
+		total = total.Add(fr.Percentage)
+	}
+
+	if !total.Equal(sdk.NewDec(100)) {
+		return ErrFeeRecipientPercentagesDoNotAddUpTo100(DefaultCodespace, total)
+	}
+	return nil
+}
+
+// Split returns each recipient's share of the fees, in the same order as the
+// recipients. Shares are rounded down, and the rounding remainder of each
+// coin goes to the first recipient, so that the shares add up to the fees.
+func (frs FeeRecipients) Split(fees sdk.Coins) []sdk.Coins {
+	shares := make([]sdk.Coins, len(frs))
+	for _, fee := range fees {
+		remainder := fee.Amount
+		for i, fr := range frs {
+			amount := fr.Percentage.QuoInt64(100).MulInt(fee.Amount).TruncateInt()
+			remainder = remainder.Sub(amount)
+			shares[i] = shares[i].Add(sdk.NewCoins(sdk.NewCoin(fee.Denom, amount)))
+		}
+		shares[0] = shares[0].Add(sdk.NewCoins(sdk.NewCoin(fee.Denom, remainder)))
+	}
+	return shares
+}
+
+// BondFeeAccrual is the share of a bond's fees that a fee recipient has not
+// withdrawn yet
+type BondFeeAccrual struct {
+	Token     string         `json:"token" yaml:"token"`
+	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	Fees      sdk.Coins      `json:"fees" yaml:"fees"`
+}
+
+func NewBondFeeAccrual(token string, recipient sdk.AccAddress, fees sdk.Coins) BondFeeAccrual {
+	return BondFeeAccrual{
+		Token:     token,
+		Recipient: recipient,
+		Fees:      fees,
+	}
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeRecipientsValidate(t *testing.T) {
+	require.Nil(t, FeeRecipients(nil).Validate())
+	require.Nil(t, FeeRecipients{
+		NewFeeRecipient(ValidFeeAddress, sdk.NewDec(60)),
+		NewFeeRecipient(ValidOtherAddress, sdk.NewDec(40)),
+	}.Validate())
+
+	invalid := []FeeRecipients{
+		// Percentages do not add up to 100
+		{NewFeeRecipient(ValidFeeAddress, sdk.NewDec(60))},
+		{NewFeeRecipient(ValidFeeAddress, sdk.NewDec(60)),
+			NewFeeRecipient(ValidOtherAddress, sdk.NewDec(60))},
+		// Non-positive percentage
+		{NewFeeRecipient(ValidFeeAddress, sdk.NewDec(100)),
+			NewFeeRecipient(ValidOtherAddress, sdk.ZeroDec())},
+		// Empty address
+		{NewFeeRecipient(nil, sdk.NewDec(100))},
+		// Duplicate address
+		{NewFeeRecipient(ValidFeeAddress, sdk.NewDec(50)),
+			NewFeeRecipient(ValidFeeAddress, sdk.NewDec(50))},
+	}
+	for _, frs := range invalid {
+		require.NotNil(t, frs.Validate())
+	}
+
+	var tooMany FeeRecipients
+	for i := 0; i <= MaxFeeRecipients; i++ {
+		address := sdk.AccAddress([]byte{byte(i)})
+		tooMany = append(tooMany, NewFeeRecipient(address, sdk.OneDec()))
+	}
+	require.Equal(t, CodeInvalidFeeRecipients, tooMany.Validate().Code())
+}
+
+func TestFeeRecipientsSplit(t *testing.T) {
+	frs := FeeRecipients{
+		NewFeeRecipient(ValidFeeAddress, sdk.NewDec(50)),
+		NewFeeRecipient(ValidOtherAddress, sdk.NewDec(30)),
+		NewFeeRecipient(ValidBuyerAddress, sdk.NewDec(20)),
+	}
+
+	// Shares are rounded down and the remainder of each coin goes to the
+	// first recipient, so that the shares add up to the fees
+	fees := sdk.NewCoins(sdk.NewInt64Coin("res", 101), sdk.NewInt64Coin("rez", 9))
+	shares := frs.Split(fees)
+	require.Equal(t, []sdk.Coins{
+		sdk.NewCoins(sdk.NewInt64Coin("res", 51), sdk.NewInt64Coin("rez", 6)),
+		sdk.NewCoins(sdk.NewInt64Coin("res", 30), sdk.NewInt64Coin("rez", 2)),
+		sdk.NewCoins(sdk.NewInt64Coin("res", 20), sdk.NewInt64Coin("rez", 1)),
+	}, shares)
+
+	total := sdk.Coins{}
+	for _, share := range shares {
+		total = total.Add(share)
+	}
+	require.Equal(t, fees, total)
+}
@@ -1,25 +1,351 @@
 package types
 
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 type GenesisState struct {
-	Bonds   []Bond  `json:"bonds" yaml:"bonds"`
-	Batches []Batch `json:"batches" yaml:"batches"`
+	Bonds        []Bond                    `json:"bonds" yaml:"bonds"`
+	Batches      []Batch                   `json:"batches" yaml:"batches"`
+	LastBatches  []Batch                   `json:"last_batches" yaml:"last_batches"`
+	Reserves     []BondReserve             `json:"reserves" yaml:"reserves"`
+	AccruedFees  []BondFeeAccrual          `json:"accrued_fees" yaml:"accrued_fees"`
+	Vesting      []AccountVestingSchedules `json:"vesting" yaml:"vesting"`
+	OrderRecords []OrderRecord             `json:"order_records" yaml:"order_records"`
+	Proposals    []BondProposal            `json:"proposals" yaml:"proposals"`
+	Votes        []ProposalVote            `json:"votes" yaml:"votes"`
+	PriceHistory []BondPriceHistory        `json:"price_history" yaml:"price_history"`
+	FundingPools []BondFundingPool         `json:"funding_pools" yaml:"funding_pools"`
+}
+
+// BondReserve is the sub-account of a bond in the bonds reserve account
+type BondReserve struct {
+	Token    string    `json:"token" yaml:"token"`
+	Balances sdk.Coins `json:"balances" yaml:"balances"`
+}
+
+func NewBondReserve(token string, balances sdk.Coins) BondReserve {
+	return BondReserve{
+		Token:    token,
+		Balances: balances,
+	}
+}
+
+// BondFundingPool is the sub-account of a bond in the bonds funding pool account
+type BondFundingPool struct {
+	Token    string    `json:"token" yaml:"token"`
+	Balances sdk.Coins `json:"balances" yaml:"balances"`
+}
+
+func NewBondFundingPool(token string, balances sdk.Coins) BondFundingPool {
+	return BondFundingPool{
+		Token:    token,
+		Balances: balances,
+	}
+}
+
+// ProposalVote is a vote on a bond proposal together with the voting power
+// recorded for the voter when voting
+type ProposalVote struct {
+	ProposalId  uint64           `json:"proposal_id" yaml:"proposal_id"`
+	Vote        BondProposalVote `json:"vote" yaml:"vote"`
+	VotingPower sdk.Int          `json:"voting_power" yaml:"voting_power"`
+}
+
+func NewProposalVote(proposalId uint64, vote BondProposalVote, votingPower sdk.Int) ProposalVote {
+	return ProposalVote{
+		ProposalId:  proposalId,
+		Vote:        vote,
+		VotingPower: votingPower,
+	}
 }
 
-func NewGenesisState(bonds []Bond, batches []Batch) GenesisState {
+// BondPriceHistory is the price history of a bond, with the entries sorted by
+// height and the counter being the number of entries ever recorded (of which
+// only the most recent PriceHistoryLength are kept)
+type BondPriceHistory struct {
+	Token   string              `json:"token" yaml:"token"`
+	Counter uint64              `json:"counter" yaml:"counter"`
+	Entries []PriceHistoryEntry `json:"entries" yaml:"entries"`
+}
+
+func NewBondPriceHistory(token string, counter uint64, entries []PriceHistoryEntry) BondPriceHistory {
+	return BondPriceHistory{
+		Token:   token,
+		Counter: counter,
+		Entries: entries,
+	}
+}
+
+func NewGenesisState(bonds []Bond, batches, lastBatches []Batch,
+	reserves []BondReserve, accruedFees []BondFeeAccrual,
+	vesting []AccountVestingSchedules, orderRecords []OrderRecord,
+	proposals []BondProposal, votes []ProposalVote,
+	priceHistory []BondPriceHistory, fundingPools []BondFundingPool) GenesisState {
 	return GenesisState{
-		Bonds:   bonds,
-		Batches: batches,
+		Bonds:        bonds,
+		Batches:      batches,
+		LastBatches:  lastBatches,
+		Reserves:     reserves,
+		AccruedFees:  accruedFees,
+		Vesting:      vesting,
+		OrderRecords: orderRecords,
+		Proposals:    proposals,
+		Votes:        votes,
+		PriceHistory: priceHistory,
+		FundingPools: fundingPools,
 	}
 }
 
-//noinspection GoUnusedParameter
+// ValidateGenesis performs the same checks on each bond as are performed on
+// bond creation, and checks that batches, reserves, accrued fees, vesting
+// schedules, order records, proposals, price histories and funding pools all belong to bonds
+// in the genesis state, and that votes belong to proposals that are still
+// being voted on. The bonds' supplies are checked against the supply module in
+// InitGenesis.
 func ValidateGenesis(data GenesisState) error {
+	bonds := make(map[string]Bond)
+	for _, b := range data.Bonds {
+		if _, ok := bonds[b.Token]; ok {
+			return fmt.Errorf("duplicate bond %s", b.Token)
+		}
+		bonds[b.Token] = b
+
+		if err := validateGenesisBond(b); err != nil {
+			return fmt.Errorf("invalid bond %s: %s", b.Token, err.Error())
+		}
+	}
+
+	// Legacy reserves shared between bonds are split by the reserve implied by
+	// each bond's curve, so these cannot be shared with swappers
+	reserveBonds := make(map[string][]Bond)
+	for _, b := range data.Bonds {
+		if b.ReserveAddress.Empty() {
+			continue
+		}
+		for _, rt := range b.ReserveTokens {
+			key := b.ReserveAddress.String() + "/" + rt
+			reserveBonds[key] = append(reserveBonds[key], b)
+		}
+	}
+	for _, b := range data.Bonds {
+		if b.ReserveAddress.Empty() || b.HasCurveImpliedReserve() {
+			continue
+		}
+		for _, rt := range b.ReserveTokens {
+			if len(reserveBonds[b.ReserveAddress.String()+"/"+rt]) > 1 {
+				return fmt.Errorf("swapper %s cannot share its %s reserve at %s with other bonds",
+					b.Token, rt, b.ReserveAddress.String())
+			}
+		}
+	}
+
+	// Check that every bond has exactly one batch
+	if err := validateGenesisBatches(bonds, data.Batches, "batch"); err != nil {
+		return err
+	}
+	if len(data.Batches) != len(bonds) {
+		return fmt.Errorf("expected %d batches but got %d", len(bonds), len(data.Batches))
+	}
+
+	// Last batches only exist for bonds that have had a batch end
+	if err := validateGenesisBatches(bonds, data.LastBatches, "last batch"); err != nil {
+		return err
+	}
+
+	for _, r := range data.Reserves {
+		if _, ok := bonds[r.Token]; !ok {
+			return fmt.Errorf("reserve for non-existent bond %s", r.Token)
+		} else if !r.Balances.IsValid() {
+			return fmt.Errorf("invalid reserve for bond %s: %s", r.Token, r.Balances.String())
+		}
+	}
+
+	fundingPools := make(map[string]bool)
+	for _, p := range data.FundingPools {
+		if b, ok := bonds[p.Token]; !ok {
+			return fmt.Errorf("funding pool for non-existent bond %s", p.Token)
+		} else if b.FunctionType != AugmentedFunction {
+			return fmt.Errorf("funding pool for bond %s which is not an %s bond", p.Token, AugmentedFunction)
+		} else if fundingPools[p.Token] {
+			return fmt.Errorf("duplicate funding pool for bond %s", p.Token)
+		} else if !p.Balances.IsValid() {
+			return fmt.Errorf("invalid funding pool for bond %s: %s", p.Token, p.Balances.String())
+		}
+		fundingPools[p.Token] = true
+	}
+
+	for _, f := range data.AccruedFees {
+		if _, ok := bonds[f.Token]; !ok {
+			return fmt.Errorf("accrued fees for non-existent bond %s", f.Token)
+		} else if f.Recipient.Empty() {
+			return fmt.Errorf("accrued fees for bond %s have no recipient", f.Token)
+		} else if !f.Fees.IsValid() {
+			return fmt.Errorf("invalid accrued fees for bond %s: %s", f.Token, f.Fees.String())
+		}
+	}
+
+	for _, v := range data.Vesting {
+		if _, ok := bonds[v.Token]; !ok {
+			return fmt.Errorf("vesting schedules for non-existent bond %s", v.Token)
+		} else if v.Address.Empty() {
+			return fmt.Errorf("vesting schedules for bond %s have no address", v.Token)
+		}
+		for _, s := range v.Schedules {
+			if s.Amount.Denom != v.Token || s.Claimed.Denom != v.Token {
+				return fmt.Errorf("vesting schedule for bond %s is not in %s", v.Token, v.Token)
+			} else if !s.Amount.IsPositive() || s.Claimed.IsNegative() ||
+				s.Amount.IsLT(s.Claimed) {
+				return fmt.Errorf("invalid vesting schedule amounts for bond %s", v.Token)
+			} else if s.CliffHeight < s.StartHeight || s.EndHeight < s.CliffHeight {
+				return fmt.Errorf("invalid vesting schedule heights for bond %s", v.Token)
+			}
+		}
+	}
+
+	orderIds := make(map[uint64]bool)
+	for _, r := range data.OrderRecords {
+		if _, ok := bonds[r.Token]; !ok {
+			return fmt.Errorf("order record for non-existent bond %s", r.Token)
+		} else if r.Id == 0 {
+			return fmt.Errorf("order record for bond %s has no ID", r.Token)
+		} else if orderIds[r.Id] {
+			return fmt.Errorf("duplicate order record %d", r.Id)
+		} else if r.Address.Empty() {
+			return fmt.Errorf("order record %d has no address", r.Id)
+		}
+		orderIds[r.Id] = true
+	}
+
+	proposals := make(map[uint64]BondProposal)
+	for _, p := range data.Proposals {
+		if _, ok := bonds[p.Token]; !ok {
+			return fmt.Errorf("proposal for non-existent bond %s", p.Token)
+		} else if p.ProposalId == 0 {
+			return fmt.Errorf("proposal for bond %s has no ID", p.Token)
+		} else if _, ok := proposals[p.ProposalId]; ok {
+			return fmt.Errorf("duplicate proposal %d", p.ProposalId)
+		} else if p.Proposer.Empty() {
+			return fmt.Errorf("proposal %d has no proposer", p.ProposalId)
+		} else if err := p.Changes.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid proposal %d: %s", p.ProposalId, err.Error())
+		} else if p.TotalVotingPower.IsNegative() {
+			return fmt.Errorf("proposal %d has negative voting power", p.ProposalId)
+		}
+		switch p.Status {
+		case VotingProposalStatus:
+			if p.BlocksRemaining.IsZero() {
+				return fmt.Errorf("proposal %d is voting with no blocks remaining", p.ProposalId)
+			}
+		case PassedProposalStatus, RejectedProposalStatus, FailedProposalStatus:
+		default:
+			return fmt.Errorf("unrecognized status %s of proposal %d", p.Status, p.ProposalId)
+		}
+		proposals[p.ProposalId] = p
+	}
+
+	// Votes (and voting powers) are deleted once a proposal ends
+	votes := make(map[string]bool)
+	for _, v := range data.Votes {
+		key := fmt.Sprintf("%d/%s", v.ProposalId, v.Vote.Voter)
+		if p, ok := proposals[v.ProposalId]; !ok || !p.IsVoting() {
+			return fmt.Errorf("vote for proposal %d which is not being voted on", v.ProposalId)
+		} else if v.Vote.Voter.Empty() {
+			return fmt.Errorf("vote for proposal %d has no voter", v.ProposalId)
+		} else if votes[key] {
+			return fmt.Errorf("duplicate vote by %s for proposal %d", v.Vote.Voter, v.ProposalId)
+		} else if !IsValidVoteOption(v.Vote.Option) {
+			return fmt.Errorf("invalid vote option %s for proposal %d", v.Vote.Option, v.ProposalId)
+		} else if !v.VotingPower.IsPositive() {
+			return fmt.Errorf("vote for proposal %d has no voting power", v.ProposalId)
+		}
+		votes[key] = true
+	}
+
+	priceHistories := make(map[string]bool)
+	for _, h := range data.PriceHistory {
+		if _, ok := bonds[h.Token]; !ok {
+			return fmt.Errorf("price history for non-existent bond %s", h.Token)
+		} else if priceHistories[h.Token] {
+			return fmt.Errorf("duplicate price history for bond %s", h.Token)
+		} else if uint64(len(h.Entries)) > h.Counter ||
+			uint64(len(h.Entries)) > PriceHistoryLength {
+			return fmt.Errorf("too many price history entries for bond %s", h.Token)
+		}
+		for i := 1; i < len(h.Entries); i++ {
+			if h.Entries[i].Height < h.Entries[i-1].Height {
+				return fmt.Errorf("price history entries for bond %s are not sorted", h.Token)
+			}
+		}
+		priceHistories[h.Token] = true
+	}
+
+	return nil
+}
+
+func validateGenesisBond(b Bond) error {
+	// Bonds created before transfer policies were introduced are open
+	transferPolicy := b.TransferPolicy
+	if transferPolicy == "" {
+		transferPolicy = OpenTransferPolicy
+	}
+
+	msg := NewMsgCreateBond(b.Token, b.Name, b.Description, b.Creator,
+		b.FunctionType, b.FunctionParameters, b.ReserveTokens,
+		b.TxFeePercentage, b.ExitFeePercentage, b.FeeAddress, b.FeeRecipients,
+		b.MaxSupply, b.OrderQuantityLimits, b.SanityRate,
+		b.SanityMarginPercentage, b.AllowSells, b.Signers, b.BatchBlocks,
+		b.HatchWhitelist, b.CommitRevealSwaps, b.Vesting, transferPolicy,
+		b.TransferAllowList)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if b.CurrentSupply.Denom != b.Token {
+		return fmt.Errorf("current supply is not in %s", b.Token)
+	} else if b.MaxSupply.Denom != b.Token {
+		return fmt.Errorf("max supply is not in %s", b.Token)
+	} else if b.CurrentSupply.IsNegative() {
+		return fmt.Errorf("current supply is negative")
+	} else if b.MaxSupply.IsLT(b.CurrentSupply) {
+		return fmt.Errorf("current supply exceeds max supply")
+	}
+
+	switch b.State {
+	case HatchState, OpenState, ClosedState, PausedState, SettledState:
+	default:
+		return fmt.Errorf("unrecognized state %s", b.State)
+	}
+
+	return nil
+}
+
+func validateGenesisBatches(bonds map[string]Bond, batches []Batch, name string) error {
+	seen := make(map[string]bool)
+	for _, b := range batches {
+		if _, ok := bonds[b.Token]; !ok {
+			return fmt.Errorf("%s for non-existent bond %s", name, b.Token)
+		} else if seen[b.Token] {
+			return fmt.Errorf("duplicate %s for bond %s", name, b.Token)
+		}
+		seen[b.Token] = true
+	}
 	return nil
 }
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Bonds:   nil,
-		Batches: nil,
+		Bonds:        nil,
+		Batches:      nil,
+		LastBatches:  nil,
+		Reserves:     nil,
+		AccruedFees:  nil,
+		Vesting:      nil,
+		OrderRecords: nil,
+		Proposals:    nil,
+		Votes:        nil,
+		PriceHistory: nil,
+		FundingPools: nil,
 	}
 }
@@ -0,0 +1,35 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"time"
+)
+
+// PriceHistoryLength is the number of most recent batches for which a price
+// history entry is kept for each bond. Older entries are overwritten.
+const PriceHistoryLength uint64 = 1000
+
+type PriceHistoryEntry struct {
+	Height          int64        `json:"height" yaml:"height"`
+	Time            time.Time    `json:"time" yaml:"time"`
+	BuyPrices       sdk.DecCoins `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices      sdk.DecCoins `json:"sell_prices" yaml:"sell_prices"`
+	BuyVolume       sdk.Coin     `json:"buy_volume" yaml:"buy_volume"`
+	SellVolume      sdk.Coin     `json:"sell_volume" yaml:"sell_volume"`
+	CurrentSupply   sdk.Coin     `json:"current_supply" yaml:"current_supply"`
+	ReserveBalances sdk.Coins    `json:"reserve_balances" yaml:"reserve_balances"`
+}
+
+func NewPriceHistoryEntry(height int64, time time.Time, batch Batch,
+	currentSupply sdk.Coin, reserveBalances sdk.Coins) PriceHistoryEntry {
+	return PriceHistoryEntry{
+		Height:          height,
+		Time:            time,
+		BuyPrices:       batch.BuyPrices,
+		SellPrices:      batch.SellPrices,
+		BuyVolume:       batch.TotalBuyAmount,
+		SellVolume:      batch.TotalSellAmount,
+		CurrentSupply:   currentSupply,
+		ReserveBalances: reserveBalances,
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
 const (
 	// ModuleName is the name of this module
 	ModuleName = "bonds"
@@ -13,6 +15,18 @@ const (
 	// BatchesIntermediaryAccount the root string for the batches account address
 	BatchesIntermediaryAccount = "batches_intermediary_account"
 
+	// BondsReserveAccount the root string for the bonds reserve account address
+	BondsReserveAccount = "bonds_reserve_account"
+
+	// BondsFeesAccount the root string for the bonds fees account address
+	BondsFeesAccount = "bonds_fees_account"
+
+	// BondsVestingAccount the root string for the bonds vesting account address
+	BondsVestingAccount = "bonds_vesting_account"
+
+	// BondsFundingPoolAccount the root string for the bonds funding pool account address
+	BondsFundingPoolAccount = "bonds_funding_pool_account"
+
 	// QuerierRoute is the querier route for this module's store.
 	QuerierRoute = ModuleName
 
@@ -20,15 +34,46 @@ const (
 	RouterKey = ModuleName
 )
 
-// Bonds and batches are stored as follow:
+// Bonds, batches, reserves, price histories, proposals, fees, vesting schedules, order records and funding pools are stored as follow:
 //
 // - Bonds: 0x00<bond_token_bytes>
 // - Batches: 0x01<bond_token_bytes>
-// - Last batches: 0x01<bond_token_bytes>
+// - Last batches: 0x02<bond_token_bytes>
+// - Reserve balances: 0x03<bond_token_bytes>
+// - Price history entries: 0x04<bond_token_bytes>/<slot_bytes>
+// - Price history counters: 0x05<bond_token_bytes>
+// - Proposals: 0x06<proposal_id_bytes>
+// - Proposal votes: 0x07<proposal_id_bytes><voter_address_bytes>
+// - Proposal voting powers: 0x08<proposal_id_bytes><voter_address_bytes>
+// - Next proposal ID: 0x09
+// - Active proposals: 0x0A<proposal_id_bytes>
+// - Accrued fees: 0x0B<bond_token_bytes>/<recipient_address_bytes>
+// - Vesting schedules: 0x0C<bond_token_bytes>/<holder_address_bytes>
+// - Order records: 0x0D<address_bytes><bond_token_bytes>/<height_bytes><order_id_bytes>
+// - Next order ID: 0x0E
+// - Funding pool balances: 0x0F<bond_token_bytes>
 var (
-	BondsKeyPrefix       = []byte{0x00} // key for bonds
-	BatchesKeyPrefix     = []byte{0x01} // key for batches
-	LastBatchesKeyPrefix = []byte{0x02} // key for last batches
+	BondsKeyPrefix           = []byte{0x00} // key for bonds
+	BatchesKeyPrefix         = []byte{0x01} // key for batches
+	LastBatchesKeyPrefix     = []byte{0x02} // key for last batches
+	ReserveBalancesKeyPrefix = []byte{0x03} // key for reserve balances
+
+	PriceHistoryKeyPrefix        = []byte{0x04} // key for price history entries
+	PriceHistoryCounterKeyPrefix = []byte{0x05} // key for price history counters
+
+	ProposalsKeyPrefix       = []byte{0x06} // key for proposals
+	ProposalVotesKeyPrefix   = []byte{0x07} // key for proposal votes
+	VotingPowersKeyPrefix    = []byte{0x08} // key for proposal voting powers
+	NextProposalIdKey        = []byte{0x09} // key for the next proposal ID
+	ActiveProposalsKeyPrefix = []byte{0x0A} // key for active proposals
+
+	AccruedFeesKeyPrefix      = []byte{0x0B} // key for accrued fees
+	VestingSchedulesKeyPrefix = []byte{0x0C} // key for vesting schedules
+
+	OrderRecordsKeyPrefix = []byte{0x0D} // key for order records
+	NextOrderIdKey        = []byte{0x0E} // key for the next order ID
+
+	FundingPoolBalancesKeyPrefix = []byte{0x0F} // key for funding pool balances
 )
 
 func GetBondKey(token string) []byte {
@@ -42,3 +87,77 @@ func GetBatchKey(token string) []byte {
 func GetLastBatchKey(token string) []byte {
 	return append(LastBatchesKeyPrefix, []byte(token)...)
 }
+
+func GetReserveBalancesKey(token string) []byte {
+	return append(ReserveBalancesKeyPrefix, []byte(token)...)
+}
+
+func GetFundingPoolBalancesKey(token string) []byte {
+	return append(FundingPoolBalancesKeyPrefix, []byte(token)...)
+}
+
+func GetPriceHistoryPrefix(token string) []byte {
+	// The separator stops one token's prefix from matching a longer token
+	return append(append(PriceHistoryKeyPrefix, []byte(token)...), '/')
+}
+
+func GetPriceHistoryKey(token string, slot uint64) []byte {
+	return append(GetPriceHistoryPrefix(token), sdk.Uint64ToBigEndian(slot)...)
+}
+
+func GetPriceHistoryCounterKey(token string) []byte {
+	return append(PriceHistoryCounterKeyPrefix, []byte(token)...)
+}
+
+func GetProposalKey(proposalId uint64) []byte {
+	return append(ProposalsKeyPrefix, sdk.Uint64ToBigEndian(proposalId)...)
+}
+
+func GetProposalVotesPrefix(proposalId uint64) []byte {
+	return append(ProposalVotesKeyPrefix, sdk.Uint64ToBigEndian(proposalId)...)
+}
+
+func GetProposalVoteKey(proposalId uint64, voter sdk.AccAddress) []byte {
+	return append(GetProposalVotesPrefix(proposalId), voter.Bytes()...)
+}
+
+func GetVotingPowersPrefix(proposalId uint64) []byte {
+	return append(VotingPowersKeyPrefix, sdk.Uint64ToBigEndian(proposalId)...)
+}
+
+func GetVotingPowerKey(proposalId uint64, voter sdk.AccAddress) []byte {
+	return append(GetVotingPowersPrefix(proposalId), voter.Bytes()...)
+}
+
+func GetActiveProposalKey(proposalId uint64) []byte {
+	return append(ActiveProposalsKeyPrefix, sdk.Uint64ToBigEndian(proposalId)...)
+}
+
+func GetAccruedFeesPrefix(token string) []byte {
+	return append(append(AccruedFeesKeyPrefix, []byte(token)...), '/')
+}
+
+func GetAccruedFeesKey(token string, recipient sdk.AccAddress) []byte {
+	return append(GetAccruedFeesPrefix(token), recipient.Bytes()...)
+}
+
+func GetVestingSchedulesPrefix(token string) []byte {
+	return append(append(VestingSchedulesKeyPrefix, []byte(token)...), '/')
+}
+
+func GetVestingSchedulesKey(token string, holder sdk.AccAddress) []byte {
+	return append(GetVestingSchedulesPrefix(token), holder.Bytes()...)
+}
+
+func GetOrderRecordsPrefix(address sdk.AccAddress) []byte {
+	return append(OrderRecordsKeyPrefix, address.Bytes()...)
+}
+
+func GetOrderRecordsByBondPrefix(address sdk.AccAddress, token string) []byte {
+	return append(append(GetOrderRecordsPrefix(address), []byte(token)...), '/')
+}
+
+func GetOrderRecordKey(address sdk.AccAddress, token string, height int64, orderId uint64) []byte {
+	return append(append(GetOrderRecordsByBondPrefix(address, token),
+		sdk.Uint64ToBigEndian(uint64(height))...), sdk.Uint64ToBigEndian(orderId)...)
+}
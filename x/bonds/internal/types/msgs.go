@@ -1,6 +1,8 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"strings"
 )
@@ -13,10 +15,10 @@ type MsgCreateBond struct {
 	FunctionParameters     FunctionParams   `json:"function_parameters" yaml:"function_parameters"`
 	Creator                sdk.AccAddress   `json:"creator" yaml:"creator"`
 	ReserveTokens          []string         `json:"reserve_tokens" yaml:"reserve_tokens"`
-	ReserveAddress         sdk.AccAddress   `json:"reserve_address" yaml:"reserve_address"`
 	TxFeePercentage        sdk.Dec          `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
 	ExitFeePercentage      sdk.Dec          `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
 	FeeAddress             sdk.AccAddress   `json:"fee_address" yaml:"fee_address"`
+	FeeRecipients          FeeRecipients    `json:"fee_recipients" yaml:"fee_recipients"`
 	MaxSupply              sdk.Coin         `json:"max_supply" yaml:"max_supply"`
 	OrderQuantityLimits    sdk.Coins        `json:"order_quantity_limits" yaml:"order_quantity_limits"`
 	SanityRate             sdk.Dec          `json:"sanity_rate" yaml:"sanity_rate"`
@@ -24,14 +26,22 @@ type MsgCreateBond struct {
 	AllowSells             string           `json:"allow_sells" yaml:"allow_sells"`
 	Signers                []sdk.AccAddress `json:"signers" yaml:"signers"`
 	BatchBlocks            sdk.Uint         `json:"batch_blocks" yaml:"batch_blocks"`
+	HatchWhitelist         []sdk.AccAddress `json:"hatch_whitelist" yaml:"hatch_whitelist"`
+	CommitRevealSwaps      string           `json:"commit_reveal_swaps" yaml:"commit_reveal_swaps"`
+	Vesting                VestingConfig    `json:"vesting" yaml:"vesting"`
+	TransferPolicy         string           `json:"transfer_policy" yaml:"transfer_policy"`
+	TransferAllowList      []sdk.AccAddress `json:"transfer_allow_list" yaml:"transfer_allow_list"`
 }
 
 func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 	functionType string, functionParameters FunctionParams,
-	reserveTokens []string, reserveAddress sdk.AccAddress, txFeePercentage,
-	exitFeePercentage sdk.Dec, feeAddress sdk.AccAddress, maxSupply sdk.Coin,
+	reserveTokens []string, txFeePercentage, exitFeePercentage sdk.Dec,
+	feeAddress sdk.AccAddress, feeRecipients FeeRecipients, maxSupply sdk.Coin,
 	orderQuantityLimits sdk.Coins, sanityRate, sanityMarginPercentage sdk.Dec,
-	allowSell string, signers []sdk.AccAddress, batchBlocks sdk.Uint) MsgCreateBond {
+	allowSell string, signers []sdk.AccAddress, batchBlocks sdk.Uint,
+	hatchWhitelist []sdk.AccAddress, commitRevealSwaps string,
+	vesting VestingConfig, transferPolicy string,
+	transferAllowList []sdk.AccAddress) MsgCreateBond {
 	return MsgCreateBond{
 		Token:                  token,
 		Name:                   name,
@@ -40,10 +50,10 @@ func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 		FunctionType:           functionType,
 		FunctionParameters:     functionParameters,
 		ReserveTokens:          reserveTokens,
-		ReserveAddress:         reserveAddress,
 		TxFeePercentage:        txFeePercentage,
 		ExitFeePercentage:      exitFeePercentage,
 		FeeAddress:             feeAddress,
+		FeeRecipients:          feeRecipients,
 		MaxSupply:              maxSupply,
 		OrderQuantityLimits:    orderQuantityLimits,
 		SanityRate:             sanityRate,
@@ -51,6 +61,11 @@ func NewMsgCreateBond(token, name, description string, creator sdk.AccAddress,
 		AllowSells:             strings.ToLower(allowSell),
 		Signers:                signers,
 		BatchBlocks:            batchBlocks,
+		HatchWhitelist:         hatchWhitelist,
+		CommitRevealSwaps:      strings.ToLower(commitRevealSwaps),
+		Vesting:                vesting,
+		TransferPolicy:         strings.ToLower(transferPolicy),
+		TransferAllowList:      transferAllowList,
 	}
 }
 
@@ -66,20 +81,24 @@ func (msg MsgCreateBond) ValidateBasic() sdk.Error {
 		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Creator")
 	} else if len(msg.ReserveTokens) == 0 {
 		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Reserve token")
-	} else if msg.ReserveAddress.Empty() {
-		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Reserve address")
 	} else if msg.FeeAddress.Empty() {
 		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Fee address")
 	} else if strings.TrimSpace(msg.FunctionType) == "" {
 		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Function type")
 	} else if strings.TrimSpace(msg.AllowSells) == "" {
 		return ErrArgumentCannotBeEmpty(DefaultCodespace, "AllowSells")
+	} else if strings.TrimSpace(msg.CommitRevealSwaps) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "CommitRevealSwaps")
+	} else if strings.TrimSpace(msg.TransferPolicy) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "TransferPolicy")
 	}
 	// Note: FunctionParameters can be empty
 
 	// Check that true or false
 	if msg.AllowSells != TRUE && msg.AllowSells != FALSE {
 		return ErrArgumentMissingOrNonBoolean(DefaultCodespace, "AllowSells")
+	} else if msg.CommitRevealSwaps != TRUE && msg.CommitRevealSwaps != FALSE {
+		return ErrArgumentMissingOrNonBoolean(DefaultCodespace, "CommitRevealSwaps")
 	}
 
 	// Check that not negative
@@ -94,23 +113,62 @@ func (msg MsgCreateBond) ValidateBasic() sdk.Error {
 		return ErrArgumentMustBePositive(DefaultCodespace, "BatchBlocks")
 	} else if msg.MaxSupply.Amount.IsZero() {
 		return ErrArgumentMustBePositive(DefaultCodespace, "MaxSupply")
-	} else {
-		// TODO: consider allowing negative function parameters where possible
-		for _, fp := range msg.FunctionParameters {
-			if fp.Value.IsZero() {
-				return ErrArgumentMustBePositive(DefaultCodespace, "FunctionParams:"+fp.Param)
-			}
+	}
+
+	// Check function type and function parameters
+	if err := msg.FunctionParameters.Validate(msg.FunctionType); err != nil {
+		return err
+	} else if err := msg.FunctionParameters.ValidateForMaxSupply(
+		msg.FunctionType, msg.MaxSupply.Amount); err != nil {
+		return err
+	}
+
+	// Check that fee recipients (if any) take up all of the fees
+	if err := msg.FeeRecipients.Validate(); err != nil {
+		return err
+	}
+
+	// Check that the weighted swapper has a weight for each reserve token
+	if msg.FunctionType == WeightedSwapperFunction {
+		if err := msg.FunctionParameters.ValidateWeights(msg.ReserveTokens); err != nil {
+			return err
 		}
 	}
 
-	// Check that reserve address is not the fee address
-	// (This is allowed for the swapper function)
-	if msg.ReserveAddress.Equals(msg.FeeAddress) &&
-		msg.FunctionType != SwapperFunction {
-		return ErrReserveAddrCannotBeFeeAddr(DefaultCodespace)
+	// Check that the augmented function has someone to take part in the hatch
+	// (The hatch whitelist is ignored for other function types)
+	if msg.FunctionType == AugmentedFunction && len(msg.HatchWhitelist) == 0 {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Hatch whitelist")
+	}
+
+	// Check that commit-reveal swaps are only enabled for swapper functions
+	if msg.CommitRevealSwaps == TRUE && msg.FunctionType != SwapperFunction &&
+		msg.FunctionType != WeightedSwapperFunction {
+		return ErrFunctionNotAvailableForFunctionType(DefaultCodespace)
+	}
+
+	// Check that the transfer policy is recognised and that an allow-list
+	// policy has someone to hold the tokens
+	// (The allow-list is ignored for other transfer policies)
+	if msg.TransferPolicy != OpenTransferPolicy &&
+		msg.TransferPolicy != AllowListTransferPolicy &&
+		msg.TransferPolicy != CredentialRequiredTransferPolicy {
+		return ErrUnrecognizedTransferPolicy(DefaultCodespace, msg.TransferPolicy)
+	} else if msg.TransferPolicy == AllowListTransferPolicy && len(msg.TransferAllowList) == 0 {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Transfer allow-list")
 	}
 
-	// Note: uniqueness of reserve tokens checked when parsing
+	// Check that vesting (if any) is within the max supply
+	if err := msg.Vesting.Validate(msg.Token, msg.MaxSupply); err != nil {
+		return err
+	}
+
+	// Check that reserve tokens are unique and valid for the function type
+	if err := CheckReserveTokenNames(msg.ReserveTokens, msg.Token); err != nil {
+		return err
+	} else if err := CheckNoOfReserveTokens(msg.ReserveTokens, msg.FunctionType); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -201,16 +259,19 @@ func (msg MsgEditBond) Route() string { return RouterKey }
 func (msg MsgEditBond) Type() string { return "edit_bond" }
 
 type MsgBuy struct {
-	Buyer     sdk.AccAddress `json:"buyer" yaml:"buyer"`
-	Amount    sdk.Coin       `json:"amount" yaml:"amount"`
-	MaxPrices sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	Buyer         sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	Amount        sdk.Coin       `json:"amount" yaml:"amount"`
+	MaxPrices     sdk.Coins      `json:"max_prices" yaml:"max_prices"`
+	GoodTillBlock int64          `json:"good_till_block" yaml:"good_till_block"`
 }
 
-func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins) MsgBuy {
+func NewMsgBuy(buyer sdk.AccAddress, amount sdk.Coin, maxPrices sdk.Coins,
+	goodTillBlock int64) MsgBuy {
 	return MsgBuy{
-		Buyer:     buyer,
-		Amount:    amount,
-		MaxPrices: maxPrices,
+		Buyer:         buyer,
+		Amount:        amount,
+		MaxPrices:     maxPrices,
+		GoodTillBlock: goodTillBlock,
 	}
 }
 
@@ -225,6 +286,11 @@ func (msg MsgBuy) ValidateBasic() sdk.Error {
 		return ErrArgumentMustBePositive(DefaultCodespace, "Amount")
 	}
 
+	// Check that not negative (zero means that the order is not good-till-block)
+	if msg.GoodTillBlock < 0 {
+		return ErrArgumentCannotBeNegative(DefaultCodespace, "GoodTillBlock")
+	}
+
 	return nil
 }
 
@@ -240,15 +306,64 @@ func (msg MsgBuy) Route() string { return RouterKey }
 
 func (msg MsgBuy) Type() string { return "buy" }
 
+type MsgBuyWithBudget struct {
+	Buyer     sdk.AccAddress `json:"buyer" yaml:"buyer"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Budget    sdk.Coins      `json:"budget" yaml:"budget"`
+}
+
+func NewMsgBuyWithBudget(buyer sdk.AccAddress, bondToken string, budget sdk.Coins) MsgBuyWithBudget {
+	return MsgBuyWithBudget{
+		Buyer:     buyer,
+		BondToken: bondToken,
+		Budget:    budget,
+	}
+}
+
+func (msg MsgBuyWithBudget) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Buyer.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Buyer")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "BondToken")
+	} else if msg.Budget.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Budget")
+	}
+
+	// Check that budget is valid
+	if !msg.Budget.IsValid() {
+		return sdk.ErrInvalidCoins(msg.Budget.String())
+	}
+
+	return nil
+}
+
+func (msg MsgBuyWithBudget) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgBuyWithBudget) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+func (msg MsgBuyWithBudget) Route() string { return RouterKey }
+
+func (msg MsgBuyWithBudget) Type() string { return "buy_with_budget" }
+
 type MsgSell struct {
-	Seller sdk.AccAddress `json:"seller" yaml:"seller"`
-	Amount sdk.Coin       `json:"amount" yaml:"amount"`
+	Seller        sdk.AccAddress `json:"seller" yaml:"seller"`
+	Amount        sdk.Coin       `json:"amount" yaml:"amount"`
+	MinReturns    sdk.Coins      `json:"min_returns" yaml:"min_returns"`
+	GoodTillBlock int64          `json:"good_till_block" yaml:"good_till_block"`
 }
 
-func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin) MsgSell {
+func NewMsgSell(seller sdk.AccAddress, amount sdk.Coin, minReturns sdk.Coins,
+	goodTillBlock int64) MsgSell {
 	return MsgSell{
-		Seller: seller,
-		Amount: amount,
+		Seller:        seller,
+		Amount:        amount,
+		MinReturns:    minReturns,
+		GoodTillBlock: goodTillBlock,
 	}
 }
 
@@ -263,6 +378,16 @@ func (msg MsgSell) ValidateBasic() sdk.Error {
 		return ErrArgumentMustBePositive(DefaultCodespace, "Amount")
 	}
 
+	// Check that min returns (optional) are valid
+	if !msg.MinReturns.IsValid() {
+		return sdk.ErrInvalidCoins(msg.MinReturns.String())
+	}
+
+	// Check that not negative (zero means that the order is not good-till-block)
+	if msg.GoodTillBlock < 0 {
+		return ErrArgumentCannotBeNegative(DefaultCodespace, "GoodTillBlock")
+	}
+
 	return nil
 }
 
@@ -279,18 +404,21 @@ func (msg MsgSell) Route() string { return RouterKey }
 func (msg MsgSell) Type() string { return "sell" }
 
 type MsgSwap struct {
-	Swapper   sdk.AccAddress `json:"swapper" yaml:"swapper"`
-	BondToken string         `json:"bond_token" yaml:"bond_token"`
-	From      sdk.Coin       `json:"from" yaml:"from"`
-	ToToken   string         `json:"to_token" yaml:"to_token"`
+	Swapper    sdk.AccAddress `json:"swapper" yaml:"swapper"`
+	BondToken  string         `json:"bond_token" yaml:"bond_token"`
+	From       sdk.Coin       `json:"from" yaml:"from"`
+	ToToken    string         `json:"to_token" yaml:"to_token"`
+	MinReturns sdk.Coins      `json:"min_returns" yaml:"min_returns"`
 }
 
-func NewMsgSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin, toToken string) MsgSwap {
+func NewMsgSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin,
+	toToken string, minReturns sdk.Coins) MsgSwap {
 	return MsgSwap{
-		Swapper:   swapper,
-		BondToken: bondToken,
-		From:      from,
-		ToToken:   toToken,
+		Swapper:    swapper,
+		BondToken:  bondToken,
+		From:       from,
+		ToToken:    toToken,
+		MinReturns: minReturns,
 	}
 }
 
@@ -314,6 +442,16 @@ func (msg MsgSwap) ValidateBasic() sdk.Error {
 		return ErrArgumentMustBePositive(DefaultCodespace, "FromAmount")
 	}
 
+	// Check that min returns (optional) are valid and only in the to token
+	if !msg.MinReturns.IsValid() {
+		return sdk.ErrInvalidCoins(msg.MinReturns.String())
+	}
+	for _, r := range msg.MinReturns {
+		if r.Denom != msg.ToToken {
+			return ErrInvalidCoinDenomination(DefaultCodespace, r.Denom)
+		}
+	}
+
 	// Note: From denom and amount must be valid since sdk.Coin
 	return nil
 }
@@ -329,3 +467,468 @@ func (msg MsgSwap) GetSigners() []sdk.AccAddress {
 func (msg MsgSwap) Route() string { return RouterKey }
 
 func (msg MsgSwap) Type() string { return "swap" }
+
+type MsgCommitSwap struct {
+	Swapper   sdk.AccAddress `json:"swapper" yaml:"swapper"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+	Hash      string         `json:"hash" yaml:"hash"`
+	Deposit   sdk.Coins      `json:"deposit" yaml:"deposit"`
+}
+
+func NewMsgCommitSwap(swapper sdk.AccAddress, bondToken, hash string,
+	deposit sdk.Coins) MsgCommitSwap {
+	return MsgCommitSwap{
+		Swapper:   swapper,
+		BondToken: bondToken,
+		Hash:      strings.ToLower(hash),
+		Deposit:   deposit,
+	}
+}
+
+func (msg MsgCommitSwap) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Swapper.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Swapper")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "BondToken")
+	} else if strings.TrimSpace(msg.Hash) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Hash")
+	} else if msg.Deposit.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Deposit")
+	}
+
+	// Check that hash is a hex-encoded SHA-256 hash
+	if hash, err := hex.DecodeString(msg.Hash); err != nil || len(hash) != sha256.Size {
+		return ErrInvalidSwapCommitHash(DefaultCodespace, msg.Hash)
+	}
+
+	// Check that deposit is valid
+	if !msg.Deposit.IsValid() {
+		return sdk.ErrInvalidCoins(msg.Deposit.String())
+	}
+
+	return nil
+}
+
+func (msg MsgCommitSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCommitSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Swapper}
+}
+
+func (msg MsgCommitSwap) Route() string { return RouterKey }
+
+func (msg MsgCommitSwap) Type() string { return "commit_swap" }
+
+type MsgRevealSwap struct {
+	Swapper    sdk.AccAddress `json:"swapper" yaml:"swapper"`
+	BondToken  string         `json:"bond_token" yaml:"bond_token"`
+	From       sdk.Coin       `json:"from" yaml:"from"`
+	ToToken    string         `json:"to_token" yaml:"to_token"`
+	MinReturns sdk.Coins      `json:"min_returns" yaml:"min_returns"`
+	Salt       string         `json:"salt" yaml:"salt"`
+}
+
+func NewMsgRevealSwap(swapper sdk.AccAddress, bondToken string, from sdk.Coin,
+	toToken string, minReturns sdk.Coins, salt string) MsgRevealSwap {
+	return MsgRevealSwap{
+		Swapper:    swapper,
+		BondToken:  bondToken,
+		From:       from,
+		ToToken:    toToken,
+		MinReturns: minReturns,
+		Salt:       salt,
+	}
+}
+
+func (msg MsgRevealSwap) ValidateBasic() sdk.Error {
+	// Check that the revealed swap is valid as a normal swap
+	if err := msg.ToMsgSwap().ValidateBasic(); err != nil {
+		return err
+	}
+
+	// Check if empty
+	if strings.TrimSpace(msg.Salt) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Salt")
+	}
+
+	return nil
+}
+
+func (msg MsgRevealSwap) ToMsgSwap() MsgSwap {
+	return NewMsgSwap(msg.Swapper, msg.BondToken, msg.From, msg.ToToken, msg.MinReturns)
+}
+
+func (msg MsgRevealSwap) GetHash() string {
+	return GetSwapCommitHash(msg.Swapper, msg.BondToken, msg.From,
+		msg.ToToken, msg.MinReturns, msg.Salt)
+}
+
+func (msg MsgRevealSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRevealSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Swapper}
+}
+
+func (msg MsgRevealSwap) Route() string { return RouterKey }
+
+func (msg MsgRevealSwap) Type() string { return "reveal_swap" }
+
+type MsgUpdateBondState struct {
+	Token   string           `json:"token" yaml:"token"`
+	State   string           `json:"state" yaml:"state"`
+	Editor  sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgUpdateBondState(token, state string, editor sdk.AccAddress,
+	signers []sdk.AccAddress) MsgUpdateBondState {
+	return MsgUpdateBondState{
+		Token:   token,
+		State:   strings.ToLower(state),
+		Editor:  editor,
+		Signers: signers,
+	}
+}
+
+func (msg MsgUpdateBondState) ValidateBasic() sdk.Error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Token")
+	} else if strings.TrimSpace(msg.State) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "State")
+	} else if msg.Editor.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Editor")
+	}
+
+	// Check that state is recognised
+	if msg.State != HatchState && msg.State != OpenState && msg.State != ClosedState {
+		return ErrUnrecognizedBondState(DefaultCodespace, msg.State)
+	}
+
+	return nil
+}
+
+func (msg MsgUpdateBondState) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUpdateBondState) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgUpdateBondState) Route() string { return RouterKey }
+
+func (msg MsgUpdateBondState) Type() string { return "update_bond_state" }
+
+type MsgPauseBond struct {
+	Token   string           `json:"token" yaml:"token"`
+	Editor  sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgPauseBond(token string, editor sdk.AccAddress, signers []sdk.AccAddress) MsgPauseBond {
+	return MsgPauseBond{
+		Token:   token,
+		Editor:  editor,
+		Signers: signers,
+	}
+}
+
+func (msg MsgPauseBond) ValidateBasic() sdk.Error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Token")
+	} else if msg.Editor.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Editor")
+	}
+
+	return nil
+}
+
+func (msg MsgPauseBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgPauseBond) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgPauseBond) Route() string { return RouterKey }
+
+func (msg MsgPauseBond) Type() string { return "pause_bond" }
+
+type MsgResumeBond struct {
+	Token   string           `json:"token" yaml:"token"`
+	Editor  sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgResumeBond(token string, editor sdk.AccAddress, signers []sdk.AccAddress) MsgResumeBond {
+	return MsgResumeBond{
+		Token:   token,
+		Editor:  editor,
+		Signers: signers,
+	}
+}
+
+func (msg MsgResumeBond) ValidateBasic() sdk.Error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Token")
+	} else if msg.Editor.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Editor")
+	}
+
+	return nil
+}
+
+func (msg MsgResumeBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgResumeBond) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgResumeBond) Route() string { return RouterKey }
+
+func (msg MsgResumeBond) Type() string { return "resume_bond" }
+
+type MsgCloseBond struct {
+	Token   string           `json:"token" yaml:"token"`
+	Editor  sdk.AccAddress   `json:"editor" yaml:"editor"`
+	Signers []sdk.AccAddress `json:"signers" yaml:"signers"`
+}
+
+func NewMsgCloseBond(token string, editor sdk.AccAddress, signers []sdk.AccAddress) MsgCloseBond {
+	return MsgCloseBond{
+		Token:   token,
+		Editor:  editor,
+		Signers: signers,
+	}
+}
+
+func (msg MsgCloseBond) ValidateBasic() sdk.Error {
+	// Check if empty
+	if strings.TrimSpace(msg.Token) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Token")
+	} else if msg.Editor.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Editor")
+	}
+
+	return nil
+}
+
+func (msg MsgCloseBond) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCloseBond) GetSigners() []sdk.AccAddress {
+	return msg.Signers
+}
+
+func (msg MsgCloseBond) Route() string { return RouterKey }
+
+func (msg MsgCloseBond) Type() string { return "close_bond" }
+
+type MsgSubmitBondProposal struct {
+	Proposer     sdk.AccAddress   `json:"proposer" yaml:"proposer"`
+	Token        string           `json:"token" yaml:"token"`
+	Description  string           `json:"description" yaml:"description"`
+	Changes      BondParamChanges `json:"changes" yaml:"changes"`
+	VotingPeriod sdk.Uint         `json:"voting_period" yaml:"voting_period"`
+}
+
+func NewMsgSubmitBondProposal(proposer sdk.AccAddress, token, description string,
+	changes BondParamChanges, votingPeriod sdk.Uint) MsgSubmitBondProposal {
+	return MsgSubmitBondProposal{
+		Proposer:     proposer,
+		Token:        token,
+		Description:  description,
+		Changes:      changes,
+		VotingPeriod: votingPeriod,
+	}
+}
+
+func (msg MsgSubmitBondProposal) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Proposer.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Proposer")
+	} else if strings.TrimSpace(msg.Token) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Token")
+	} else if strings.TrimSpace(msg.Description) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Description")
+	}
+
+	// Check that voting period is not too short
+	if msg.VotingPeriod.LT(sdk.NewUint(MinProposalVotingPeriod)) {
+		return ErrVotingPeriodTooShort(DefaultCodespace, MinProposalVotingPeriod)
+	}
+
+	return msg.Changes.ValidateBasic()
+}
+
+func (msg MsgSubmitBondProposal) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgSubmitBondProposal) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Proposer}
+}
+
+func (msg MsgSubmitBondProposal) Route() string { return RouterKey }
+
+func (msg MsgSubmitBondProposal) Type() string { return "submit_bond_proposal" }
+
+type MsgVoteBondProposal struct {
+	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`
+	ProposalId uint64         `json:"proposal_id" yaml:"proposal_id"`
+	Option     string         `json:"option" yaml:"option"`
+}
+
+func NewMsgVoteBondProposal(voter sdk.AccAddress, proposalId uint64,
+	option string) MsgVoteBondProposal {
+	return MsgVoteBondProposal{
+		Voter:      voter,
+		ProposalId: proposalId,
+		Option:     strings.ToLower(option),
+	}
+}
+
+func (msg MsgVoteBondProposal) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Voter.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Voter")
+	} else if strings.TrimSpace(msg.Option) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Option")
+	}
+
+	// Check that vote option is valid
+	if !IsValidVoteOption(msg.Option) {
+		return ErrInvalidVoteOption(DefaultCodespace, msg.Option)
+	}
+
+	return nil
+}
+
+func (msg MsgVoteBondProposal) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgVoteBondProposal) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+func (msg MsgVoteBondProposal) Route() string { return RouterKey }
+
+func (msg MsgVoteBondProposal) Type() string { return "vote_bond_proposal" }
+
+type MsgWithdrawBondFees struct {
+	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewMsgWithdrawBondFees(recipient sdk.AccAddress, bondToken string) MsgWithdrawBondFees {
+	return MsgWithdrawBondFees{
+		Recipient: recipient,
+		BondToken: bondToken,
+	}
+}
+
+func (msg MsgWithdrawBondFees) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Recipient.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Recipient")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "BondToken")
+	}
+
+	return nil
+}
+
+func (msg MsgWithdrawBondFees) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgWithdrawBondFees) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Recipient}
+}
+
+func (msg MsgWithdrawBondFees) Route() string { return RouterKey }
+
+func (msg MsgWithdrawBondFees) Type() string { return "withdraw_bond_fees" }
+
+type MsgWithdrawFundingPool struct {
+	Recipient sdk.AccAddress `json:"recipient" yaml:"recipient"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewMsgWithdrawFundingPool(recipient sdk.AccAddress, bondToken string) MsgWithdrawFundingPool {
+	return MsgWithdrawFundingPool{
+		Recipient: recipient,
+		BondToken: bondToken,
+	}
+}
+
+func (msg MsgWithdrawFundingPool) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Recipient.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Recipient")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "BondToken")
+	}
+
+	return nil
+}
+
+func (msg MsgWithdrawFundingPool) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgWithdrawFundingPool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Recipient}
+}
+
+func (msg MsgWithdrawFundingPool) Route() string { return RouterKey }
+
+func (msg MsgWithdrawFundingPool) Type() string { return "withdraw_funding_pool" }
+
+type MsgClaimVested struct {
+	Claimer   sdk.AccAddress `json:"claimer" yaml:"claimer"`
+	BondToken string         `json:"bond_token" yaml:"bond_token"`
+}
+
+func NewMsgClaimVested(claimer sdk.AccAddress, bondToken string) MsgClaimVested {
+	return MsgClaimVested{
+		Claimer:   claimer,
+		BondToken: bondToken,
+	}
+}
+
+func (msg MsgClaimVested) ValidateBasic() sdk.Error {
+	// Check if empty
+	if msg.Claimer.Empty() {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "Claimer")
+	} else if strings.TrimSpace(msg.BondToken) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "BondToken")
+	}
+
+	return nil
+}
+
+func (msg MsgClaimVested) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgClaimVested) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Claimer}
+}
+
+func (msg MsgClaimVested) Route() string { return RouterKey }
+
+func (msg MsgClaimVested) Type() string { return "claim_vested" }
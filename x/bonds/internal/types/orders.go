@@ -0,0 +1,65 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	PendingOrderStatus = "pending"
+
+	DefaultOrdersQueryLimit = 100
+)
+
+// OrderRecord is the history of an order placed by an address, from the batch
+// that it was added to until it was fulfilled or cancelled. Prices are only
+// set for buys and sells, and charged prices only for buys.
+type OrderRecord struct {
+	Id              uint64         `json:"id" yaml:"id"`
+	Token           string         `json:"token" yaml:"token"`
+	Address         sdk.AccAddress `json:"address" yaml:"address"`
+	Height          int64          `json:"height" yaml:"height"`
+	OrderType       string         `json:"order_type" yaml:"order_type"`
+	Amount          sdk.Coin       `json:"amount" yaml:"amount"`
+	ToToken         string         `json:"to_token" yaml:"to_token"`
+	Status          string         `json:"status" yaml:"status"`
+	CancelReason    string         `json:"cancel_reason" yaml:"cancel_reason"`
+	FulfilledHeight int64          `json:"fulfilled_height" yaml:"fulfilled_height"`
+	Prices          sdk.DecCoins   `json:"prices" yaml:"prices"`
+	ChargedPrices   sdk.Coins      `json:"charged_prices" yaml:"charged_prices"`
+	ChargedFees     sdk.Coins      `json:"charged_fees" yaml:"charged_fees"`
+	Returned        sdk.Coins      `json:"returned" yaml:"returned"`
+}
+
+func NewOrderRecord(token, orderType string, order BaseOrder, toToken string) OrderRecord {
+	return OrderRecord{
+		Id:        order.Id,
+		Token:     token,
+		Address:   order.Address,
+		Height:    order.Height,
+		OrderType: orderType,
+		Amount:    order.Amount,
+		ToToken:   toToken,
+		Status:    PendingOrderStatus,
+	}
+}
+
+// QueryOrdersParams optionally limit the orders of an address to a bond token
+// and select a page of the orders, where pages start from 1
+type QueryOrdersParams struct {
+	Token string `json:"token" yaml:"token"`
+	Page  int    `json:"page" yaml:"page"`
+	Limit int    `json:"limit" yaml:"limit"`
+}
+
+func NewQueryOrdersParams(token string, page, limit int) QueryOrdersParams {
+	return QueryOrdersParams{
+		Token: token,
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+type QueryOrders struct {
+	Total  int           `json:"total" yaml:"total"`
+	Orders []OrderRecord `json:"orders" yaml:"orders"`
+}
@@ -0,0 +1,264 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"strings"
+)
+
+const (
+	VotingProposalStatus   = "voting"
+	PassedProposalStatus   = "passed"
+	RejectedProposalStatus = "rejected"
+	FailedProposalStatus   = "failed"
+
+	YesVoteOption     = "yes"
+	NoVoteOption      = "no"
+	AbstainVoteOption = "abstain"
+
+	// MinProposalVotingPeriod is the minimum number of blocks that token
+	// holders are given to vote on a bond proposal
+	MinProposalVotingPeriod = 10
+
+	// MaxActiveProposalsPerBond is the maximum number of a bond's proposals
+	// that can be voted on at the same time
+	MaxActiveProposalsPerBond = 5
+)
+
+var (
+	// ProposalQuorum is the minimum fraction of the snapshot voting power
+	// that has to vote (including abstain votes) for a proposal to be valid
+	ProposalQuorum = sdk.NewDecWithPrec(334, 3)
+
+	// ProposalThreshold is the fraction of yes votes, out of the yes and no
+	// votes, that has to be exceeded for a proposal to pass
+	ProposalThreshold = sdk.NewDecWithPrec(5, 1)
+
+	// MinProposerVotingPower is the minimum fraction of the total voting
+	// power that has to be held by the proposer to submit a proposal
+	MinProposerVotingPower = sdk.NewDecWithPrec(1, 2)
+)
+
+type BondParamChanges struct {
+	FunctionParameters string `json:"function_parameters" yaml:"function_parameters"`
+	TxFeePercentage    string `json:"tx_fee_percentage" yaml:"tx_fee_percentage"`
+	ExitFeePercentage  string `json:"exit_fee_percentage" yaml:"exit_fee_percentage"`
+	FeeAddress         string `json:"fee_address" yaml:"fee_address"`
+	MaxSupply          string `json:"max_supply" yaml:"max_supply"`
+}
+
+func NewBondParamChanges(functionParameters, txFeePercentage,
+	exitFeePercentage, feeAddress, maxSupply string) BondParamChanges {
+	return BondParamChanges{
+		FunctionParameters: functionParameters,
+		TxFeePercentage:    txFeePercentage,
+		ExitFeePercentage:  exitFeePercentage,
+		FeeAddress:         feeAddress,
+		MaxSupply:          maxSupply,
+	}
+}
+
+func (c BondParamChanges) ValidateBasic() sdk.Error {
+	// Check if empty
+	if strings.TrimSpace(c.FunctionParameters) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "FunctionParameters")
+	} else if strings.TrimSpace(c.TxFeePercentage) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "TxFeePercentage")
+	} else if strings.TrimSpace(c.ExitFeePercentage) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "ExitFeePercentage")
+	} else if strings.TrimSpace(c.FeeAddress) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "FeeAddress")
+	} else if strings.TrimSpace(c.MaxSupply) == "" {
+		return ErrArgumentCannotBeEmpty(DefaultCodespace, "MaxSupply")
+	}
+
+	// Check that at least one parameter was changed. Parameters that will
+	// not be changed should be "DoNotModifyField", and not an empty string
+	inputList := []string{
+		c.FunctionParameters, c.TxFeePercentage, c.ExitFeePercentage,
+		c.FeeAddress, c.MaxSupply,
+	}
+	for _, e := range inputList {
+		if e != DoNotModifyField {
+			return nil
+		}
+	}
+	return ErrDidNotEditAnything(DefaultCodespace)
+}
+
+// ApplyTo returns the bond with the changes applied, or an error if any of
+// the changes is invalid for the bond. Checks that depend on the state of the
+// bond's reserve are left to the keeper.
+func (c BondParamChanges) ApplyTo(bond Bond) (Bond, sdk.Error) {
+	if c.FunctionParameters != DoNotModifyField {
+		functionParams, err := parseFunctionParams(c.FunctionParameters)
+		if err != nil {
+			return Bond{}, err
+		}
+		if err := functionParams.Validate(bond.FunctionType); err != nil {
+			return Bond{}, err
+		}
+		if bond.FunctionType == WeightedSwapperFunction {
+			if err := functionParams.ValidateWeights(bond.ReserveTokens); err != nil {
+				return Bond{}, err
+			}
+		}
+		bond.FunctionParameters = functionParams
+	}
+
+	if c.TxFeePercentage != DoNotModifyField {
+		txFeePercentage, err := sdk.NewDecFromStr(c.TxFeePercentage)
+		if err != nil {
+			return Bond{}, ErrArgumentMissingOrNonFloat(DefaultCodespace, "tx fee percentage")
+		} else if txFeePercentage.IsNegative() {
+			return Bond{}, ErrArgumentCannotBeNegative(DefaultCodespace, "TxFeePercentage")
+		}
+		bond.TxFeePercentage = txFeePercentage
+	}
+
+	if c.ExitFeePercentage != DoNotModifyField {
+		exitFeePercentage, err := sdk.NewDecFromStr(c.ExitFeePercentage)
+		if err != nil {
+			return Bond{}, ErrArgumentMissingOrNonFloat(DefaultCodespace, "exit fee percentage")
+		} else if exitFeePercentage.IsNegative() {
+			return Bond{}, ErrArgumentCannotBeNegative(DefaultCodespace, "ExitFeePercentage")
+		}
+		bond.ExitFeePercentage = exitFeePercentage
+	}
+
+	if bond.TxFeePercentage.Add(bond.ExitFeePercentage).GTE(sdk.NewDec(100)) {
+		return Bond{}, ErrFeesCannotBeOrExceed100Percent(DefaultCodespace)
+	}
+
+	if c.FeeAddress != DoNotModifyField {
+		feeAddress, err := sdk.AccAddressFromBech32(c.FeeAddress)
+		if err != nil {
+			return Bond{}, sdk.ErrInvalidAddress(err.Error())
+		}
+		bond.FeeAddress = feeAddress
+	}
+
+	if c.MaxSupply != DoNotModifyField {
+		maxSupply, err := sdk.ParseCoin(c.MaxSupply)
+		if err != nil {
+			return Bond{}, sdk.ErrInvalidCoins(err.Error())
+		} else if maxSupply.Denom != bond.Token {
+			return Bond{}, ErrMaxSupplyDenomDoesNotMatchTokenDenom(DefaultCodespace)
+		} else if maxSupply.IsLT(bond.CurrentSupply) {
+			return Bond{}, ErrMaxSupplyBelowCurrentSupply(DefaultCodespace, maxSupply, bond.CurrentSupply)
+		}
+		bond.MaxSupply = maxSupply
+	}
+
+	// Both the function parameters and the max supply can change, so the
+	// changed function is checked against the (possibly changed) max supply
+	if err := bond.FunctionParameters.ValidateForMaxSupply(
+		bond.FunctionType, bond.MaxSupply.Amount); err != nil {
+		return Bond{}, err
+	}
+
+	return bond, nil
+}
+
+func parseFunctionParams(fnParamsStr string) (fnParams FunctionParams, err sdk.Error) {
+	// Parse "a:1,b:2" into function parameters (if not empty)
+	if strings.TrimSpace(fnParamsStr) == "" {
+		return FunctionParams{}, nil
+	}
+	for _, pv := range strings.Split(fnParamsStr, ",") {
+		pvArray := strings.SplitN(pv, ":", 2)
+		if len(pvArray) != 2 {
+			return nil, ErrInvalidFunctionParameter(DefaultCodespace, pv)
+		}
+		value, err := sdk.NewDecFromStr(pvArray[1])
+		if err != nil {
+			return nil, ErrFunctionParameterMissingOrNonFloat(DefaultCodespace, pvArray[0])
+		}
+		fnParams = append(fnParams, NewFunctionParam(pvArray[0], value))
+	}
+	return fnParams, nil
+}
+
+type TallyResult struct {
+	Yes     sdk.Int `json:"yes" yaml:"yes"`
+	No      sdk.Int `json:"no" yaml:"no"`
+	Abstain sdk.Int `json:"abstain" yaml:"abstain"`
+}
+
+func NewTallyResult(yes, no, abstain sdk.Int) TallyResult {
+	return TallyResult{
+		Yes:     yes,
+		No:      no,
+		Abstain: abstain,
+	}
+}
+
+func EmptyTallyResult() TallyResult {
+	return NewTallyResult(sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt())
+}
+
+// Passes returns whether the tally meets the quorum, out of the total voting
+// power, and whether the yes votes exceed the threshold of yes and no votes
+func (tr TallyResult) Passes(totalVotingPower sdk.Int) bool {
+	turnout := tr.Yes.Add(tr.No).Add(tr.Abstain)
+	if totalVotingPower.IsZero() ||
+		sdk.NewDecFromInt(turnout).LT(ProposalQuorum.MulInt(totalVotingPower)) {
+		return false
+	}
+
+	yesAndNo := tr.Yes.Add(tr.No)
+	if yesAndNo.IsZero() {
+		return false
+	}
+	return sdk.NewDecFromInt(tr.Yes).GT(ProposalThreshold.MulInt(yesAndNo))
+}
+
+type BondProposal struct {
+	ProposalId       uint64           `json:"proposal_id" yaml:"proposal_id"`
+	Token            string           `json:"token" yaml:"token"`
+	Proposer         sdk.AccAddress   `json:"proposer" yaml:"proposer"`
+	Description      string           `json:"description" yaml:"description"`
+	Changes          BondParamChanges `json:"changes" yaml:"changes"`
+	Status           string           `json:"status" yaml:"status"`
+	SubmitHeight     int64            `json:"submit_height" yaml:"submit_height"`
+	BlocksRemaining  sdk.Uint         `json:"blocks_remaining" yaml:"blocks_remaining"`
+	TotalVotingPower sdk.Int          `json:"total_voting_power" yaml:"total_voting_power"`
+	TallyResult      TallyResult      `json:"tally_result" yaml:"tally_result"`
+}
+
+func NewBondProposal(proposalId uint64, token string, proposer sdk.AccAddress,
+	description string, changes BondParamChanges, submitHeight int64,
+	votingPeriod sdk.Uint, totalVotingPower sdk.Int) BondProposal {
+	return BondProposal{
+		ProposalId:       proposalId,
+		Token:            token,
+		Proposer:         proposer,
+		Description:      description,
+		Changes:          changes,
+		Status:           VotingProposalStatus,
+		SubmitHeight:     submitHeight,
+		BlocksRemaining:  votingPeriod,
+		TotalVotingPower: totalVotingPower,
+		TallyResult:      EmptyTallyResult(),
+	}
+}
+
+func (p BondProposal) IsVoting() bool {
+	return p.Status == VotingProposalStatus
+}
+
+type BondProposalVote struct {
+	Voter  sdk.AccAddress `json:"voter" yaml:"voter"`
+	Option string         `json:"option" yaml:"option"`
+}
+
+func NewBondProposalVote(voter sdk.AccAddress, option string) BondProposalVote {
+	return BondProposalVote{
+		Voter:  voter,
+		Option: option,
+	}
+}
+
+func IsValidVoteOption(option string) bool {
+	return option == YesVoteOption || option == NoVoteOption ||
+		option == AbstainVoteOption
+}
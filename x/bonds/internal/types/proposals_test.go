@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTallyResultPasses(t *testing.T) {
+	testCases := []struct {
+		yes, no, abstain int64
+		passes           bool
+	}{
+		// Quorum of 33.4% of the total voting power, including abstain votes
+		{34, 0, 0, true},
+		{33, 0, 0, false},
+		{10, 0, 30, true},
+		{0, 0, 100, false},
+		// Yes votes have to exceed half of the yes and no votes
+		{50, 50, 0, false},
+		{51, 49, 0, true},
+	}
+	for _, tc := range testCases {
+		tr := NewTallyResult(sdk.NewInt(tc.yes), sdk.NewInt(tc.no), sdk.NewInt(tc.abstain))
+		require.Equal(t, tc.passes, tr.Passes(sdk.NewInt(100)), tc)
+	}
+
+	// No proposal passes without any voting power
+	require.False(t, NewTallyResult(sdk.NewInt(1), sdk.ZeroInt(), sdk.ZeroInt()).Passes(sdk.ZeroInt()))
+}
+
+func TestBondParamChangesApplyTo(t *testing.T) {
+	bond := testBond(PowerFunction, functionParams("m", "1", "n", "1", "c", "1"), 1000)
+	bond.CurrentSupply = sdk.NewInt64Coin("token", 100)
+	bond.TxFeePercentage = sdk.NewDec(10)
+	bond.ExitFeePercentage = sdk.NewDec(10)
+
+	changes := NewBondParamChanges("m:2,n:1,c:2", "5", DoNotModifyField,
+		ValidFeeAddress.String(), "500token")
+	require.Nil(t, changes.ValidateBasic())
+	changed, err := changes.ApplyTo(bond)
+	require.Nil(t, err)
+	require.Equal(t, functionParams("m", "2", "n", "1", "c", "2"), changed.FunctionParameters)
+	require.Equal(t, sdk.NewDec(5), changed.TxFeePercentage)
+	require.Equal(t, bond.ExitFeePercentage, changed.ExitFeePercentage)
+	require.Equal(t, ValidFeeAddress, changed.FeeAddress)
+	require.Equal(t, sdk.NewInt64Coin("token", 500), changed.MaxSupply)
+
+	invalid := []BondParamChanges{
+		// Missing function parameter
+		NewBondParamChanges("m:2,n:1", DoNotModifyField, DoNotModifyField,
+			DoNotModifyField, DoNotModifyField),
+		// Fees adding up to 100%
+		NewBondParamChanges(DoNotModifyField, "90", DoNotModifyField,
+			DoNotModifyField, DoNotModifyField),
+		// Invalid fee address
+		NewBondParamChanges(DoNotModifyField, DoNotModifyField, DoNotModifyField,
+			"address", DoNotModifyField),
+		// Max supply in a different denom or below the current supply
+		NewBondParamChanges(DoNotModifyField, DoNotModifyField, DoNotModifyField,
+			DoNotModifyField, "500other"),
+		NewBondParamChanges(DoNotModifyField, DoNotModifyField, DoNotModifyField,
+			DoNotModifyField, "99token"),
+	}
+	for _, c := range invalid {
+		require.Nil(t, c.ValidateBasic())
+		_, err := c.ApplyTo(bond)
+		require.NotNil(t, err, c)
+	}
+
+	// At least one parameter has to be changed
+	changes = NewBondParamChanges(DoNotModifyField, DoNotModifyField,
+		DoNotModifyField, DoNotModifyField, DoNotModifyField)
+	require.NotNil(t, changes.ValidateBasic())
+}
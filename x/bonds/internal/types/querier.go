@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"strings"
 )
@@ -11,10 +12,27 @@ func (b QueryBonds) String() string {
 	return strings.Join(b[:], "\n")
 }
 
+type BondDetails struct {
+	Token         string   `json:"token" yaml:"token"`
+	State         string   `json:"state" yaml:"state"`
+	CurrentSupply sdk.Coin `json:"current_supply" yaml:"current_supply"`
+}
+
+type QueryBondsDetailed []BondDetails
+
+func (b QueryBondsDetailed) String() string {
+	lines := make([]string, len(b))
+	for i, d := range b {
+		lines[i] = fmt.Sprintf("%s %s %s", d.Token, d.State, d.CurrentSupply.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
 type QueryBuyPrice struct {
 	AdjustedSupply sdk.Coin  `json:"adjusted_supply" yaml:"asdjusted_supply"`
 	Prices         sdk.Coins `json:"prices" yaml:"prices"`
 	TxFees         sdk.Coins `json:"tx_fees" yaml:"tx_fees"`
+	EntryTributes  sdk.Coins `json:"entry_tributes" yaml:"entry_tributes"`
 	TotalPrices    sdk.Coins `json:"total_prices" yaml:"total_prices"`
 	TotalFees      sdk.Coins `json:"total_fees" yaml:"total_fees"`
 }
@@ -32,3 +50,73 @@ type QuerySwapReturn struct {
 	TotalReturns sdk.Coins `json:"total_returns" yaml:"total_returns"`
 	TotalFees    sdk.Coins `json:"total_fees" yaml:"total_fees"`
 }
+
+type QueryBondState struct {
+	State         string   `json:"state" yaml:"state"`
+	CurrentSupply sdk.Coin `json:"current_supply" yaml:"current_supply"`
+	HatchSupply   sdk.Coin `json:"hatch_supply" yaml:"hatch_supply"`
+}
+
+type QueryPoolBalances struct {
+	ReserveBalances     sdk.Coins `json:"reserve_balances" yaml:"reserve_balances"`
+	FundingPoolBalances sdk.Coins `json:"funding_pool_balances" yaml:"funding_pool_balances"`
+}
+
+type QueryRestingOrders struct {
+	Buys  []BuyOrder  `json:"buys" yaml:"buys"`
+	Sells []SellOrder `json:"sells" yaml:"sells"`
+}
+
+type QueryPriceHistory []PriceHistoryEntry
+
+type QueryBondProposals []BondProposal
+
+type QueryVesting struct {
+	Schedules []VestingSchedule `json:"schedules" yaml:"schedules"`
+	Claimable sdk.Coin          `json:"claimable" yaml:"claimable"`
+}
+
+// QuerySimulateBatchParams are the hypothetical orders that are added to the
+// current batch when simulating it
+type QuerySimulateBatchParams struct {
+	Buys  []BuyOrder  `json:"buys" yaml:"buys"`
+	Sells []SellOrder `json:"sells" yaml:"sells"`
+	Swaps []SwapOrder `json:"swaps" yaml:"swaps"`
+}
+
+func NewQuerySimulateBatchParams(buys []BuyOrder, sells []SellOrder,
+	swaps []SwapOrder) QuerySimulateBatchParams {
+	return QuerySimulateBatchParams{
+		Buys:  buys,
+		Sells: sells,
+		Swaps: swaps,
+	}
+}
+
+const (
+	FulfilledOrderStatus = "fulfilled"
+	CancelledOrderStatus = "cancelled"
+	RestingOrderStatus   = "resting"
+)
+
+// SimulatedOrder is the outcome of an order in a batch simulation. Prices are
+// only set for buys, and returns only for sells and swaps.
+type SimulatedOrder struct {
+	OrderType    string         `json:"order_type" yaml:"order_type"`
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	Amount       sdk.Coin       `json:"amount" yaml:"amount"`
+	Hypothetical bool           `json:"hypothetical" yaml:"hypothetical"`
+	Status       string         `json:"status" yaml:"status"`
+	CancelReason string         `json:"cancel_reason" yaml:"cancel_reason"`
+	Prices       sdk.Coins      `json:"prices" yaml:"prices"`
+	Fees         sdk.Coins      `json:"fees" yaml:"fees"`
+	Returns      sdk.Coins      `json:"returns" yaml:"returns"`
+}
+
+type QuerySimulateBatch struct {
+	Orders          []SimulatedOrder `json:"orders" yaml:"orders"`
+	BuyPrices       sdk.DecCoins     `json:"buy_prices" yaml:"buy_prices"`
+	SellPrices      sdk.DecCoins     `json:"sell_prices" yaml:"sell_prices"`
+	CurrentSupply   sdk.Coin         `json:"current_supply" yaml:"current_supply"`
+	ReserveBalances sdk.Coins        `json:"reserve_balances" yaml:"reserve_balances"`
+}
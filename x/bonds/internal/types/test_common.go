@@ -0,0 +1,30 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	ValidCreatorAddress = sdk.AccAddress([]byte("creator_address_____"))
+	ValidFeeAddress     = sdk.AccAddress([]byte("fee_address_________"))
+	ValidBuyerAddress   = sdk.AccAddress([]byte("buyer_address_______"))
+	ValidOtherAddress   = sdk.AccAddress([]byte("other_address_______"))
+)
+
+const (
+	ValidToken        = "abc"
+	ValidReserveToken = "res"
+)
+
+// ValidCreateBondMsg creates an open power function bond (price = x + 1)
+// with a batch of a single block and no fees, vesting or transfer policy
+var ValidCreateBondMsg = NewMsgCreateBond(ValidToken, "Name", "Description",
+	ValidCreatorAddress, PowerFunction, FunctionParams{
+		NewFunctionParam("m", sdk.OneDec()),
+		NewFunctionParam("n", sdk.OneDec()),
+		NewFunctionParam("c", sdk.OneDec()),
+	}, []string{ValidReserveToken}, sdk.ZeroDec(), sdk.ZeroDec(),
+	ValidFeeAddress, nil, sdk.NewInt64Coin(ValidToken, 1000000), nil,
+	sdk.ZeroDec(), sdk.ZeroDec(), TRUE, []sdk.AccAddress{ValidCreatorAddress},
+	sdk.OneUint(), nil, FALSE, NoVestingConfig(ValidToken),
+	OpenTransferPolicy, nil)
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"math/big"
 	"strings"
@@ -18,6 +19,112 @@ func SquareRootInt(i sdk.Int) sdk.Dec {
 	return SquareRootDec(sdk.NewDecFromInt(i))
 }
 
+var (
+	// Constants used by the decimal exponential and logarithm approximations
+	eDec   = sdk.MustNewDecFromStr("2.718281828459045235")
+	ln2Dec = sdk.MustNewDecFromStr("0.693147180559945309")
+	twoDec = sdk.NewDec(2)
+
+	// MaxExpDecExponent is the largest exponent accepted by ExpDec. e^100 is
+	// in the order of 10^43, which leaves enough headroom below the maximum
+	// Dec (in the order of 10^76) for the arithmetic that curve functions
+	// apply to the result.
+	MaxExpDecExponent = sdk.NewDec(100)
+)
+
+func powerDecUint(d sdk.Dec, n uint64) sdk.Dec {
+	// Exponentiation by squaring
+	result := sdk.OneDec()
+	base := d
+	for n > 0 {
+		if n%2 == 1 {
+			result = result.Mul(base)
+		}
+		n /= 2
+		if n > 0 {
+			base = base.Mul(base)
+		}
+	}
+	return result
+}
+
+func ExpDec(d sdk.Dec) sdk.Dec {
+	// e^(-x) = 1/e^x, which rounds down to zero way before -x is too large
+	if d.IsNegative() {
+		if d.Neg().GT(MaxExpDecExponent) {
+			return sdk.ZeroDec()
+		}
+		return sdk.OneDec().Quo(ExpDec(d.Neg()))
+	} else if d.GT(MaxExpDecExponent) {
+		panic(fmt.Sprintf("exponent %s exceeds maximum exponent %s", d, MaxExpDecExponent))
+	}
+
+	// Split x into integer and fractional parts: e^x = e^i * e^f
+	intPart := d.TruncateInt()
+	fracPart := d.Sub(sdk.NewDecFromInt(intPart))
+
+	// Taylor series for e^f, which converges quickly since 0 <= f < 1
+	sum := sdk.OneDec()
+	term := sdk.OneDec()
+	for i := int64(1); !term.IsZero(); i++ {
+		term = term.Mul(fracPart).QuoInt64(i)
+		sum = sum.Add(term)
+	}
+
+	return powerDecUint(eDec, uint64(intPart.Int64())).Mul(sum)
+}
+
+func LnDec(d sdk.Dec) sdk.Dec {
+	if !d.IsPositive() {
+		panic(fmt.Sprintf("logarithm of non-positive value %s", d))
+	}
+
+	// Scale x into [1,2) such that x = m * 2^k and ln(x) = ln(m) + k*ln(2)
+	k := int64(0)
+	m := d
+	for m.GTE(twoDec) {
+		m = m.Quo(twoDec)
+		k++
+	}
+	for m.LT(sdk.OneDec()) {
+		m = m.Mul(twoDec)
+		k--
+	}
+
+	// Series ln(m) = 2 * sum(z^(2i+1) / (2i+1)), where z = (m-1)/(m+1) <= 1/3
+	z := m.Sub(sdk.OneDec()).Quo(m.Add(sdk.OneDec()))
+	zSquared := z.Mul(z)
+	sum := sdk.ZeroDec()
+	power := z
+	for i := int64(1); !power.IsZero(); i += 2 {
+		sum = sum.Add(power.QuoInt64(i))
+		power = power.Mul(zSquared)
+	}
+
+	return sum.Mul(twoDec).Add(ln2Dec.MulInt64(k))
+}
+
+func PowerDec(d sdk.Dec, exponent sdk.Dec) sdk.Dec {
+	if exponent.IsNegative() {
+		panic(fmt.Sprintf("negative exponent %s", exponent))
+	}
+
+	// Integer exponents can be calculated precisely
+	if exponent.IsInteger() {
+		intExponent := exponent.TruncateInt()
+		if !intExponent.IsInt64() {
+			panic(fmt.Sprintf("exponent %s too large", exponent))
+		}
+		return powerDecUint(d, uint64(intExponent.Int64()))
+	}
+
+	// Otherwise, x^n = e^(n*ln(x)) for any x > 0
+	if d.IsZero() {
+		return sdk.ZeroDec()
+	}
+	return ExpDec(exponent.Mul(LnDec(d)))
+}
+
 func RoundReservePrice(p sdk.DecCoin) sdk.Coin {
 	// ReservePrices are rounded up so that the account gets charged more
 	roundedAmount := p.Amount.Ceil().TruncateInt()
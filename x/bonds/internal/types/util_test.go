@@ -0,0 +1,98 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Approximations are compared up to 10^-12, relative to the expected value
+// for values larger than 1, which is well within the 18 decimal places
+func requireApproxEqual(t *testing.T, expected, actual sdk.Dec) {
+	tolerance := sdk.NewDecWithPrec(1, 12)
+	if expected.Abs().GT(sdk.OneDec()) {
+		tolerance = tolerance.Mul(expected.Abs())
+	}
+	require.True(t, expected.Sub(actual).Abs().LTE(tolerance),
+		"expected %s but got %s", expected, actual)
+}
+
+func TestExpDec(t *testing.T) {
+	testCases := []struct {
+		x        string
+		expected string
+	}{
+		{"0", "1"},
+		{"1", "2.718281828459045235"},
+		{"0.5", "1.648721270700128147"},
+		{"-1", "0.367879441171442322"},
+		{"2.5", "12.182493960703473438"},
+		{"10", "22026.465794806716516958"},
+		{"-10", "0.000045399929762485"},
+		{"100", "26881171418161354484126255515800135873611118.773741922415191608"},
+		{"-100", "0"},
+		{"-1000", "0"},
+	}
+	for _, tc := range testCases {
+		x := sdk.MustNewDecFromStr(tc.x)
+		requireApproxEqual(t, sdk.MustNewDecFromStr(tc.expected), ExpDec(x))
+	}
+
+	// Exponents above the max exponent cannot be represented
+	require.Panics(t, func() { ExpDec(MaxExpDecExponent.Add(sdk.SmallestDec())) })
+	require.Panics(t, func() { ExpDec(sdk.NewDec(1000000)) })
+}
+
+func TestLnDec(t *testing.T) {
+	testCases := []struct {
+		x        string
+		expected string
+	}{
+		{"1", "0"},
+		{"2", "0.693147180559945309"},
+		{"2.718281828459045235", "1"},
+		{"0.5", "-0.693147180559945309"},
+		{"10", "2.302585092994045684"},
+		{"1000000", "13.815510557964274104"},
+		{"0.000001", "-13.815510557964274104"},
+	}
+	for _, tc := range testCases {
+		x := sdk.MustNewDecFromStr(tc.x)
+		requireApproxEqual(t, sdk.MustNewDecFromStr(tc.expected), LnDec(x))
+	}
+
+	// Logarithm is only defined for positive values
+	require.Panics(t, func() { LnDec(sdk.ZeroDec()) })
+	require.Panics(t, func() { LnDec(sdk.NewDec(-1)) })
+}
+
+func TestPowerDec(t *testing.T) {
+	testCases := []struct {
+		x        string
+		exponent string
+		expected string
+	}{
+		{"2", "0", "1"},
+		{"2", "10", "1024"},
+		{"1.5", "3", "3.375"},
+		{"0", "2", "0"},
+		{"0", "0.5", "0"},
+		{"4", "0.5", "2"},
+		{"2", "1.5", "2.828427124746190098"},
+		{"10", "2.5", "316.227766016837933200"},
+		{"0.25", "0.5", "0.5"},
+		{"1", "1000000000000", "1"},
+	}
+	for _, tc := range testCases {
+		x := sdk.MustNewDecFromStr(tc.x)
+		exponent := sdk.MustNewDecFromStr(tc.exponent)
+		requireApproxEqual(t, sdk.MustNewDecFromStr(tc.expected), PowerDec(x, exponent))
+	}
+
+	// Negative exponents and exponents that do not fit in an int64 panic
+	require.Panics(t, func() { PowerDec(sdk.NewDec(2), sdk.NewDec(-1)) })
+	require.Panics(t, func() {
+		PowerDec(sdk.OneDec(), sdk.NewDecFromInt(sdk.NewInt(1).MulRaw(1<<62).MulRaw(4)))
+	})
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingConfig makes tokens bought from a bond vest linearly over a number
+// of blocks, with nothing claimable before the cliff. Vesting only applies to
+// buys performed while the bond's supply is below AppliesUntilSupply, so that
+// it can be limited to an initial offering. A zero duration disables vesting.
+type VestingConfig struct {
+	CliffBlocks        uint64   `json:"cliff_blocks" yaml:"cliff_blocks"`
+	DurationBlocks     uint64   `json:"duration_blocks" yaml:"duration_blocks"`
+	AppliesUntilSupply sdk.Coin `json:"applies_until_supply" yaml:"applies_until_supply"`
+}
+
+func NewVestingConfig(cliffBlocks, durationBlocks uint64, appliesUntilSupply sdk.Coin) VestingConfig {
+	return VestingConfig{
+		CliffBlocks:        cliffBlocks,
+		DurationBlocks:     durationBlocks,
+		AppliesUntilSupply: appliesUntilSupply,
+	}
+}
+
+func NoVestingConfig(token string) VestingConfig {
+	return NewVestingConfig(0, 0, sdk.NewCoin(token, sdk.ZeroInt()))
+}
+
+func (vc VestingConfig) String() string {
+	return fmt.Sprintf("{cliff:%d,duration:%d,until:%s}",
+		vc.CliffBlocks, vc.DurationBlocks, vc.AppliesUntilSupply.String())
+}
+
+func (vc VestingConfig) IsEnabled() bool {
+	return vc.DurationBlocks != 0
+}
+
+// AppliesAt returns whether a buy performed at the specified supply vests
+func (vc VestingConfig) AppliesAt(supply sdk.Coin) bool {
+	return vc.IsEnabled() && supply.IsLT(vc.AppliesUntilSupply)
+}
+
+func (vc VestingConfig) Validate(token string, maxSupply sdk.Coin) sdk.Error {
+	// Vesting is optional
+	if !vc.IsEnabled() {
+		if vc.CliffBlocks != 0 {
+			return ErrArgumentMustBePositive(DefaultCodespace, "Vesting duration")
+		}
+		return nil
+	}
+
+	if vc.CliffBlocks > vc.DurationBlocks {
+		return ErrVestingCliffExceedsDuration(DefaultCodespace, vc.CliffBlocks, vc.DurationBlocks)
+	} else if vc.AppliesUntilSupply.Denom != token {
+		return ErrVestingSupplyDenomDoesNotMatchTokenDenom(DefaultCodespace)
+	} else if !vc.AppliesUntilSupply.IsPositive() {
+		return ErrArgumentMustBePositive(DefaultCodespace, "Vesting applies-until supply")
+	} else if maxSupply.IsLT(vc.AppliesUntilSupply) {
+		return ErrVestingSupplyExceedsMaxSupply(DefaultCodespace)
+	}
+	return nil
+}
+
+// VestingSchedule is an amount of bond tokens that vests linearly from the
+// start height to the end height, none of which vests before the cliff height
+type VestingSchedule struct {
+	StartHeight int64    `json:"start_height" yaml:"start_height"`
+	CliffHeight int64    `json:"cliff_height" yaml:"cliff_height"`
+	EndHeight   int64    `json:"end_height" yaml:"end_height"`
+	Amount      sdk.Coin `json:"amount" yaml:"amount"`
+	Claimed     sdk.Coin `json:"claimed" yaml:"claimed"`
+}
+
+func NewVestingSchedule(config VestingConfig, startHeight int64, amount sdk.Coin) VestingSchedule {
+	return VestingSchedule{
+		StartHeight: startHeight,
+		CliffHeight: startHeight + int64(config.CliffBlocks),
+		EndHeight:   startHeight + int64(config.DurationBlocks),
+		Amount:      amount,
+		Claimed:     sdk.NewCoin(amount.Denom, sdk.ZeroInt()),
+	}
+}
+
+// VestedAt returns the amount of the schedule that has vested by the height,
+// rounded down, including any amount that has already been claimed
+func (vs VestingSchedule) VestedAt(height int64) sdk.Coin {
+	if height < vs.CliffHeight {
+		return sdk.NewCoin(vs.Amount.Denom, sdk.ZeroInt())
+	} else if height >= vs.EndHeight {
+		return vs.Amount
+	}
+	elapsed := sdk.NewInt(height - vs.StartHeight)
+	duration := sdk.NewInt(vs.EndHeight - vs.StartHeight)
+	return sdk.NewCoin(vs.Amount.Denom, vs.Amount.Amount.Mul(elapsed).Quo(duration))
+}
+
+// ClaimableAt returns the amount of the schedule that has vested by the
+// height but has not been claimed yet
+func (vs VestingSchedule) ClaimableAt(height int64) sdk.Coin {
+	return vs.VestedAt(height).Sub(vs.Claimed)
+}
+
+// Unclaimed returns the amount of the schedule that is still held by the
+// bonds vesting account, whether vested or not
+func (vs VestingSchedule) Unclaimed() sdk.Coin {
+	return vs.Amount.Sub(vs.Claimed)
+}
+
+func (vs VestingSchedule) IsFullyClaimed() bool {
+	return vs.Claimed.IsEqual(vs.Amount)
+}
+
+// AccountVestingSchedules are the vesting schedules of an address's tokens
+// bought from a bond
+type AccountVestingSchedules struct {
+	Token     string            `json:"token" yaml:"token"`
+	Address   sdk.AccAddress    `json:"address" yaml:"address"`
+	Schedules []VestingSchedule `json:"schedules" yaml:"schedules"`
+}
+
+func NewAccountVestingSchedules(token string, address sdk.AccAddress, schedules []VestingSchedule) AccountVestingSchedules {
+	return AccountVestingSchedules{
+		Token:     token,
+		Address:   address,
+		Schedules: schedules,
+	}
+}
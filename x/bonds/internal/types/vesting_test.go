@@ -0,0 +1,65 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingConfigValidate(t *testing.T) {
+	maxSupply := sdk.NewInt64Coin("token", 1000)
+	require.Nil(t, NoVestingConfig("token").Validate("token", maxSupply))
+	require.Nil(t, NewVestingConfig(10, 100, sdk.NewInt64Coin("token", 1000)).Validate("token", maxSupply))
+	require.Nil(t, NewVestingConfig(0, 100, sdk.NewInt64Coin("token", 1)).Validate("token", maxSupply))
+
+	invalid := []VestingConfig{
+		// Cliff without a duration
+		NewVestingConfig(10, 0, sdk.NewInt64Coin("token", 0)),
+		// Cliff after the end of the duration
+		NewVestingConfig(101, 100, sdk.NewInt64Coin("token", 1000)),
+		// Supply in a different denom, zero or greater than the max supply
+		NewVestingConfig(10, 100, sdk.NewInt64Coin("other", 1000)),
+		NewVestingConfig(10, 100, sdk.NewInt64Coin("token", 0)),
+		NewVestingConfig(10, 100, sdk.NewInt64Coin("token", 1001)),
+	}
+	for _, vc := range invalid {
+		require.NotNil(t, vc.Validate("token", maxSupply))
+	}
+}
+
+func TestVestingConfigAppliesAt(t *testing.T) {
+	vc := NewVestingConfig(10, 100, sdk.NewInt64Coin("token", 500))
+	require.True(t, vc.AppliesAt(sdk.NewInt64Coin("token", 0)))
+	require.True(t, vc.AppliesAt(sdk.NewInt64Coin("token", 499)))
+	require.False(t, vc.AppliesAt(sdk.NewInt64Coin("token", 500)))
+	require.False(t, NoVestingConfig("token").AppliesAt(sdk.NewInt64Coin("token", 0)))
+}
+
+func TestVestingScheduleVestedAt(t *testing.T) {
+	// 1000 tokens vesting over 100 blocks from block 5, with a cliff at block 15
+	vc := NewVestingConfig(10, 100, sdk.NewInt64Coin("token", 1000))
+	vs := NewVestingSchedule(vc, 5, sdk.NewInt64Coin("token", 1000))
+	require.Equal(t, int64(15), vs.CliffHeight)
+	require.Equal(t, int64(105), vs.EndHeight)
+
+	testCases := []struct {
+		height int64
+		vested int64
+	}{
+		{5, 0}, {14, 0}, {15, 100}, {38, 330}, {104, 990}, {105, 1000}, {200, 1000},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, sdk.NewInt64Coin("token", tc.vested), vs.VestedAt(tc.height))
+	}
+
+	// Claimed tokens are no longer claimable, but are still vested
+	vs.Claimed = sdk.NewInt64Coin("token", 330)
+	require.Equal(t, sdk.NewInt64Coin("token", 330), vs.VestedAt(38))
+	require.True(t, vs.ClaimableAt(38).IsZero())
+	require.Equal(t, sdk.NewInt64Coin("token", 670), vs.ClaimableAt(105))
+	require.Equal(t, sdk.NewInt64Coin("token", 670), vs.Unclaimed())
+	require.False(t, vs.IsFullyClaimed())
+	vs.Claimed = vs.Amount
+	require.True(t, vs.IsFullyClaimed())
+}
@@ -0,0 +1,257 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds"
+)
+
+var (
+	// ReserveTokens are the denominations that simulated bonds use as reserve
+	// tokens, which the simulation's genesis accounts are expected to hold
+	ReserveTokens = []string{"resa", "resb", "resc", "resd"}
+
+	// FunctionTypes are the function types of the simulated bonds. Each one of
+	// them is used by one of the genesis bonds.
+	FunctionTypes = []string{
+		bonds.PowerFunction,
+		bonds.SigmoidFunction,
+		bonds.ExponentialFunction,
+		bonds.LogarithmicFunction,
+		bonds.AugmentedFunction,
+		bonds.SwapperFunction,
+		bonds.WeightedSwapperFunction,
+	}
+)
+
+// RandomizedGenState generates a genesis state with a new bond of each
+// function type, created by random accounts. The bonds have no supply yet, so
+// the genesis state is consistent with any genesis accounts.
+func RandomizedGenState(r *rand.Rand, accs []simulation.Account) bonds.GenesisState {
+	var bondsList []bonds.Bond
+	var batches []bonds.Batch
+	for i, functionType := range FunctionTypes {
+		token := fmt.Sprintf("bond%d", i)
+		msg := randomMsgCreateBond(r, accs, token, functionType)
+		if err := msg.ValidateBasic(); err != nil {
+			panic(fmt.Sprintf("invalid random genesis bond %s: %s", token, err.Error()))
+		}
+
+		bond := bonds.NewBond(msg.Token, msg.Name, msg.Description, msg.Creator,
+			msg.FunctionType, msg.FunctionParameters, msg.ReserveTokens,
+			msg.TxFeePercentage, msg.ExitFeePercentage, msg.FeeAddress,
+			msg.FeeRecipients, msg.MaxSupply, msg.OrderQuantityLimits, msg.SanityRate,
+			msg.SanityMarginPercentage, msg.AllowSells, msg.Signers,
+			msg.BatchBlocks, msg.HatchWhitelist, msg.CommitRevealSwaps, msg.Vesting,
+			msg.TransferPolicy, msg.TransferAllowList)
+		bondsList = append(bondsList, bond)
+		batches = append(batches, bonds.NewBatch(bond.Token, bond.BatchBlocks))
+	}
+
+	return bonds.NewGenesisState(bondsList, batches, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+}
+
+// randomMsgCreateBond generates a bond creation with random values for the
+// function type. Function parameters and max supplies are generated across
+// the full range of values that are accepted when creating a bond.
+func randomMsgCreateBond(r *rand.Rand, accs []simulation.Account,
+	token, functionType string) bonds.MsgCreateBond {
+
+	creator := simulation.RandomAcc(r, accs)
+	maxSupply := sdk.NewCoin(token, randomMaxSupply(r))
+
+	// Parameters are drawn from ranges that include values that are rejected
+	// (e.g. kappa < 1, or powers that cannot be calculated at the max
+	// supply), in which case the parameters are drawn again
+	reserveTokens, functionParams := randomFunctionParams(r, functionType, maxSupply.Amount)
+	for functionParams.Validate(functionType) != nil ||
+		functionParams.ValidateForMaxSupply(functionType, maxSupply.Amount) != nil {
+		reserveTokens, functionParams = randomFunctionParams(r, functionType, maxSupply.Amount)
+	}
+
+	// Fee percentages of up to 5%, with the fees optionally being split
+	// between the fee address and a random number of fee recipients
+	txFeePercentage := randomDec(r, 0, 500, 2)
+	exitFeePercentage := randomDec(r, 0, 500, 2)
+	feeAddress := simulation.RandomAcc(r, accs).Address
+	var feeRecipients bonds.FeeRecipients
+	if r.Intn(2) == 0 {
+		feeRecipients = randomFeeRecipients(r, accs)
+	}
+
+	var orderQuantityLimits sdk.Coins
+	if r.Intn(4) == 0 {
+		orderQuantityLimits = sdk.NewCoins(sdk.NewInt64Coin(token,
+			int64(simulation.RandIntBetween(r, 100, 1000))))
+	}
+
+	allowSells := bonds.TRUE
+	if r.Intn(5) == 0 {
+		allowSells = bonds.FALSE
+	}
+
+	// The hatch whitelist is only used by augmented functions
+	var hatchWhitelist []sdk.AccAddress
+	if functionType == bonds.AugmentedFunction {
+		hatchWhitelist = randomAddresses(r, accs)
+	}
+
+	var vesting bonds.VestingConfig
+	if r.Intn(4) == 0 {
+		durationBlocks := uint64(simulation.RandIntBetween(r, 1, 50))
+		cliffBlocks := uint64(r.Int63n(int64(durationBlocks) + 1))
+		appliesUntilSupply := simulation.RandomAmount(r, maxSupply.Amount)
+		if appliesUntilSupply.IsZero() {
+			appliesUntilSupply = sdk.OneInt()
+		}
+		vesting = bonds.NewVestingConfig(cliffBlocks, durationBlocks,
+			sdk.NewCoin(token, appliesUntilSupply))
+	}
+
+	// Credential-required transfer policies are not simulated, since these
+	// depend on the DID module
+	transferPolicy := bonds.OpenTransferPolicy
+	var transferAllowList []sdk.AccAddress
+	if r.Intn(5) == 0 {
+		transferPolicy = bonds.AllowListTransferPolicy
+		transferAllowList = randomAddresses(r, accs)
+	}
+
+	batchBlocks := sdk.NewUint(uint64(simulation.RandIntBetween(r, 1, 6)))
+
+	return bonds.NewMsgCreateBond(token,
+		simulation.RandStringOfLength(r, 10),
+		simulation.RandStringOfLength(r, 50),
+		creator.Address, functionType, functionParams, reserveTokens,
+		txFeePercentage, exitFeePercentage, feeAddress, feeRecipients,
+		maxSupply, orderQuantityLimits, sdk.ZeroDec(), sdk.ZeroDec(),
+		allowSells, []sdk.AccAddress{creator.Address}, batchBlocks,
+		hatchWhitelist, bonds.FALSE, vesting, transferPolicy, transferAllowList)
+}
+
+// randomFunctionParams generates random reserve tokens and function
+// parameters for the function type, with non-integer parameters spanning
+// several orders of magnitude
+func randomFunctionParams(r *rand.Rand, functionType string, maxSupply sdk.Int) (
+	reserveTokens []string, functionParams bonds.FunctionParams) {
+
+	switch functionType {
+	case bonds.PowerFunction:
+		reserveTokens = randomReserveTokens(r, 1, len(ReserveTokens))
+		functionParams = bonds.FunctionParams{
+			bonds.NewFunctionParam("m", randomDec(r, 1, 100000, 4)),
+			bonds.NewFunctionParam("n", randomDec(r, 1, 400, 2)),
+			bonds.NewFunctionParam("c", randomDec(r, 1, 1000000, 4)),
+		}
+	case bonds.SigmoidFunction:
+		reserveTokens = randomReserveTokens(r, 1, len(ReserveTokens))
+		functionParams = bonds.FunctionParams{
+			bonds.NewFunctionParam("a", randomDec(r, 1, 1000000, 4)),
+			bonds.NewFunctionParam("b", randomDec(r, 1, 100000000, 4)),
+			bonds.NewFunctionParam("c", randomDec(r, 1, 100000000, 4)),
+		}
+	case bonds.ExponentialFunction:
+		// The exponent b*s at the max supply is anywhere up to the max
+		// exponent, including the max exponent itself
+		maxB := bonds.MaxExpDecExponent.QuoInt(maxSupply)
+		reserveTokens = randomReserveTokens(r, 1, len(ReserveTokens))
+		functionParams = bonds.FunctionParams{
+			bonds.NewFunctionParam("a", randomDec(r, 1, 100000, 4)),
+			bonds.NewFunctionParam("b", maxB.Mul(randomDec(r, 1, 10000, 4))),
+			bonds.NewFunctionParam("c", randomDec(r, 1, 1000000, 4)),
+		}
+	case bonds.LogarithmicFunction:
+		reserveTokens = randomReserveTokens(r, 1, len(ReserveTokens))
+		functionParams = bonds.FunctionParams{
+			bonds.NewFunctionParam("a", randomDec(r, 1, 1000000, 4)),
+			bonds.NewFunctionParam("b", randomDec(r, 1, 1000000, 4)),
+			bonds.NewFunctionParam("c", randomDec(r, 1, 1000000, 4)),
+		}
+	case bonds.AugmentedFunction:
+		reserveTokens = randomReserveTokens(r, 1, len(ReserveTokens))
+		functionParams = bonds.FunctionParams{
+			bonds.NewFunctionParam("d0", randomDec(r, 1, 1000000000, 2)),
+			bonds.NewFunctionParam("p0", randomDec(r, 1, 100000, 4)),
+			bonds.NewFunctionParam("theta", randomDec(r, 1, 99, 2)),
+			bonds.NewFunctionParam("kappa", randomDec(r, 50, 500, 2)),
+		}
+	case bonds.SwapperFunction:
+		reserveTokens = randomReserveTokens(r, 2, 2)
+	case bonds.WeightedSwapperFunction:
+		reserveTokens = randomReserveTokens(r,
+			bonds.MinWeightedSwapperReserveTokens, len(ReserveTokens))
+		for _, rt := range reserveTokens {
+			functionParams = append(functionParams,
+				bonds.NewFunctionParam(rt, randomDec(r, 1, 10000, 3)))
+		}
+	default:
+		panic(fmt.Sprintf("unrecognized function type %s", functionType))
+	}
+	return reserveTokens, functionParams
+}
+
+// randomMaxSupply generates a random max supply with up to 13 digits, with
+// each number of digits being equally likely
+func randomMaxSupply(r *rand.Rand) sdk.Int {
+	lowerBound := sdk.NewIntWithDecimal(1, simulation.RandIntBetween(r, 0, 13))
+	return lowerBound.Add(simulation.RandomAmount(r, lowerBound.MulRaw(9)))
+}
+
+// randomBondToken generates a random bond token denomination
+func randomBondToken(r *rand.Rand) string {
+	return "bond" + strings.ToLower(simulation.RandStringOfLength(r, 8))
+}
+
+// randomReserveTokens picks between min and max distinct reserve tokens
+func randomReserveTokens(r *rand.Rand, min, max int) []string {
+	n := simulation.RandIntBetween(r, min, max+1)
+	var reserveTokens []string
+	for _, i := range r.Perm(len(ReserveTokens))[:n] {
+		reserveTokens = append(reserveTokens, ReserveTokens[i])
+	}
+	return reserveTokens
+}
+
+// randomAddresses picks a non-empty random subset of the accounts
+func randomAddresses(r *rand.Rand, accs []simulation.Account) []sdk.AccAddress {
+	n := simulation.RandIntBetween(r, 1, len(accs)+1)
+	var addresses []sdk.AccAddress
+	for _, i := range r.Perm(len(accs))[:n] {
+		addresses = append(addresses, accs[i].Address)
+	}
+	return addresses
+}
+
+// randomFeeRecipients picks distinct fee recipients whose integer percentages
+// add up to 100
+func randomFeeRecipients(r *rand.Rand, accs []simulation.Account) bonds.FeeRecipients {
+	maxRecipients := bonds.MaxFeeRecipients
+	if len(accs) < maxRecipients {
+		maxRecipients = len(accs)
+	}
+	n := simulation.RandIntBetween(r, 1, maxRecipients+1)
+
+	var feeRecipients bonds.FeeRecipients
+	remaining := int64(100)
+	for j, i := range r.Perm(len(accs))[:n] {
+		percentage := remaining
+		if j < n-1 {
+			// Leave at least 1% for each of the remaining recipients
+			percentage = r.Int63n(remaining-int64(n-1-j)) + 1
+		}
+		remaining -= percentage
+		feeRecipients = append(feeRecipients,
+			bonds.NewFeeRecipient(accs[i].Address, sdk.NewDec(percentage)))
+	}
+	return feeRecipients
+}
+
+// randomDec generates a random decimal between min and max (inclusive) with
+// the specified precision, e.g. randomDec(r, 1, 10, 4) is between 0.0001 and 0.0010
+func randomDec(r *rand.Rand, min, max int, prec int64) sdk.Dec {
+	return sdk.NewDecWithPrec(int64(simulation.RandIntBetween(r, min, max+1)), prec)
+}
@@ -0,0 +1,296 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds"
+)
+
+// Weights of the simulated operations. Orders are simulated much more often
+// than bond creations and edits, so that batches are mostly non-empty.
+const (
+	WeightMsgCreateBond = 5
+	WeightMsgEditBond   = 5
+	WeightMsgBuy        = 100
+	WeightMsgSell       = 60
+	WeightMsgSwap       = 40
+
+	// Max amount of bond tokens bought or sold by a single order
+	maxOrderAmount = 1000
+)
+
+// WeightedOperations returns all of the operations of the bonds module with
+// the weights that they are simulated with
+func WeightedOperations(k bonds.Keeper) simulation.WeightedOperations {
+	return simulation.WeightedOperations{
+		{Weight: WeightMsgCreateBond, Op: SimulateMsgCreateBond(k)},
+		{Weight: WeightMsgEditBond, Op: SimulateMsgEditBond(k)},
+		{Weight: WeightMsgBuy, Op: SimulateMsgBuy(k)},
+		{Weight: WeightMsgSell, Op: SimulateMsgSell(k)},
+		{Weight: WeightMsgSwap, Op: SimulateMsgSwap(k)},
+	}
+}
+
+// SimulateMsgCreateBond generates a MsgCreateBond for a bond of a random
+// function type with random values
+func SimulateMsgCreateBond(k bonds.Keeper) simulation.Operation {
+	handler := bonds.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		token := randomBondToken(r)
+		functionType := FunctionTypes[r.Intn(len(FunctionTypes))]
+		msg := randomMsgCreateBond(r, accs, token, functionType)
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		ok := simulateHandleMsg(msg, handler, ctx)
+		opMsg = simulation.NewOperationMsg(msg, ok, functionType)
+		return opMsg, nil, nil
+	}
+}
+
+// SimulateMsgEditBond generates a MsgEditBond with a random name, description
+// and order quantity limits for a random bond
+func SimulateMsgEditBond(k bonds.Keeper) simulation.Operation {
+	handler := bonds.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		bond, found := randomBond(r, k, ctx, func(bonds.Bond) bool { return true })
+		if !found {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		orderQuantityLimits := bonds.DoNotModifyField
+		if r.Intn(2) == 0 {
+			orderQuantityLimits = ""
+			if r.Intn(2) == 0 {
+				orderQuantityLimits = sdk.NewInt64Coin(bond.Token,
+					int64(simulation.RandIntBetween(r, 100, 1000))).String()
+			}
+		}
+
+		msg := bonds.NewMsgEditBond(bond.Token,
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 50),
+			orderQuantityLimits, bonds.DoNotModifyField, bonds.DoNotModifyField,
+			bond.Creator, bond.Signers)
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		ok := simulateHandleMsg(msg, handler, ctx)
+		opMsg = simulation.NewOperationMsg(msg, ok, "")
+		return opMsg, nil, nil
+	}
+}
+
+// SimulateMsgBuy generates a MsgBuy of a random amount of a random bond's
+// tokens, with max prices of up to the buyer's reserve token balances
+func SimulateMsgBuy(k bonds.Keeper) simulation.Operation {
+	handler := bonds.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		bond, found := randomBond(r, k, ctx, func(b bonds.Bond) bool {
+			return b.IsAcceptingOrders()
+		})
+		if !found {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		// Hatch buyers are limited to the whitelist and the hatch supply, so
+		// these are only rarely simulated as failing buys
+		buyer := simulation.RandomAcc(r, accs)
+		if bond.State == bonds.HatchState && r.Intn(10) != 0 {
+			buyer = randomWhitelistedAcc(r, accs, bond.HatchWhitelist)
+		}
+
+		maxAmount := bond.MaxSupply.Amount.Sub(bond.CurrentSupply.Amount)
+		if maxAmount.GT(sdk.NewInt(maxOrderAmount)) {
+			maxAmount = sdk.NewInt(maxOrderAmount)
+		}
+		amount, err := simulation.RandPositiveInt(r, maxAmount)
+		if err != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		balances := k.CoinKeeper.GetCoins(ctx, buyer.Address)
+		var maxPrices sdk.Coins
+		for _, rt := range bond.ReserveTokens {
+			maxPrice := simulation.RandomAmount(r, balances.AmountOf(rt))
+			if maxPrice.IsZero() {
+				return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+			}
+			maxPrices = append(maxPrices, sdk.NewCoin(rt, maxPrice))
+		}
+
+		msg := bonds.NewMsgBuy(buyer.Address, sdk.NewCoin(bond.Token, amount),
+			maxPrices.Sort(), randomGoodTillBlock(r, ctx))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		ok := simulateHandleMsg(msg, handler, ctx)
+		opMsg = simulation.NewOperationMsg(msg, ok, bond.FunctionType)
+		return opMsg, nil, nil
+	}
+}
+
+// SimulateMsgSell generates a MsgSell of a random amount of a random bond's
+// tokens held by a random account
+func SimulateMsgSell(k bonds.Keeper) simulation.Operation {
+	handler := bonds.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		bond, found := randomBond(r, k, ctx, func(b bonds.Bond) bool {
+			return b.CurrentSupply.IsPositive()
+		})
+		if !found {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		seller, found := randomAccWithBalance(r, k, ctx, accs, bond.Token)
+		if !found {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		maxAmount := k.CoinKeeper.GetCoins(ctx, seller.Address).AmountOf(bond.Token)
+		if maxAmount.GT(sdk.NewInt(maxOrderAmount)) {
+			maxAmount = sdk.NewInt(maxOrderAmount)
+		}
+		amount := simulation.RandomAmount(r, maxAmount)
+		if amount.IsZero() {
+			amount = sdk.OneInt()
+		}
+
+		msg := bonds.NewMsgSell(seller.Address, sdk.NewCoin(bond.Token, amount),
+			nil, randomGoodTillBlock(r, ctx))
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		ok := simulateHandleMsg(msg, handler, ctx)
+		opMsg = simulation.NewOperationMsg(msg, ok, bond.FunctionType)
+		return opMsg, nil, nil
+	}
+}
+
+// SimulateMsgSwap generates a MsgSwap of a random amount of one of a random
+// swapper bond's reserve tokens for another one of its reserve tokens
+func SimulateMsgSwap(k bonds.Keeper) simulation.Operation {
+	handler := bonds.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (
+		opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		bond, found := randomBond(r, k, ctx, func(b bonds.Bond) bool {
+			return (b.FunctionType == bonds.SwapperFunction ||
+				b.FunctionType == bonds.WeightedSwapperFunction) &&
+				b.IsAcceptingOrders() && b.CurrentSupply.IsPositive()
+		})
+		if !found {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		perm := r.Perm(len(bond.ReserveTokens))
+		fromToken := bond.ReserveTokens[perm[0]]
+		toToken := bond.ReserveTokens[perm[1]]
+
+		swapper := simulation.RandomAcc(r, accs)
+		maxAmount := k.CoinKeeper.GetCoins(ctx, swapper.Address).AmountOf(fromToken)
+		amount, err := simulation.RandPositiveInt(r, maxAmount)
+		if err != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, nil
+		}
+
+		msg := bonds.NewMsgSwap(swapper.Address, bond.Token,
+			sdk.NewCoin(fromToken, amount), toToken, nil)
+		if msg.ValidateBasic() != nil {
+			return simulation.NoOpMsg(bonds.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+
+		ok := simulateHandleMsg(msg, handler, ctx)
+		opMsg = simulation.NewOperationMsg(msg, ok, bond.FunctionType)
+		return opMsg, nil, nil
+	}
+}
+
+// simulateHandleMsg handles the msg in a cached context, which is only
+// written if the msg succeeds, as is the case for msgs in a transaction
+func simulateHandleMsg(msg sdk.Msg, handler sdk.Handler, ctx sdk.Context) (ok bool) {
+	ctx, write := ctx.CacheContext()
+	ok = handler(ctx, msg).IsOK()
+	if ok {
+		write()
+	}
+	return ok
+}
+
+// randomBond picks a random bond out of the bonds that satisfy the filter
+func randomBond(r *rand.Rand, k bonds.Keeper, ctx sdk.Context,
+	filter func(bonds.Bond) bool) (bond bonds.Bond, found bool) {
+
+	var bondsList []bonds.Bond
+	iterator := k.GetBondIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		b := k.MustGetBondByKey(ctx, iterator.Key())
+		if filter(b) {
+			bondsList = append(bondsList, b)
+		}
+	}
+
+	if len(bondsList) == 0 {
+		return bonds.Bond{}, false
+	}
+	return bondsList[r.Intn(len(bondsList))], true
+}
+
+// randomAccWithBalance picks a random account that holds some of the denom
+func randomAccWithBalance(r *rand.Rand, k bonds.Keeper, ctx sdk.Context,
+	accs []simulation.Account, denom string) (acc simulation.Account, found bool) {
+
+	for _, i := range r.Perm(len(accs)) {
+		if k.CoinKeeper.GetCoins(ctx, accs[i].Address).AmountOf(denom).IsPositive() {
+			return accs[i], true
+		}
+	}
+	return simulation.Account{}, false
+}
+
+// randomWhitelistedAcc picks a random account out of the accounts that are in
+// the whitelist, or any random account if none of them are
+func randomWhitelistedAcc(r *rand.Rand, accs []simulation.Account,
+	whitelist []sdk.AccAddress) simulation.Account {
+
+	for _, i := range r.Perm(len(accs)) {
+		for _, address := range whitelist {
+			if accs[i].Address.Equals(address) {
+				return accs[i]
+			}
+		}
+	}
+	return simulation.RandomAcc(r, accs)
+}
+
+// randomGoodTillBlock returns zero (i.e. the order is not good-till-block) or
+// a height in the next few blocks, so that orders that cannot be fulfilled
+// straight away sometimes rest
+func randomGoodTillBlock(r *rand.Rand, ctx sdk.Context) int64 {
+	if r.Intn(4) != 0 {
+		return 0
+	}
+	return ctx.BlockHeight() + int64(r.Intn(10))
+}
@@ -0,0 +1,150 @@
+package simulation
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/ixofoundation/ixo-cosmos/x/bonds"
+	"github.com/ixofoundation/ixo-cosmos/x/did"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+var (
+	seed      int64
+	numBlocks int
+	blockSize int
+	verbose   bool
+)
+
+func init() {
+	flag.Int64Var(&seed, "BondsSimulationSeed", 42, "simulation random seed")
+	flag.IntVar(&numBlocks, "BondsSimulationNumBlocks", 50, "number of blocks")
+	flag.IntVar(&blockSize, "BondsSimulationBlockSize", 30, "operations per block")
+	flag.BoolVar(&verbose, "BondsSimulationVerbose", false, "verbose log output")
+}
+
+// getMockApp returns a mock app with the modules that the bonds module
+// depends on, with the bonds keeper's end blocker run at the end of each block
+func getMockApp(t *testing.T) (*mock.App, bonds.Keeper, supply.Keeper) {
+	mapp := mock.NewApp()
+
+	bonds.RegisterCodec(mapp.Cdc)
+	supply.RegisterCodec(mapp.Cdc)
+
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyDid := sdk.NewKVStoreKey(did.StoreKey)
+	keyBonds := sdk.NewKVStoreKey(bonds.StoreKey)
+
+	maccPerms := map[string][]string{
+		staking.BondedPoolName:           {supply.Burner, supply.Staking},
+		staking.NotBondedPoolName:        {supply.Burner, supply.Staking},
+		bonds.BondsMintBurnAccount:       {supply.Minter, supply.Burner},
+		bonds.BatchesIntermediaryAccount: nil,
+		bonds.BondsReserveAccount:        nil,
+		bonds.BondsFeesAccount:           nil,
+		bonds.BondsVestingAccount:        nil,
+		bonds.BondsFundingPoolAccount:    nil,
+	}
+
+	bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper,
+		mapp.ParamsKeeper.Subspace(bank.DefaultParamspace),
+		bank.DefaultCodespace, map[string]bool{})
+	supplyKeeper := supply.NewKeeper(mapp.Cdc, keySupply, mapp.AccountKeeper,
+		bankKeeper, maccPerms)
+	stakingKeeper := staking.NewKeeper(mapp.Cdc, keyStaking, tkeyStaking,
+		supplyKeeper, mapp.ParamsKeeper.Subspace(staking.DefaultParamspace),
+		staking.DefaultCodespace)
+	didKeeper := did.NewKeeper(mapp.Cdc, keyDid)
+	keeper := bonds.NewKeeper(bankKeeper, supplyKeeper, mapp.AccountKeeper,
+		stakingKeeper, didKeeper, keyBonds, mapp.Cdc)
+
+	mapp.Router().AddRoute(bonds.RouterKey, bonds.NewHandler(keeper))
+	mapp.SetEndBlocker(func(ctx sdk.Context, _ abci.RequestEndBlock) abci.ResponseEndBlock {
+		return abci.ResponseEndBlock{ValidatorUpdates: bonds.EndBlocker(ctx, keeper)}
+	})
+	mapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		res := mapp.InitChainer(ctx, req)
+		supply.InitGenesis(ctx, supplyKeeper, mapp.AccountKeeper, supply.DefaultGenesisState())
+		stakingKeeper.SetParams(ctx, staking.DefaultParams())
+
+		var genesisState bonds.GenesisState
+		bonds.ModuleCdc.MustUnmarshalJSON(req.AppStateBytes, &genesisState)
+		require.NoError(t, bonds.ValidateGenesis(genesisState))
+		bonds.InitGenesis(ctx, keeper, genesisState)
+
+		// The simulation needs at least one validator to propose blocks
+		res.Validators = []abci.ValidatorUpdate{{
+			PubKey: tmtypes.TM2PB.PubKey(ed25519.GenPrivKeyFromSecret([]byte(bonds.ModuleName)).PubKey()),
+			Power:  1,
+		}}
+		return res
+	})
+
+	require.NoError(t, mapp.CompleteSetup(keyStaking, tkeyStaking, keySupply, keyDid, keyBonds))
+	return mapp, keeper, supplyKeeper
+}
+
+// appStateFn gives each of the simulation's accounts a random balance of each
+// reserve token and generates a random bonds genesis state
+func appStateFn(mapp *mock.App) simulation.AppStateFn {
+	return func(r *rand.Rand, accs []simulation.Account) (
+		json.RawMessage, []simulation.Account, string, time.Time) {
+
+		var genAccs []auth.Account
+		for _, acc := range accs {
+			var coins sdk.Coins
+			for _, rt := range ReserveTokens {
+				amount := int64(simulation.RandIntBetween(r, 1000000, 1000000000))
+				coins = append(coins, sdk.NewInt64Coin(rt, amount))
+			}
+			genAccs = append(genAccs, &auth.BaseAccount{
+				Address: acc.Address,
+				Coins:   coins.Sort(),
+			})
+		}
+		mapp.GenesisAccounts = genAccs
+
+		appState := bonds.ModuleCdc.MustMarshalJSON(RandomizedGenState(r, accs))
+		return appState, accs, "bonds-simulation", simulation.RandTimestamp(r)
+	}
+}
+
+func TestBondsSimulation(t *testing.T) {
+	mapp, keeper, supplyKeeper := getMockApp(t)
+
+	var w io.Writer = ioutil.Discard
+	if verbose {
+		w = os.Stdout
+	}
+
+	invariants := []sdk.Invariant{
+		bonds.AllInvariants(keeper),
+		supply.AllInvariants(supplyKeeper),
+	}
+
+	_, _, err := simulation.SimulateFromSeed(
+		t, w, mapp.BaseApp, appStateFn(mapp), seed,
+		WeightedOperations(keeper), invariants,
+		1, numBlocks, 0, blockSize, "",
+		false, true, false, false, false, nil,
+	)
+	require.NoError(t, err)
+}
@@ -15,8 +15,13 @@ const (
 )
 
 type (
-	Keeper       = keeper.Keeper
-	GenesisState = types.GenesisState
+	Keeper        = keeper.Keeper
+	GenesisState  = types.GenesisState
+	BaseDidDoc    = types.BaseDidDoc
+	DidCredential = types.DidCredential
+	Claim         = types.Claim
+	
+	LinkAccountMsg = types.LinkAccountMsg
 )
 
 var (
@@ -28,5 +33,9 @@ var (
 	DefaultGenesisState = types.DefaultGenesisState
 	ValidateGenesis     = types.ValidateGenesis
 	
-	ErrorInvalidDid = types.ErrorInvalidDid
+	NewLinkAccountMsg       = types.NewLinkAccountMsg
+	GetAccountLinkSignBytes = types.GetAccountLinkSignBytes
+	
+	ErrorInvalidDid         = types.ErrorInvalidDid
+	ErrorInvalidAccountLink = types.ErrorInvalidAccountLink
 )
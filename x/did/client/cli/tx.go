@@ -11,6 +11,10 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"golang.org/x/crypto/ed25519"
 	
 	"github.com/ixofoundation/ixo-cosmos/x/did/internal/keeper"
 	"github.com/ixofoundation/ixo-cosmos/x/did/internal/types"
@@ -125,3 +129,37 @@ func AddCredentialCmd(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func LinkAccountCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "linkAccount sovrinDid",
+		Short: "Link the --from account to a did, signing the link with the sovrin did's keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || len(args[0]) == 0 {
+				return errors.New("You must provide the sovrin did document as generated by 'sovrin-did' node package")
+			}
+			
+			sovrinDid := sovrin.SovrinDid{}
+			err := json.Unmarshal([]byte(args[0]), &sovrinDid)
+			if err != nil {
+				return err
+			}
+			
+			ctx := context.NewCLIContext().
+				WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().
+				WithTxEncoder(utils.GetTxEncoder(cdc))
+			
+			privKey := [64]byte{}
+			copy(privKey[:], base58.Decode(sovrinDid.Secret.SignKey))
+			copy(privKey[32:], base58.Decode(sovrinDid.VerifyKey))
+			
+			address := ctx.GetFromAddress()
+			signBytes := types.GetAccountLinkSignBytes(sovrinDid.Did, address)
+			signature := ed25519.Sign(privKey[:], signBytes)
+			
+			msg := types.NewLinkAccountMsg(address, sovrinDid.Did, signature)
+			return utils.GenerateOrBroadcastMsgs(ctx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -14,6 +14,8 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleAddDidDocMsg(ctx, k, msg)
 		case types.AddCredentialMsg:
 			return handleAddCredentialMsg(ctx, k, msg)
+		case types.LinkAccountMsg:
+			return handleLinkAccountMsg(ctx, k, msg)
 		default:
 			return sdk.ErrUnknownRequest("No match for message type.").Result()
 		}
@@ -47,3 +49,20 @@ func handleAddCredentialMsg(ctx sdk.Context, k keeper.Keeper, msg types.AddCrede
 		Code: sdk.CodeOK,
 	}
 }
+
+func handleLinkAccountMsg(ctx sdk.Context, k keeper.Keeper, msg types.LinkAccountMsg) sdk.Result {
+	didDoc, err := k.GetDidDoc(ctx, msg.Did)
+	if err != nil {
+		return err.Result()
+	}
+	
+	if !msg.VerifyDidSignature(didDoc) {
+		return types.ErrorInvalidAccountLink(types.DefaultCodeSpace, "did signature verification failed").Result()
+	}
+	
+	k.SetAccountDid(ctx, msg.Address, msg.Did)
+	
+	return sdk.Result{
+		Code: sdk.CodeOK,
+	}
+}
@@ -71,6 +71,42 @@ func (k Keeper) AddCredentials(ctx sdk.Context, did ixo.Did, credential types.Di
 	return nil
 }
 
+// GetAccountDid returns the DID that the account address is linked to
+func (k Keeper) GetAccountDid(ctx sdk.Context, address sdk.AccAddress) (did ixo.Did, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAccountDidPrefixKey(address))
+	if bz == nil {
+		return "", false
+	}
+	
+	return ixo.Did(bz), true
+}
+
+// GetDidAccount returns the account address that was last linked to the DID
+func (k Keeper) GetDidAccount(ctx sdk.Context, did ixo.Did) (address sdk.AccAddress, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDidAccountPrefixKey(did))
+	if bz == nil {
+		return nil, false
+	}
+	
+	return sdk.AccAddress(bz), true
+}
+
+// SetAccountDid links the account address to the DID, and the DID to the
+// account address, unlinking the DID that the address was linked to before
+func (k Keeper) SetAccountDid(ctx sdk.Context, address sdk.AccAddress, did ixo.Did) {
+	store := ctx.KVStore(k.storeKey)
+	if oldDid, found := k.GetAccountDid(ctx, address); found {
+		if oldAddress, _ := k.GetDidAccount(ctx, oldDid); oldAddress.Equals(address) {
+			store.Delete(types.GetDidAccountPrefixKey(oldDid))
+		}
+	}
+	
+	store.Set(types.GetAccountDidPrefixKey(address), []byte(did))
+	store.Set(types.GetDidAccountPrefixKey(did), address.Bytes())
+}
+
 func (k Keeper) GetAllDidDocs(ctx sdk.Context) (didDocs []ixo.DidDoc) {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.DidKey)
@@ -3,6 +3,7 @@ package keeper
 import (
 	"testing"
 	
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	
 	"github.com/ixofoundation/ixo-cosmos/x/did/internal/types"
@@ -21,3 +22,27 @@ func TestKeeper(t *testing.T) {
 	_, err = k.GetDidDoc(ctx, types.ValidDidDoc.GetDid())
 	require.Nil(t, err)
 }
+
+func TestKeeper_AccountDid(t *testing.T) {
+	ctx, k, _ := CreateTestInput()
+	address := sdk.AccAddress([]byte("address"))
+	
+	_, found := k.GetAccountDid(ctx, address)
+	require.False(t, found)
+	
+	k.SetAccountDid(ctx, address, types.ValidDidDoc.GetDid())
+	did, found := k.GetAccountDid(ctx, address)
+	require.True(t, found)
+	require.Equal(t, types.ValidDidDoc.GetDid(), did)
+	didAddress, found := k.GetDidAccount(ctx, types.ValidDidDoc.GetDid())
+	require.True(t, found)
+	require.Equal(t, address, didAddress)
+	
+	// Linking the address to another DID unlinks the first DID
+	k.SetAccountDid(ctx, address, "otherDid")
+	_, found = k.GetDidAccount(ctx, types.ValidDidDoc.GetDid())
+	require.False(t, found)
+	didAddress, found = k.GetDidAccount(ctx, "otherDid")
+	require.True(t, found)
+	require.Equal(t, address, didAddress)
+}
@@ -9,6 +9,7 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(AddDidMsg{}, "did/AddDid", nil)
 	cdc.RegisterConcrete(AddCredentialMsg{}, "did/AddCredential", nil)
+	cdc.RegisterConcrete(LinkAccountMsg{}, "did/LinkAccount", nil)
 	cdc.RegisterInterface((*ixo.DidDoc)(nil), nil)
 	
 }
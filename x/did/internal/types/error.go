@@ -10,6 +10,7 @@ const (
 	CodeInvalidPubKey                        = 202
 	CodeInvalidIssuer                        = 203
 	CodeInvalidCredentials                   = 204
+	CodeInvalidAccountLink                   = 205
 )
 
 func ErrorInvalidDid(codeSpace sdk.CodespaceType, msg string) sdk.Error {
@@ -43,3 +44,11 @@ func ErrorInvalidCredentials(codeSpace sdk.CodespaceType, msg string) sdk.Error
 	
 	return sdk.NewError(codeSpace, CodeInvalidCredentials, "Data already exist")
 }
+
+func ErrorInvalidAccountLink(codeSpace sdk.CodespaceType, msg string) sdk.Error {
+	if msg != "" {
+		return sdk.NewError(codeSpace, CodeInvalidAccountLink, msg)
+	}
+	
+	return sdk.NewError(codeSpace, CodeInvalidAccountLink, "Invalid account link")
+}
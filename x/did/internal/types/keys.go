@@ -1,6 +1,8 @@
 package types
 
 import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	
 	"github.com/ixofoundation/ixo-cosmos/x/ixo"
 )
 
@@ -11,8 +13,20 @@ const (
 	QuerierRoute = RouterKey
 )
 
-var DidKey = []byte{0x01}
+var (
+	DidKey        = []byte{0x01}
+	AccountDidKey = []byte{0x02}
+	DidAccountKey = []byte{0x03}
+)
 
 func GetDidPrefixKey(did ixo.Did) []byte {
 	return append(DidKey, []byte(did)...)
 }
+
+func GetAccountDidPrefixKey(address sdk.AccAddress) []byte {
+	return append(AccountDidKey, address.Bytes()...)
+}
+
+func GetDidAccountPrefixKey(did ixo.Did) []byte {
+	return append(DidAccountKey, []byte(did)...)
+}
@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	
+	"github.com/btcsuite/btcutil/base58"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"golang.org/x/crypto/ed25519"
+	
+	"github.com/ixofoundation/ixo-cosmos/x/ixo"
 )
 
 type AddDidMsg struct {
@@ -115,3 +119,70 @@ func (msg AddCredentialMsg) GetSignBytes() []byte {
 }
 
 func (msg AddCredentialMsg) IsNewDid() bool { return false }
+
+// LinkAccountMsg links an account address to a DID, so that modules can look
+// up the DID of an address (e.g. to check its credentials) and the account of
+// a DID (e.g. to fund projects from it with messages signed by the DID). It is
+// signed by the account as a standard Cosmos transaction, and carries a
+// signature by the DID's key over GetAccountLinkSignBytes, so that both
+// consent to the link.
+type LinkAccountMsg struct {
+	Address      sdk.AccAddress `json:"address"`
+	Did          ixo.Did        `json:"did"`
+	DidSignature []byte         `json:"didSignature"`
+}
+
+func NewLinkAccountMsg(address sdk.AccAddress, did ixo.Did, didSignature []byte) LinkAccountMsg {
+	return LinkAccountMsg{
+		Address:      address,
+		Did:          did,
+		DidSignature: didSignature,
+	}
+}
+
+var _ sdk.Msg = LinkAccountMsg{}
+
+// Not of type "did", since it is not signed by the DID as an ixo transaction
+func (msg LinkAccountMsg) Type() string  { return "link_account" }
+func (msg LinkAccountMsg) Route() string { return RouterKey }
+func (msg LinkAccountMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+func (msg LinkAccountMsg) String() string {
+	return fmt.Sprintf("LinkAccountMsg{Address: %v, Did: %v}", msg.Address.String(), string(msg.Did))
+}
+
+func (msg LinkAccountMsg) ValidateBasic() sdk.Error {
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress("address should not be empty")
+	} else if msg.Did == "" {
+		return ErrorInvalidDid(DefaultCodeSpace, "did should not be empty")
+	} else if len(msg.DidSignature) != ed25519.SignatureSize {
+		return ErrorInvalidAccountLink(DefaultCodeSpace, "invalid did signature length")
+	}
+	
+	return nil
+}
+
+func (msg LinkAccountMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// VerifyDidSignature returns whether the DID signature was produced by the
+// key of the DID doc
+func (msg LinkAccountMsg) VerifyDidSignature(didDoc ixo.DidDoc) bool {
+	pubKey := base58.Decode(didDoc.GetPubKey())
+	if len(pubKey) != ed25519.PublicKeySize {
+		return false
+	}
+	
+	signBytes := GetAccountLinkSignBytes(msg.Did, msg.Address)
+	return ed25519.Verify(pubKey, signBytes, msg.DidSignature)
+}
+
+// GetAccountLinkSignBytes returns the bytes that a DID signs to consent to
+// being linked to the account address
+func GetAccountLinkSignBytes(did ixo.Did, address sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("link_account|%s|%X", did, address.Bytes()))
+}
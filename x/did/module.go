@@ -60,6 +60,7 @@ func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	didTxCmd.AddCommand(client.PostCommands(
 		cli.AddDidDocCmd(cdc),
 		cli.AddCredentialCmd(cdc),
+		cli.LinkAccountCmd(cdc),
 	)...)
 	
 	return didTxCmd
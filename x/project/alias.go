@@ -14,6 +14,27 @@ const (
 	DefaultCodeSpace = types.DefaultCodeSpace
 	PaidoutStatus    = types.PaidoutStatus
 	FundedStatus     = types.FundedStatus
+	StartedStatus    = types.StartedStatus
+	StoppedStatus    = types.StoppedStatus
+	CreatedProject   = types.CreatedProject
+	PendingStatus    = types.PendingStatus
+	
+	EventTypeUpdateProjectStatus        = types.EventTypeUpdateProjectStatus
+	AttributeKeyProjectDid              = types.AttributeKeyProjectDid
+	AttributeKeyStatus                  = types.AttributeKeyStatus
+	AttributeKeyReason                  = types.AttributeKeyReason
+	AttributeValueRequiredClaimsReached = types.AttributeValueRequiredClaimsReached
+	AttributeValueEndDatePassed         = types.AttributeValueEndDatePassed
+	
+	ServiceAgentRole   = types.ServiceAgentRole
+	EvaluatorAgentRole = types.EvaluatorAgentRole
+	InvestorAgentRole  = types.InvestorAgentRole
+	PendingAgent       = types.PendingAgent
+	ApprovedAgent      = types.ApprovedAgent
+	RevokedAgent       = types.RevokedAgent
+	PendingClaim       = types.PendingClaim
+	ApprovedClaim      = types.ApprovedClaim
+	RejectedClaim      = types.RejectedClaim
 )
 
 type (
@@ -25,12 +46,39 @@ type (
 	CreateClaimMsg         = types.CreateClaimMsg
 	CreateEvaluationMsg    = types.CreateEvaluationMsg
 	WithdrawFundsMsg       = types.WithdrawFundsMsg
+	FundProjectMsg         = types.FundProjectMsg
+	FundProjectDoc         = types.FundProjectDoc
+	Contribution           = types.Contribution
 	StoredProjectDoc       = types.StoredProjectDoc
+	ProjectStatus          = types.ProjectStatus
 	WithdrawalInfo         = types.WithdrawalInfo
 	AccountMap             = types.AccountMap
+	Agent                  = types.Agent
+	GenesisState           = types.GenesisState
+	GenesisAccount         = types.GenesisAccount
+	GenesisAccountMap      = types.GenesisAccountMap
+	GenesisWithdrawals     = types.GenesisWithdrawals
+	GenesisProjectAgents   = types.GenesisProjectAgents
+	GenesisProjectClaims   = types.GenesisProjectClaims
+	Claim                  = types.Claim
+	ClaimCounts            = types.ClaimCounts
+	ProjectClaims          = types.ProjectClaims
+	
+	GenesisProjectContributions = types.GenesisProjectContributions
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
-	ModuleCdc = types.ModuleCdc
+	NewKeeper        = keeper.NewKeeper
+	NewAgent         = types.NewAgent
+	IsValidAgentRole = types.IsValidAgentRole
+	NewClaim         = types.NewClaim
+	ModuleCdc        = types.ModuleCdc
+	
+	NewGenesisState     = types.NewGenesisState
+	DefaultGenesisState = types.DefaultGenesisState
+	ValidateGenesis     = types.ValidateGenesis
+	
+	AccountKey        = types.AccountKey
+	WithdrawalKey     = types.WithdrawalKey
+	SplitStopQueueKey = types.SplitStopQueueKey
 )
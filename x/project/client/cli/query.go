@@ -133,3 +133,119 @@ func GetProjectTxsCmd(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func GetProjectAgentsCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "getProjectAgents projectDid",
+		Short: "Get the agents of a Project for a projectDid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCLIContext().
+				WithCodec(cdc)
+			
+			if len(args) != 1 || len(args[0]) == 0 {
+				return errors.New("You must provide a project did")
+			}
+			projectDid := args[0]
+			
+			res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryProjectAgents, projectDid), nil)
+			if err != nil {
+				return err
+			}
+			
+			agents := []types.Agent{}
+			err = cdc.UnmarshalJSON(res, &agents)
+			if err != nil {
+				return err
+			}
+			
+			output, err := json.MarshalIndent(agents, "", "  ")
+			if err != nil {
+				return err
+			}
+			
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetProjectClaimsCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "getProjectClaims projectDid [status]",
+		Short: "Get the claims of a Project for a projectDid, optionally filtered by status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCLIContext().
+				WithCodec(cdc)
+			
+			if len(args) < 1 || len(args) > 2 || len(args[0]) == 0 {
+				return errors.New("You must provide a project did")
+			}
+			
+			route := fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryProjectClaims, args[0])
+			if len(args) == 2 {
+				status := types.ClaimStatus(args[1])
+				if !status.IsValid() {
+					return errors.New("The status must be one of '0' (Pending), '1' (Approved) or '2' (Rejected)")
+				}
+				route = fmt.Sprintf("%s/%s", route, status)
+			}
+			
+			res, _, err := ctx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			
+			var claims types.ProjectClaims
+			err = cdc.UnmarshalJSON(res, &claims)
+			if err != nil {
+				return err
+			}
+			
+			output, err := json.MarshalIndent(claims, "", "  ")
+			if err != nil {
+				return err
+			}
+			
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func GetProjectContributionsCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "getProjectContributions projectDid",
+		Short: "Get the funder contributions of a Project for a projectDid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.NewCLIContext().
+				WithCodec(cdc)
+			
+			if len(args) != 1 || len(args[0]) == 0 {
+				return errors.New("You must provide a project did")
+			}
+			projectDid := args[0]
+			
+			res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute,
+				keeper.QueryContributions, projectDid), nil)
+			if err != nil {
+				return err
+			}
+			
+			contributions := []types.Contribution{}
+			err = cdc.UnmarshalJSON(res, &contributions)
+			if err != nil {
+				return err
+			}
+			
+			output, err := json.MarshalIndent(contributions, "", "  ")
+			if err != nil {
+				return err
+			}
+			
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
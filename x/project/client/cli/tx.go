@@ -8,6 +8,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	
@@ -275,3 +277,34 @@ func WithDrawFundsCmd(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func FundProjectCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fundProject projectDid amount",
+		Short: "Fund a project with IXO from the --from account, which must be linked to a did.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+				return errors.New("You must provide the project did and amount.")
+			}
+			
+			amount, ok := sdk.NewIntFromString(args[1])
+			if !ok {
+				return errors.New("Amount must be an integer.")
+			}
+			
+			ctx := context.NewCLIContext().
+				WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().
+				WithTxEncoder(utils.GetTxEncoder(cdc))
+			
+			data := types.FundProjectDoc{ProjectDid: args[0], Amount: amount}
+			msg := types.NewFundProjectMsg(ctx.GetFromAddress(), data)
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			
+			return utils.GenerateOrBroadcastMsgs(ctx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
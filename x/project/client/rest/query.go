@@ -24,6 +24,9 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/project/{did}", queryProjectDocRequestHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/projectAccounts/{projectDid}", queryProjectAccountsRequestHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/projectTxs/{projectDid}", queryProjectTxsRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/projectAgents/{projectDid}", queryProjectAgentsRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/projectClaims/{projectDid}", queryProjectClaimsRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/projectContributions/{projectDid}", queryProjectContributionsRequestHandler(cliCtx)).Methods("GET")
 }
 
 func queryProjectDocRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -122,3 +125,104 @@ func queryProjectTxsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 	}
 	
 }
+
+func queryProjectAgentsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		projectDid := vars["projectDid"]
+		
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s",
+			types.QuerierRoute, keeper.QueryProjectAgents, projectDid), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query did. Error: %s", err.Error())))
+			
+			return
+		}
+		
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			
+			return
+		}
+		
+		agents := []types.Agent{}
+		cliCtx.Codec.MustUnmarshalJSON(res, &agents)
+		
+		bz, err := json.Marshal(agents)
+		_, _ = w.Write(bz)
+	}
+	
+}
+
+func queryProjectClaimsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		projectDid := vars["projectDid"]
+		
+		route := fmt.Sprintf("custom/%s/%s/%s", types.QuerierRoute, keeper.QueryProjectClaims, projectDid)
+		status := r.URL.Query().Get("status")
+		if status != "" {
+			if !types.ClaimStatus(status).IsValid() {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("The status must be one of '0' (Pending), '1' (Approved) or '2' (Rejected)"))
+				
+				return
+			}
+			route = fmt.Sprintf("%s/%s", route, status)
+		}
+		
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query did. Error: %s", err.Error())))
+			
+			return
+		}
+		
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			
+			return
+		}
+		
+		var claims types.ProjectClaims
+		cliCtx.Codec.MustUnmarshalJSON(res, &claims)
+		
+		bz, err := json.Marshal(claims)
+		_, _ = w.Write(bz)
+	}
+	
+}
+
+func queryProjectContributionsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		vars := mux.Vars(r)
+		projectDid := vars["projectDid"]
+		
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s",
+			types.QuerierRoute, keeper.QueryContributions, projectDid), nil)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(fmt.Sprintf("Could't query did. Error: %s", err.Error())))
+			
+			return
+		}
+		
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			
+			return
+		}
+		
+		contributions := []types.Contribution{}
+		cliCtx.Codec.MustUnmarshalJSON(res, &contributions)
+		
+		bz, err := json.Marshal(contributions)
+		_, _ = w.Write(bz)
+	}
+	
+}
@@ -7,7 +7,9 @@ import (
 	
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/gorilla/mux"
 	
 	"github.com/ixofoundation/ixo-cosmos/x/ixo"
@@ -22,6 +24,7 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/createClaim", CreateClaimRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/createEvaluation", CreateEvaluationRequestHandler(cliCtx)).Methods("POST")
 	r.HandleFunc("/withdrawFunds", WithDrawFundsRequestHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/fundProject", FundProjectRequestHandler(cliCtx)).Methods("POST")
 }
 
 func createProjectRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -456,3 +459,45 @@ func WithDrawFundsRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, output)
 	}
 }
+
+type fundProjectReq struct {
+	BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
+	ProjectDid string       `json:"project_did" yaml:"project_did"`
+	Amount     string       `json:"amount" yaml:"amount"`
+}
+
+func FundProjectRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req fundProjectReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+		
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+		
+		funder, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		
+		amount, ok := sdk.NewIntFromString(req.Amount)
+		if !ok {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "amount must be an integer")
+			return
+		}
+		
+		data := types.FundProjectDoc{ProjectDid: req.ProjectDid, Amount: amount}
+		msg := types.NewFundProjectMsg(funder, data)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		
+		utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+	}
+}
@@ -14,6 +14,7 @@ func Registercodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(types.CreateClaimMsg{}, "project/CreateClaim", nil)
 	cdc.RegisterConcrete(types.CreateEvaluationMsg{}, "project/CreateEvaluation", nil)
 	cdc.RegisterConcrete(types.WithdrawFundsMsg{}, "project/WithdrawFunds", nil)
+	cdc.RegisterConcrete(types.FundProjectMsg{}, "project/FundProject", nil)
 }
 
 var moduleCdc = codec.New()
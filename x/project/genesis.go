@@ -0,0 +1,126 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	
+	"github.com/ixofoundation/ixo-cosmos/x/ixo"
+)
+
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	// Initialise project docs
+	for _, p := range data.ProjectDocs {
+		projectDoc := p
+		k.AddProjectDoc(ctx, &projectDoc)
+	}
+	
+	// Initialise account maps, checking that the accounts exist in the auth
+	// module, which is initialised first
+	for _, m := range data.AccountMaps {
+		accountMap := make(AccountMap)
+		for _, a := range m.Accounts {
+			if !k.AccountExists(ctx, sdk.AccAddress(a.Address)) {
+				panic(fmt.Sprintf("account %s of project %s does not exist", a.ID, m.ProjectDid))
+			}
+			accountMap[a.ID] = a.Address
+		}
+		k.SetAccountMap(ctx, m.ProjectDid, accountMap)
+	}
+	
+	// Initialise withdrawals
+	for _, w := range data.Withdrawals {
+		k.SetProjectWithdrawalTransactions(ctx, w.ProjectDid, w.Withdrawals)
+	}
+	
+	// Initialise agents
+	for _, a := range data.Agents {
+		k.SetProjectAgents(ctx, a.ProjectDid, a.Agents)
+	}
+	
+	// Initialise claims
+	for _, c := range data.Claims {
+		for _, claim := range c.Claims {
+			k.SetClaim(ctx, c.ProjectDid, claim)
+		}
+	}
+	
+	// Initialise contributions
+	for _, c := range data.Contributions {
+		k.SetProjectContributions(ctx, c.ProjectDid, c.Contributions)
+	}
+}
+
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	// Export project docs, together with their agents, claims and contributions
+	var projectDocs []CreateProjectMsg
+	var agents []GenesisProjectAgents
+	var claims []GenesisProjectClaims
+	var contributions []GenesisProjectContributions
+	for _, p := range k.GetAllProjectDocs(ctx) {
+		projectDoc := p.(*CreateProjectMsg)
+		projectDocs = append(projectDocs, *projectDoc)
+		
+		projectAgents := k.GetProjectAgents(ctx, projectDoc.ProjectDid)
+		if len(projectAgents) > 0 {
+			agents = append(agents, GenesisProjectAgents{
+				ProjectDid: projectDoc.ProjectDid,
+				Agents:     projectAgents,
+			})
+		}
+		
+		projectClaims := k.GetProjectClaims(ctx, projectDoc.ProjectDid)
+		if len(projectClaims) > 0 {
+			claims = append(claims, GenesisProjectClaims{
+				ProjectDid: projectDoc.ProjectDid,
+				Claims:     projectClaims,
+			})
+		}
+		
+		projectContributions := k.GetProjectContributions(ctx, projectDoc.ProjectDid)
+		if len(projectContributions) > 0 {
+			contributions = append(contributions, GenesisProjectContributions{
+				ProjectDid:    projectDoc.ProjectDid,
+				Contributions: projectContributions,
+			})
+		}
+	}
+	
+	// Export account maps, sorted by account ID so that the export is
+	// deterministic
+	var accountMaps []GenesisAccountMap
+	accIterator := k.GetAccountMapIterator(ctx)
+	for ; accIterator.Valid(); accIterator.Next() {
+		projectDid := ixo.Did(accIterator.Key()[len(AccountKey):])
+		accountMap := k.GetAccountMap(ctx, projectDid)
+		
+		var accounts []GenesisAccount
+		for id, address := range accountMap {
+			accounts = append(accounts, GenesisAccount{ID: id, Address: address.(string)})
+		}
+		sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+		
+		accountMaps = append(accountMaps, GenesisAccountMap{
+			ProjectDid: projectDid,
+			Accounts:   accounts,
+		})
+	}
+	accIterator.Close()
+	
+	// Export withdrawals
+	var withdrawals []GenesisWithdrawals
+	wIterator := k.GetWithdrawalsIterator(ctx)
+	for ; wIterator.Valid(); wIterator.Next() {
+		projectDid := ixo.Did(wIterator.Key()[len(WithdrawalKey):])
+		txs, _ := k.GetProjectWithdrawalTransactions(ctx, projectDid)
+		
+		withdrawals = append(withdrawals, GenesisWithdrawals{
+			ProjectDid:  projectDid,
+			Withdrawals: txs,
+		})
+	}
+	wIterator.Close()
+	
+	return NewGenesisState(projectDocs, accountMaps, withdrawals, agents, claims, contributions)
+}
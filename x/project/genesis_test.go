@@ -0,0 +1,74 @@
+package project
+
+import (
+	"testing"
+	
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/stretchr/testify/require"
+	
+	"github.com/ixofoundation/ixo-cosmos/x/project/internal/keeper"
+	"github.com/ixofoundation/ixo-cosmos/x/project/internal/types"
+)
+
+func TestGenesis(t *testing.T) {
+	ctx, k, cdc, _, _, _, _ := keeper.CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*exported.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
+	
+	projectDid := types.ValidCreateProjectMsg.ProjectDid
+	res := handleCreateProjectMsg(ctx, k, nil, types.ValidCreateProjectMsg)
+	require.True(t, res.IsOK())
+	
+	k.AddProjectWithdrawalTransaction(ctx, projectDid, types.ValidWithdrawalInfo)
+	k.SetProjectAgent(ctx, projectDid, types.ValidAgent)
+	k.SetClaim(ctx, projectDid, types.ValidClaim)
+	k.AddProjectContribution(ctx, projectDid, "FunderDid", sdk.NewInt(100))
+	
+	genesisState := ExportGenesis(ctx, k)
+	require.Nil(t, ValidateGenesis(genesisState))
+	require.Equal(t, []CreateProjectMsg{types.ValidCreateProjectMsg}, genesisState.ProjectDocs)
+	require.Equal(t, 1, len(genesisState.AccountMaps))
+	require.Equal(t, 2, len(genesisState.AccountMaps[0].Accounts))
+	require.Equal(t, []GenesisWithdrawals{{ProjectDid: projectDid, Withdrawals: []WithdrawalInfo{types.ValidWithdrawalInfo}}}, genesisState.Withdrawals)
+	require.Equal(t, []GenesisProjectAgents{{ProjectDid: projectDid, Agents: []Agent{types.ValidAgent}}}, genesisState.Agents)
+	require.Equal(t, []GenesisProjectClaims{{ProjectDid: projectDid, Claims: []Claim{types.ValidClaim}}}, genesisState.Claims)
+	require.Equal(t, 1, len(genesisState.Contributions))
+	
+	// Project accounts have to exist in the auth module
+	newCtx, newK, newCdc, _, _, _, _ := keeper.CreateTestInput()
+	codec.RegisterCrypto(newCdc)
+	newCdc.RegisterInterface((*exported.Account)(nil), nil)
+	newCdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
+	require.Panics(t, func() { InitGenesis(newCtx, newK, genesisState) })
+	
+	for _, a := range genesisState.AccountMaps[0].Accounts {
+		_, err := newK.CreateNewAccount(newCtx, projectDid, a.ID)
+		require.Nil(t, err)
+	}
+	InitGenesis(newCtx, newK, genesisState)
+	require.Equal(t, genesisState, ExportGenesis(newCtx, newK))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+	
+	invalidStatusProject := types.ValidCreateProjectMsg
+	invalidStatusProject.Data.Status = "INVALID"
+	
+	invalidGenesisStates := []GenesisState{
+		NewGenesisState([]CreateProjectMsg{types.ValidCreateProjectMsg, types.ValidCreateProjectMsg}, nil, nil, nil, nil, nil),
+		NewGenesisState([]CreateProjectMsg{invalidStatusProject}, nil, nil, nil, nil, nil),
+		NewGenesisState(nil, []GenesisAccountMap{{ProjectDid: "ProjectDid", Accounts: []GenesisAccount{{ID: "", Address: "address"}}}}, nil, nil, nil, nil),
+		NewGenesisState(nil, nil, nil, []GenesisProjectAgents{{ProjectDid: "ProjectDid", Agents: []Agent{types.ValidAgent}}}, nil, nil),
+		NewGenesisState(nil, nil, nil, nil, []GenesisProjectClaims{{ProjectDid: "ProjectDid", Claims: []Claim{types.ValidClaim}}}, nil),
+		NewGenesisState([]CreateProjectMsg{types.ValidCreateProjectMsg}, nil, nil, nil, nil,
+			[]GenesisProjectContributions{{ProjectDid: types.ValidCreateProjectMsg.ProjectDid, Contributions: []Contribution{{FunderDid: "FunderDid", Amount: sdk.ZeroInt()}}}}),
+	}
+	for _, genesisState := range invalidGenesisStates {
+		require.NotNil(t, ValidateGenesis(genesisState))
+	}
+}
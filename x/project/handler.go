@@ -6,6 +6,7 @@ import (
 	
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
 	
 	"github.com/ixofoundation/ixo-cosmos/x/contracts"
 	"github.com/ixofoundation/ixo-cosmos/x/fees"
@@ -42,12 +43,57 @@ func NewHandler(k Keeper, fk fees.Keeper, ck contracts.Keeper, bk bank.Keeper, p
 			return handleCreateEvaluationMsg(ctx, k, fk, bk, msg)
 		case WithdrawFundsMsg:
 			return handleWithdrawFundsMsg(ctx, k, bk, pk, ethClient, msg)
+		case FundProjectMsg:
+			return handleFundProjectMsg(ctx, k, bk, msg)
 		default:
 			return sdk.ErrUnknownRequest("No match for message type.").Result()
 		}
 	}
 }
 
+func EndBlocker(ctx sdk.Context, k Keeper) []abciTypes.ValidatorUpdate {
+	// Only the projects queued to be checked by now are checked, which are the
+	// started projects that have reached their end date or required claims
+	var queueKeys [][]byte
+	iterator := k.GetStopQueueIterator(ctx, ctx.BlockHeader().Time)
+	for ; iterator.Valid(); iterator.Next() {
+		queueKeys = append(queueKeys, iterator.Key())
+	}
+	iterator.Close()
+	
+	for _, key := range queueKeys {
+		stopTime, projectDid := SplitStopQueueKey(key)
+		k.RemoveFromStopQueue(ctx, projectDid, stopTime)
+		
+		projectDoc, err := k.GetProjectDoc(ctx, projectDid)
+		if err != nil || projectDoc.GetStatus() != StartedStatus {
+			continue
+		}
+		
+		var reason string
+		endDate, hasEndDate := projectDoc.GetEndDate()
+		if k.HasRequiredClaims(ctx, projectDoc) {
+			reason = AttributeValueRequiredClaimsReached
+		} else if hasEndDate && !ctx.BlockHeader().Time.Before(endDate) {
+			reason = AttributeValueEndDatePassed
+		} else {
+			continue
+		}
+		
+		projectDoc.SetStatus(StoppedStatus)
+		k.AddProjectDoc(ctx, projectDoc)
+		
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			EventTypeUpdateProjectStatus,
+			sdk.NewAttribute(AttributeKeyProjectDid, projectDoc.GetProjectDid()),
+			sdk.NewAttribute(AttributeKeyStatus, string(StoppedStatus)),
+			sdk.NewAttribute(AttributeKeyReason, reason),
+		))
+	}
+	
+	return []abciTypes.ValidatorUpdate{}
+}
+
 func handleCreateProjectMsg(ctx sdk.Context, k Keeper, bk bank.Keeper, msg CreateProjectMsg) sdk.Result {
 	
 	_, err := createAccountInProjectAccounts(ctx, k, msg.GetProjectDid(), IxoAccountFeesId)
@@ -86,13 +132,20 @@ func handleUpdateProjectStatusMsg(ctx sdk.Context, k Keeper, ck contracts.Keeper
 	if newStatus == FundedStatus {
 		ethFundingTxnID := msg.GetEthFundingTxnID()
 		ctx.Logger().Info("Provided ethFundingTxnID: ", ethFundingTxnID)
-		if ethFundingTxnID == "" {
+		if ethFundingTxnID == "" && ExistingProjectDoc.GetFundingTarget() == 0 {
 			ctx.Logger().Error("ETH tx not valid isFundingTx")
 			
 			return sdk.ErrUnknownRequest("Invalid EthFundingTxnID provided").Result()
 		}
 		
-		res := fundIfLegitimateEthereumTx(ctx, k, bk, ethClient, ethFundingTxnID, ExistingProjectDoc)
+		// Projects with a funding target are funded with on-chain IXO, so no
+		// Ethereum funding transaction is needed once the target is reached
+		var res sdk.Result
+		if ethFundingTxnID == "" {
+			res = checkFundingTargetReached(ctx, k, bk, ExistingProjectDoc)
+		} else {
+			res = fundIfLegitimateEthereumTx(ctx, k, bk, ethClient, ethFundingTxnID, ExistingProjectDoc)
+		}
 		if res.Code != sdk.CodeOK {
 			return res
 		}
@@ -176,17 +229,48 @@ func getIxoAmount(ctx sdk.Context, k Keeper, bk bank.Keeper, projectDid ixo.Did,
 }
 
 func handleCreateAgentMsg(ctx sdk.Context, k Keeper, bk bank.Keeper, msg CreateAgentMsg) sdk.Result {
-	_, err := createAccountInProjectAccounts(ctx, k, msg.GetProjectDid(), msg.Data.AgentDid)
+	if !IsValidAgentRole(msg.Data.Role) {
+		return sdk.ErrUnknownRequest("The role must be one of 'SA', 'EA' or 'IA'").Result()
+	}
+	
+	_, err := k.GetProjectDoc(ctx, msg.GetProjectDid())
 	if err != nil {
-		err.Result()
+		return sdk.ErrUnknownRequest("Could not find Project").Result()
+	}
+	
+	_, found := k.GetProjectAgent(ctx, msg.GetProjectDid(), msg.Data.AgentDid)
+	if found {
+		return sdk.ErrUnknownRequest("Agent already exists on the project").Result()
 	}
 	
+	_, err = createAccountInProjectAccounts(ctx, k, msg.GetProjectDid(), msg.Data.AgentDid)
+	if err != nil {
+		return err.Result()
+	}
+	
+	k.SetProjectAgent(ctx, msg.GetProjectDid(), NewAgent(msg.Data.AgentDid, msg.Data.Role))
+	
 	return sdk.Result{
 		Code: sdk.CodeOK,
 	}
 }
 
 func handleUpdateAgentMsg(ctx sdk.Context, k Keeper, bk bank.Keeper, msg UpdateAgentMsg) sdk.Result {
+	agent, found := k.GetProjectAgent(ctx, msg.GetProjectDid(), msg.Data.Did)
+	if !found {
+		return sdk.ErrUnknownRequest("Could not find Agent on the project").Result()
+	}
+	
+	if msg.Data.Role != "" && msg.Data.Role != agent.Role {
+		return sdk.ErrUnknownRequest("Agent role does not match the role it was created with").Result()
+	}
+	
+	if !msg.Data.Status.IsValidProgressionFrom(agent.Status) {
+		return sdk.ErrUnknownRequest("Invalid Agent Status Progression requested").Result()
+	}
+	
+	agent.Status = msg.Data.Status
+	k.SetProjectAgent(ctx, msg.GetProjectDid(), agent)
 	
 	return sdk.Result{
 		Code: sdk.CodeOK,
@@ -194,21 +278,51 @@ func handleUpdateAgentMsg(ctx sdk.Context, k Keeper, bk bank.Keeper, msg UpdateA
 }
 
 func handleCreateClaimMsg(ctx sdk.Context, k Keeper, fk fees.Keeper, bk bank.Keeper, msg CreateClaimMsg) sdk.Result {
+	err := checkApprovedAgent(ctx, k, msg.GetProjectDid(), msg.GetSenderDid(), ServiceAgentRole)
+	if err != nil {
+		return err.Result()
+	}
+	
+	if msg.Data.ClaimID == "" {
+		return sdk.ErrUnknownRequest("ClaimID is empty").Result()
+	}
 	
-	_, err := processFees(ctx, k, fk, bk, fees.FeeClaimTransaction, msg.GetProjectDid())
+	_, found := k.GetClaim(ctx, msg.GetProjectDid(), msg.Data.ClaimID)
+	if found {
+		return sdk.ErrUnknownRequest("Claim already exists").Result()
+	}
+	
+	_, err = processFees(ctx, k, fk, bk, fees.FeeClaimTransaction, msg.GetProjectDid())
 	if err != nil {
 		
 		return err.Result()
-	} else {
-		
-		return sdk.Result{
-			Code: sdk.CodeOK,
-		}
+	}
+	
+	k.SetClaim(ctx, msg.GetProjectDid(), NewClaim(msg.Data.ClaimID, msg.GetSenderDid()))
+	
+	return sdk.Result{
+		Code: sdk.CodeOK,
 	}
 }
 
 func handleCreateEvaluationMsg(ctx sdk.Context, k Keeper, fk fees.Keeper, bk bank.Keeper, msg CreateEvaluationMsg) sdk.Result {
-	_, err := processFees(ctx, k, fk, bk, fees.FeeEvaluationTransaction, msg.GetProjectDid())
+	err := checkApprovedAgent(ctx, k, msg.GetProjectDid(), msg.GetSenderDid(), EvaluatorAgentRole)
+	if err != nil {
+		return err.Result()
+	}
+	
+	if msg.Data.Status != ApprovedClaim && msg.Data.Status != RejectedClaim {
+		return sdk.ErrUnknownRequest("Evaluation status must be one of '1' (Approved) or '2' (Rejected)").Result()
+	}
+	
+	claim, found := k.GetClaim(ctx, msg.GetProjectDid(), msg.Data.ClaimID)
+	if !found {
+		return sdk.ErrUnknownRequest("Could not find Claim").Result()
+	} else if claim.Status != PendingClaim {
+		return sdk.ErrUnknownRequest("Claim has already been evaluated").Result()
+	}
+	
+	_, err = processFees(ctx, k, fk, bk, fees.FeeEvaluationTransaction, msg.GetProjectDid())
 	if err != nil {
 		return err.Result()
 	}
@@ -264,6 +378,10 @@ func handleCreateEvaluationMsg(ctx sdk.Context, k Keeper, fk fees.Keeper, bk ban
 		}
 	}
 	
+	claim.Status = msg.Data.Status
+	claim.EvaluatorDid = msg.GetSenderDid()
+	k.SetClaim(ctx, msg.GetProjectDid(), claim)
+	
 	return sdk.Result{
 		Code: sdk.CodeOK,
 	}
@@ -278,6 +396,12 @@ func handleWithdrawFundsMsg(ctx sdk.Context, k Keeper, bk bank.Keeper, pk params
 		return sdk.ErrUnknownRequest("Could not find Project").Result()
 	}
 	
+	// Projects with a funding target were funded with on-chain IXO, which is
+	// refunded to the funders rather than paid out as ERC20 tokens
+	if withdrawFundsDoc.IsRefund && projectDoc.GetFundingTarget() > 0 {
+		return refundContribution(ctx, k, bk, projectDoc, msg.GetSenderDid())
+	}
+	
 	if projectDoc.GetStatus() != PaidoutStatus {
 		return sdk.ErrUnknownRequest("Project not in PAIDOUT Status").Result()
 	}
@@ -330,6 +454,110 @@ func payoutERC20AndRecon(ctx sdk.Context, k Keeper, bk bank.Keeper, pk params.Ke
 	}
 }
 
+// refundContribution sends the funder their share of what is left in the
+// project's account, in proportion to their contribution to the project, and
+// removes their contribution so that it cannot be refunded twice. Funders can
+// be refunded before the project is funded, or once it has stopped.
+func refundContribution(ctx sdk.Context, k Keeper, bk bank.Keeper, projectDoc StoredProjectDoc,
+	funderDid ixo.Did) sdk.Result {
+	
+	switch projectDoc.GetStatus() {
+	case CreatedProject, PendingStatus, StoppedStatus, PaidoutStatus:
+	default:
+		return sdk.ErrUnknownRequest("Project not in CREATED, PENDING, STOPPED or PAIDOUT Status").Result()
+	}
+	
+	projectDid := projectDoc.GetProjectDid()
+	contributions := k.GetProjectContributions(ctx, projectDid)
+	index := -1
+	totalContributed := sdk.ZeroInt()
+	for i, c := range contributions {
+		if c.FunderDid == funderDid {
+			index = i
+		}
+		totalContributed = totalContributed.Add(c.Amount)
+	}
+	if index < 0 {
+		return sdk.ErrUnknownRequest("No contribution to refund").Result()
+	}
+	
+	funderAddr, found := k.GetFunderAccount(ctx, funderDid)
+	if !found {
+		return sdk.ErrUnauthorized("Funder did is not linked to an account").Result()
+	}
+	
+	projectAddr, err := getAccountInProjectAccounts(ctx, k, projectDid, projectDid)
+	if err != nil {
+		return err.Result()
+	}
+	
+	// Since each refund removes its contribution from the total, every funder
+	// is refunded the same share of the balance regardless of the order of refunds
+	balance := bk.GetCoins(ctx, projectAddr).AmountOf(ixo.IxoNativeToken)
+	refund := balance.Mul(contributions[index].Amount).Quo(totalContributed)
+	if refund.IsPositive() {
+		err = bk.SendCoins(ctx, projectAddr, funderAddr, sdk.Coins{sdk.NewCoin(ixo.IxoNativeToken, refund)})
+		if err != nil {
+			return err.Result()
+		}
+	}
+	
+	contributions = append(contributions[:index], contributions[index+1:]...)
+	k.SetProjectContributions(ctx, projectDid, contributions)
+	
+	return sdk.Result{
+		Code: sdk.CodeOK,
+	}
+}
+
+func checkFundingTargetReached(ctx sdk.Context, k Keeper, bk bank.Keeper, projectDoc StoredProjectDoc) sdk.Result {
+	target := sdk.NewDec(projectDoc.GetFundingTarget()).Mul(ixo.IxoDecimals) // This is in IXO * 10^8
+	balance := getIxoAmount(ctx, k, bk, projectDoc.GetProjectDid(), projectDoc.GetProjectDid())
+	if sdk.NewDec(balance).LT(target) {
+		return sdk.ErrUnknownRequest("Project funding target not reached").Result()
+	}
+	
+	return sdk.Result{
+		Code: sdk.CodeOK,
+	}
+}
+
+func handleFundProjectMsg(ctx sdk.Context, k Keeper, bk bank.Keeper, msg FundProjectMsg) sdk.Result {
+	projectDoc, err := k.GetProjectDoc(ctx, msg.GetProjectDid())
+	if err != nil {
+		return sdk.ErrUnknownRequest("Could not find Project").Result()
+	}
+	
+	status := projectDoc.GetStatus()
+	if status != CreatedProject && status != PendingStatus {
+		return sdk.ErrUnknownRequest("Project not in CREATED or PENDING Status").Result()
+	}
+	
+	projectAddr, err := getAccountInProjectAccounts(ctx, k, msg.GetProjectDid(), msg.GetProjectDid())
+	if err != nil {
+		return err.Result()
+	}
+	
+	// The funder's account signs the message, and the contribution is recorded
+	// for the DID that the account has been linked to (see did.LinkAccountMsg)
+	funderDid, found := k.GetFunderDid(ctx, msg.Funder)
+	if !found {
+		return sdk.ErrUnauthorized("Funder account is not linked to a did").Result()
+	}
+	
+	amount := msg.GetFundProjectDoc().Amount
+	err = bk.SendCoins(ctx, msg.Funder, projectAddr, sdk.Coins{sdk.NewCoin(ixo.IxoNativeToken, amount)})
+	if err != nil {
+		return err.Result()
+	}
+	
+	k.AddProjectContribution(ctx, msg.GetProjectDid(), funderDid, amount)
+	
+	return sdk.Result{
+		Code: sdk.CodeOK,
+	}
+}
+
 func fundIfLegitimateEthereumTx(ctx sdk.Context, k Keeper, bk bank.Keeper, ethClient ixo.EthClient,
 	ethFundingTxnID string, ExistingProjectDoc StoredProjectDoc) sdk.Result {
 	
@@ -425,6 +653,15 @@ func processFees(ctx sdk.Context, k Keeper, fk fees.Keeper, bk bank.Keeper, feeT
 	}, nil
 }
 
+func checkApprovedAgent(ctx sdk.Context, k Keeper, projectDid ixo.Did, agentDid ixo.Did, role string) sdk.Error {
+	agent, found := k.GetProjectAgent(ctx, projectDid, agentDid)
+	if !found || !agent.IsApprovedAs(role) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not an approved %s agent on the project", agentDid, role))
+	}
+	
+	return nil
+}
+
 func checkAccountInProjectAccounts(ctx sdk.Context, k Keeper, projectDid ixo.Did, accountId string) bool {
 	accMap := k.GetAccountMap(ctx, projectDid)
 	_, found := accMap[accountId]
@@ -3,6 +3,7 @@ package project
 import (
 	"encoding/json"
 	"testing"
+	"time"
 	
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -19,7 +20,7 @@ import (
 
 func TestHandler_CreateClaim(t *testing.T) {
 	
-	ctx, keeper, cdc, feesKeeper, bankKeeper, _ := keeper.CreateTestInput()
+	ctx, keeper, cdc, feesKeeper, bankKeeper, _, _ := keeper.CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterConcrete(types.CreateProjectMsg{}, "ixo/createProjectMsg", nil)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
@@ -40,7 +41,7 @@ func TestHandler_CreateClaim(t *testing.T) {
 }
 
 func TestHandler_ProjectMsg(t *testing.T) {
-	ctx, keeper, cdc, _, bankKeeper, _ := keeper.CreateTestInput()
+	ctx, keeper, cdc, _, bankKeeper, _, _ := keeper.CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterConcrete(types.CreateProjectMsg{}, "ixo/createProjectMsg", nil)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
@@ -57,7 +58,7 @@ func TestHandler_ProjectMsg(t *testing.T) {
 	
 }
 func Test_CreateEvaluation(t *testing.T) {
-	ctx, k, cdc, fk, bk, _ := keeper.CreateTestInput()
+	ctx, k, cdc, fk, bk, _, _ := keeper.CreateTestInput()
 	
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterConcrete(types.CreateEvaluationMsg{}, "ixo/createEvaluationMsg", nil)
@@ -109,8 +110,392 @@ func Test_CreateEvaluation(t *testing.T) {
 	require.NotNil(t, res)
 }
 
+func TestHandler_Agents(t *testing.T) {
+	ctx, k, cdc, fk, bk, _, _ := keeper.CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*exported.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
+	
+	fk.SetDec(ctx, fees.KeyIxoFactor, sdk.OneDec())
+	fk.SetDec(ctx, fees.KeyNodeFeePercentage, sdk.NewDec(5).Quo(sdk.NewDec(10)))
+	fk.SetDec(ctx, fees.KeyClaimFeeAmount, sdk.NewDec(6).Quo(sdk.NewDec(10)).Mul(ixo.IxoDecimals))
+	
+	projectDid := types.ValidCreateProjectMsg.ProjectDid
+	createAgentMsg := types.CreateAgentMsg{
+		ProjectDid: projectDid,
+		SenderDid:  projectDid,
+		Data:       types.CreateAgentDoc{AgentDid: "agentDid", Role: ServiceAgentRole},
+	}
+	updateAgentMsg := types.UpdateAgentMsg{
+		ProjectDid: projectDid,
+		SenderDid:  projectDid,
+		Data:       types.UpdateAgentDoc{Did: "agentDid", Status: ApprovedAgent, Role: ServiceAgentRole},
+	}
+	claimMsg := types.CreateClaimMsg{
+		ProjectDid: projectDid,
+		SenderDid:  "agentDid",
+		Data:       types.CreateClaimDoc{ClaimID: "claim1"},
+	}
+	evaluationMsg := types.CreateEvaluationMsg{
+		ProjectDid: projectDid,
+		SenderDid:  "agentDid",
+		Data:       types.CreateEvaluationDoc{ClaimID: "claim1", Status: types.ApprovedClaim},
+	}
+	
+	// Agents can only be created on existing projects
+	res := handleCreateAgentMsg(ctx, k, bk, createAgentMsg)
+	require.False(t, res.IsOK())
+	
+	err := k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
+	require.Nil(t, err)
+	projectAddr, err := createAccountInProjectAccounts(ctx, k, projectDid, projectDid)
+	require.Nil(t, err)
+	_, err = bk.AddCoins(ctx, projectAddr, sdk.NewCoins(sdk.NewInt64Coin(ixo.IxoNativeToken, 100000000)))
+	require.Nil(t, err)
+	
+	invalidRoleMsg := createAgentMsg
+	invalidRoleMsg.Data.Role = "XA"
+	res = handleCreateAgentMsg(ctx, k, bk, invalidRoleMsg)
+	require.False(t, res.IsOK())
+	
+	res = handleCreateAgentMsg(ctx, k, bk, createAgentMsg)
+	require.True(t, res.IsOK())
+	res = handleCreateAgentMsg(ctx, k, bk, createAgentMsg)
+	require.False(t, res.IsOK())
+	
+	// Pending agents cannot submit claims
+	res = handleCreateClaimMsg(ctx, k, fk, bk, claimMsg)
+	require.False(t, res.IsOK())
+	
+	wrongRoleMsg := updateAgentMsg
+	wrongRoleMsg.Data.Role = EvaluatorAgentRole
+	res = handleUpdateAgentMsg(ctx, k, bk, wrongRoleMsg)
+	require.False(t, res.IsOK())
+	
+	res = handleUpdateAgentMsg(ctx, k, bk, updateAgentMsg)
+	require.True(t, res.IsOK())
+	agent, found := k.GetProjectAgent(ctx, projectDid, "agentDid")
+	require.True(t, found)
+	require.Equal(t, ApprovedAgent, agent.Status)
+	
+	// Approved service agents can submit claims but not evaluations
+	res = handleCreateClaimMsg(ctx, k, fk, bk, claimMsg)
+	require.True(t, res.IsOK())
+	res = handleCreateEvaluationMsg(ctx, k, fk, bk, evaluationMsg)
+	require.False(t, res.IsOK())
+	
+	updateAgentMsg.Data.Status = RevokedAgent
+	res = handleUpdateAgentMsg(ctx, k, bk, updateAgentMsg)
+	require.True(t, res.IsOK())
+	
+	// Revoked agents cannot be approved again or submit claims
+	updateAgentMsg.Data.Status = ApprovedAgent
+	res = handleUpdateAgentMsg(ctx, k, bk, updateAgentMsg)
+	require.False(t, res.IsOK())
+	res = handleCreateClaimMsg(ctx, k, fk, bk, claimMsg)
+	require.False(t, res.IsOK())
+}
+
+func TestHandler_Claims(t *testing.T) {
+	ctx, k, cdc, fk, bk, _, _ := keeper.CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*exported.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
+	
+	fk.SetDec(ctx, fees.KeyIxoFactor, sdk.OneDec())
+	fk.SetDec(ctx, fees.KeyNodeFeePercentage, sdk.NewDec(5).Quo(sdk.NewDec(10)))
+	fk.SetDec(ctx, fees.KeyClaimFeeAmount, sdk.NewDec(6).Quo(sdk.NewDec(10)).Mul(ixo.IxoDecimals))
+	fk.SetDec(ctx, fees.KeyEvaluationFeeAmount, sdk.NewDec(4).Quo(sdk.NewDec(10)).Mul(ixo.IxoDecimals))
+	fk.SetDec(ctx, fees.KeyEvaluationPayFeePercentage, sdk.NewDec(1).Quo(sdk.NewDec(10)))
+	fk.SetDec(ctx, fees.KeyEvaluationPayNodeFeePercentage, sdk.NewDec(5).Quo(sdk.NewDec(10)))
+	
+	projectDid := types.ValidCreateProjectMsg.ProjectDid
+	err := k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
+	require.Nil(t, err)
+	projectAddr, err := createAccountInProjectAccounts(ctx, k, projectDid, projectDid)
+	require.Nil(t, err)
+	_, err = bk.AddCoins(ctx, projectAddr, sdk.NewCoins(sdk.NewInt64Coin(ixo.IxoNativeToken, 10000000000)))
+	require.Nil(t, err)
+	
+	k.SetProjectAgent(ctx, projectDid, Agent{Did: "serviceAgentDid", Role: ServiceAgentRole, Status: ApprovedAgent})
+	k.SetProjectAgent(ctx, projectDid, Agent{Did: "evaluatorAgentDid", Role: EvaluatorAgentRole, Status: ApprovedAgent})
+	
+	claimMsg := types.CreateClaimMsg{
+		ProjectDid: projectDid,
+		SenderDid:  "serviceAgentDid",
+		Data:       types.CreateClaimDoc{ClaimID: "claim1"},
+	}
+	evaluationMsg := types.CreateEvaluationMsg{
+		ProjectDid: projectDid,
+		SenderDid:  "evaluatorAgentDid",
+		Data:       types.CreateEvaluationDoc{ClaimID: "claim1", Status: ApprovedClaim},
+	}
+	
+	// Claims cannot be evaluated before they are submitted
+	res := handleCreateEvaluationMsg(ctx, k, fk, bk, evaluationMsg)
+	require.False(t, res.IsOK())
+	
+	res = handleCreateClaimMsg(ctx, k, fk, bk, claimMsg)
+	require.True(t, res.IsOK())
+	res = handleCreateClaimMsg(ctx, k, fk, bk, claimMsg)
+	require.False(t, res.IsOK())
+	
+	pendingEvaluationMsg := evaluationMsg
+	pendingEvaluationMsg.Data.Status = PendingClaim
+	res = handleCreateEvaluationMsg(ctx, k, fk, bk, pendingEvaluationMsg)
+	require.False(t, res.IsOK())
+	
+	res = handleCreateEvaluationMsg(ctx, k, fk, bk, evaluationMsg)
+	require.True(t, res.IsOK())
+	
+	claim, found := k.GetClaim(ctx, projectDid, "claim1")
+	require.True(t, found)
+	require.Equal(t, Claim{ID: "claim1", SenderDid: "serviceAgentDid", Status: ApprovedClaim, EvaluatorDid: "evaluatorAgentDid"}, claim)
+	
+	// Claims can only be evaluated once
+	evaluationMsg.Data.Status = RejectedClaim
+	res = handleCreateEvaluationMsg(ctx, k, fk, bk, evaluationMsg)
+	require.False(t, res.IsOK())
+	
+	counts, err := k.GetProjectClaimCounts(ctx, projectDid)
+	require.Nil(t, err)
+	require.Equal(t, ClaimCounts{Required: 3, Approved: 1}, counts)
+}
+
+func TestEndBlocker(t *testing.T) {
+	ctx, k, _, _, _, _, _ := keeper.CreateTestInput()
+	ctx = ctx.WithBlockTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	
+	claimsProject := types.ValidCreateProjectMsg
+	claimsProject.ProjectDid = "claimsProjectDid"
+	claimsProject.Data.Status = StartedStatus
+	
+	endDateProject := claimsProject
+	endDateProject.ProjectDid = "endDateProjectDid"
+	endDateProject.Data.EndDate = "2019-12-31T00:00:00Z"
+	
+	runningProject := claimsProject
+	runningProject.ProjectDid = "runningProjectDid"
+	runningProject.Data.EndDate = "2020-12-31T00:00:00Z"
+	
+	for _, msg := range []CreateProjectMsg{claimsProject, endDateProject, runningProject} {
+		msg := msg
+		require.Nil(t, k.SetProjectDoc(ctx, &msg))
+	}
+	
+	for _, claimID := range []string{"claim1", "claim2", "claim3"} {
+		k.SetClaim(ctx, claimsProject.ProjectDid, Claim{ID: claimID, Status: ApprovedClaim})
+	}
+	k.SetClaim(ctx, runningProject.ProjectDid, Claim{ID: "claim1", Status: ApprovedClaim})
+	k.SetClaim(ctx, runningProject.ProjectDid, Claim{ID: "claim2", Status: RejectedClaim})
+	k.SetClaim(ctx, runningProject.ProjectDid, Claim{ID: "claim3", Status: PendingClaim})
+	
+	EndBlocker(ctx, k)
+	
+	expectedStatuses := map[string]ProjectStatus{
+		claimsProject.ProjectDid:  StoppedStatus,
+		endDateProject.ProjectDid: StoppedStatus,
+		runningProject.ProjectDid: StartedStatus,
+	}
+	for projectDid, status := range expectedStatuses {
+		projectDoc, err := k.GetProjectDoc(ctx, projectDid)
+		require.Nil(t, err)
+		require.Equal(t, status, projectDoc.GetStatus())
+	}
+	
+	events := ctx.EventManager().Events()
+	require.Equal(t, 2, len(events))
+	require.Equal(t, EventTypeUpdateProjectStatus, events[0].Type)
+	
+	// Approved claims are counted as they are set, and the running project is
+	// stopped at the end of the block in which its claims are approved
+	require.Equal(t, int64(3), k.GetApprovedClaimCount(ctx, claimsProject.ProjectDid))
+	require.Equal(t, int64(1), k.GetApprovedClaimCount(ctx, runningProject.ProjectDid))
+	ctx = ctx.WithBlockTime(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)).WithEventManager(sdk.NewEventManager())
+	k.SetClaim(ctx, runningProject.ProjectDid, Claim{ID: "claim3", Status: ApprovedClaim})
+	require.Equal(t, int64(2), k.GetApprovedClaimCount(ctx, runningProject.ProjectDid))
+	EndBlocker(ctx, k)
+	projectDoc, err := k.GetProjectDoc(ctx, runningProject.ProjectDid)
+	require.Nil(t, err)
+	require.Equal(t, StartedStatus, projectDoc.GetStatus())
+	
+	k.SetClaim(ctx, runningProject.ProjectDid, Claim{ID: "claim4", Status: ApprovedClaim})
+	EndBlocker(ctx, k)
+	projectDoc, err = k.GetProjectDoc(ctx, runningProject.ProjectDid)
+	require.Nil(t, err)
+	require.Equal(t, StoppedStatus, projectDoc.GetStatus())
+	events = ctx.EventManager().Events()
+	require.Equal(t, 1, len(events))
+	require.Equal(t, AttributeValueRequiredClaimsReached, string(events[0].Attributes[2].Value))
+	
+	// Only the running project's end date is left in the stop queue, and it is
+	// removed once it is reached
+	iterator := k.GetStopQueueIterator(ctx, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, iterator.Valid())
+	_, projectDid := SplitStopQueueKey(iterator.Key())
+	require.Equal(t, runningProject.ProjectDid, projectDid)
+	iterator.Next()
+	require.False(t, iterator.Valid())
+	iterator.Close()
+	
+	ctx = ctx.WithBlockTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).WithEventManager(sdk.NewEventManager())
+	EndBlocker(ctx, k)
+	require.Empty(t, ctx.EventManager().Events())
+	iterator = k.GetStopQueueIterator(ctx, ctx.BlockHeader().Time)
+	require.False(t, iterator.Valid())
+	iterator.Close()
+}
+
+func TestHandler_FundProject(t *testing.T) {
+	ctx, k, cdc, _, bk, pk, dk := keeper.CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*exported.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
+	ck := contracts.NewKeeper(cdc, pk)
+	
+	projectMsg := types.ValidCreateProjectMsg
+	projectMsg.Data.FundingTarget = "2"
+	projectDid := projectMsg.ProjectDid
+	res := handleCreateProjectMsg(ctx, k, bk, projectMsg)
+	require.True(t, res.IsOK())
+	
+	funderAddr := sdk.AccAddress([]byte("funder_address______"))
+	_, err := bk.AddCoins(ctx, funderAddr, sdk.NewCoins(sdk.NewInt64Coin(ixo.IxoNativeToken, 300000000)))
+	require.Nil(t, err)
+	
+	fundMsg := types.NewFundProjectMsg(funderAddr, FundProjectDoc{
+		ProjectDid: projectDid,
+		Amount:     sdk.NewInt(100000000),
+	})
+	updateStatusMsg := types.UpdateProjectStatusMsg{
+		ProjectDid: projectDid,
+		Data:       types.UpdateProjectStatusDoc{Status: PendingStatus},
+	}
+	
+	// The funder's account signs a standard transaction, whose account sequence
+	// prevents replays, rather than an ixo transaction signed by a DID
+	require.NotEqual(t, "project", fundMsg.Type())
+	require.Equal(t, []sdk.AccAddress{funderAddr}, fundMsg.GetSigners())
+	
+	// Contributions are recorded for the DID that the funder's account is linked to
+	res = handleFundProjectMsg(ctx, k, bk, fundMsg)
+	require.False(t, res.IsOK())
+	dk.SetAccountDid(ctx, funderAddr, "funderDid")
+	
+	res = handleFundProjectMsg(ctx, k, bk, fundMsg)
+	require.True(t, res.IsOK())
+	res = handleUpdateProjectStatusMsg(ctx, k, ck, bk, pk, ixo.EthClient{}, updateStatusMsg)
+	require.True(t, res.IsOK())
+	
+	// The funding target of 2 IXO has not been reached yet
+	updateStatusMsg.Data.Status = FundedStatus
+	res = handleUpdateProjectStatusMsg(ctx, k, ck, bk, pk, ixo.EthClient{}, updateStatusMsg)
+	require.False(t, res.IsOK())
+	
+	res = handleFundProjectMsg(ctx, k, bk, fundMsg)
+	require.True(t, res.IsOK())
+	res = handleUpdateProjectStatusMsg(ctx, k, ck, bk, pk, ixo.EthClient{}, updateStatusMsg)
+	require.True(t, res.IsOK())
+	
+	require.Equal(t, []Contribution{{FunderDid: "funderDid", Amount: sdk.NewInt(200000000)}},
+		k.GetProjectContributions(ctx, projectDid))
+	require.Equal(t, int64(100000000), bk.GetCoins(ctx, funderAddr).AmountOf(ixo.IxoNativeToken).Int64())
+	
+	// Funded projects cannot be funded any further
+	res = handleFundProjectMsg(ctx, k, bk, fundMsg)
+	require.False(t, res.IsOK())
+}
+
+func TestHandler_RefundContributions(t *testing.T) {
+	ctx, k, cdc, _, bk, pk, dk := keeper.CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*exported.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
+	ck := contracts.NewKeeper(cdc, pk)
+	
+	projectMsg := types.ValidCreateProjectMsg
+	projectMsg.Data.FundingTarget = "3"
+	projectDid := projectMsg.ProjectDid
+	res := handleCreateProjectMsg(ctx, k, bk, projectMsg)
+	require.True(t, res.IsOK())
+	
+	// Two funders contribute 2 IXO and 1 IXO from their linked accounts
+	funders := map[string]sdk.AccAddress{
+		"funderDid1": sdk.AccAddress([]byte("funder_address_1____")),
+		"funderDid2": sdk.AccAddress([]byte("funder_address_2____")),
+	}
+	for funderDid, funderAddr := range funders {
+		dk.SetAccountDid(ctx, funderAddr, funderDid)
+		_, err := bk.AddCoins(ctx, funderAddr, sdk.NewCoins(sdk.NewInt64Coin(ixo.IxoNativeToken, 200000000)))
+		require.Nil(t, err)
+	}
+	fund := func(funderDid string, amount int64) {
+		res := handleFundProjectMsg(ctx, k, bk, types.NewFundProjectMsg(funders[funderDid], FundProjectDoc{
+			ProjectDid: projectDid,
+			Amount:     sdk.NewInt(amount),
+		}))
+		require.True(t, res.IsOK())
+	}
+	refund := func(funderDid string) sdk.Result {
+		return handleWithdrawFundsMsg(ctx, k, bk, pk, ixo.EthClient{}, types.WithdrawFundsMsg{
+			SenderDid: funderDid,
+			Data:      types.WithdrawFundsDoc{ProjectDid: projectDid, IsRefund: true},
+		})
+	}
+	balanceOf := func(funderDid string) int64 {
+		return bk.GetCoins(ctx, funders[funderDid]).AmountOf(ixo.IxoNativeToken).Int64()
+	}
+	fund("funderDid1", 200000000)
+	fund("funderDid2", 100000000)
+	
+	// Before the project is funded, funders can be refunded what they contributed
+	res = refund("funderDid2")
+	require.True(t, res.IsOK())
+	require.Equal(t, int64(200000000), balanceOf("funderDid2"))
+	fund("funderDid2", 100000000)
+	
+	for _, status := range []ProjectStatus{PendingStatus, FundedStatus, StartedStatus} {
+		res = handleUpdateProjectStatusMsg(ctx, k, ck, bk, pk, ixo.EthClient{}, types.UpdateProjectStatusMsg{
+			ProjectDid: projectDid,
+			Data:       types.UpdateProjectStatusDoc{Status: status},
+		})
+		require.True(t, res.IsOK())
+	}
+	
+	// Funds cannot be refunded while the project is running
+	res = refund("funderDid1")
+	require.False(t, res.IsOK())
+	
+	// Once the project has stopped, what is left in the project's account
+	// (here 2.4 of the 3 IXO) is refunded in proportion to the contributions
+	res = handleUpdateProjectStatusMsg(ctx, k, ck, bk, pk, ixo.EthClient{}, types.UpdateProjectStatusMsg{
+		ProjectDid: projectDid,
+		Data:       types.UpdateProjectStatusDoc{Status: StoppedStatus},
+	})
+	require.True(t, res.IsOK())
+	projectAddr, err := getAccountInProjectAccounts(ctx, k, projectDid, projectDid)
+	require.Nil(t, err)
+	_, err = bk.SubtractCoins(ctx, projectAddr, sdk.NewCoins(sdk.NewInt64Coin(ixo.IxoNativeToken, 60000000)))
+	require.Nil(t, err)
+	
+	res = refund("funderDid2")
+	require.True(t, res.IsOK())
+	require.Equal(t, int64(100000000+80000000), balanceOf("funderDid2"))
+	res = refund("funderDid1")
+	require.True(t, res.IsOK())
+	require.Equal(t, int64(160000000), balanceOf("funderDid1"))
+	require.True(t, bk.GetCoins(ctx, projectAddr).AmountOf(ixo.IxoNativeToken).IsZero())
+	
+	// Contributions can only be refunded once, and only to funders
+	require.Empty(t, k.GetProjectContributions(ctx, projectDid))
+	res = refund("funderDid1")
+	require.False(t, res.IsOK())
+	res = refund("otherDid")
+	require.False(t, res.IsOK())
+}
+
 func Test_WithdrawFunds(t *testing.T) {
-	ctx, k, cdc, _, bk, pk := keeper.CreateTestInput()
+	ctx, k, cdc, _, bk, pk, _ := keeper.CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "cosmos-sdk/Account", nil)
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"time"
 	
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,14 +19,17 @@ type Keeper struct {
 	cdc           *codec.Codec
 	storeKey      sdk.StoreKey
 	accountKeeper types.AccountKeeper
+	didKeeper     types.DidKeeper
 	feeKeeper     types.FeeKeeper
 }
 
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, accountKeeper types.AccountKeeper, feeKeeper types.FeeKeeper) Keeper {
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, accountKeeper types.AccountKeeper,
+	didKeeper types.DidKeeper, feeKeeper types.FeeKeeper) Keeper {
 	return Keeper{
 		cdc:           cdc,
 		storeKey:      key,
 		accountKeeper: accountKeeper,
+		didKeeper:     didKeeper,
 		feeKeeper:     feeKeeper,
 	}
 }
@@ -45,6 +49,25 @@ func (k Keeper) GetProjectDoc(ctx sdk.Context, projectDid ixo.Did) (types.Stored
 	return &projectDoc, nil
 }
 
+func (k Keeper) GetProjectDocIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.ProjectKey)
+}
+
+func (k Keeper) GetAllProjectDocs(ctx sdk.Context) []types.StoredProjectDoc {
+	iterator := k.GetProjectDocIterator(ctx)
+	defer iterator.Close()
+	
+	var projectDocs []types.StoredProjectDoc
+	for ; iterator.Valid(); iterator.Next() {
+		var projectDoc types.CreateProjectMsg
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &projectDoc)
+		projectDocs = append(projectDocs, &projectDoc)
+	}
+	
+	return projectDocs
+}
+
 func (k Keeper) SetProjectDoc(ctx sdk.Context, projectDoc types.StoredProjectDoc) sdk.Error {
 	existedDoc, err := k.GetProjectDoc(ctx, projectDoc.GetProjectDid())
 	if existedDoc != nil {
@@ -60,6 +83,17 @@ func (k Keeper) AddProjectDoc(ctx sdk.Context, projectDoc types.StoredProjectDoc
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetProjectPrefixKey(projectDoc.GetProjectDid())
 	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(projectDoc))
+	
+	// Started projects are checked by the end-blocker at their end date, and
+	// straight away if they already have their required claims
+	if projectDoc.GetStatus() == types.StartedStatus {
+		if endDate, hasEndDate := projectDoc.GetEndDate(); hasEndDate {
+			k.InsertStopQueue(ctx, projectDoc.GetProjectDid(), endDate)
+		}
+		if k.HasRequiredClaims(ctx, projectDoc) {
+			k.InsertStopQueue(ctx, projectDoc.GetProjectDid(), ctx.BlockHeader().Time)
+		}
+	}
 }
 
 func (k Keeper) UpdateProjectDoc(ctx sdk.Context, newProjectDoc types.StoredProjectDoc) (types.StoredProjectDoc, sdk.Error) {
@@ -112,6 +146,27 @@ func (k Keeper) AddAccountToProjectAccounts(ctx sdk.Context, projectDid ixo.Did,
 	store.Set(key, bz)
 }
 
+func (k Keeper) SetAccountMap(ctx sdk.Context, projectDid ixo.Did, accountMap types.AccountMap) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetAccountPrefixKey(projectDid)
+	
+	bz, err := json.Marshal(accountMap)
+	if err != nil {
+		panic(err)
+	}
+	
+	store.Set(key, bz)
+}
+
+func (k Keeper) GetAccountMapIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.AccountKey)
+}
+
+func (k Keeper) AccountExists(ctx sdk.Context, address sdk.AccAddress) bool {
+	return k.accountKeeper.GetAccount(ctx, address) != nil
+}
+
 func (k Keeper) CreateNewAccount(ctx sdk.Context, projectDid ixo.Did, accountId string) (auth.Account, sdk.Error) {
 	src := []byte(projectDid + "/" + accountId)
 	hexAddress := hex.EncodeToString(src)
@@ -142,6 +197,18 @@ func (k Keeper) GetProjectWithdrawalTransactions(ctx sdk.Context, projectDid ixo
 	}
 }
 
+func (k Keeper) SetProjectWithdrawalTransactions(ctx sdk.Context, projectDid ixo.Did, txs []types.WithdrawalInfo) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetWithdrawalPrefixKey(projectDid)
+	
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(txs))
+}
+
+func (k Keeper) GetWithdrawalsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.WithdrawalKey)
+}
+
 func (k Keeper) AddProjectWithdrawalTransaction(ctx sdk.Context, projectDid ixo.Did, info types.WithdrawalInfo) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetWithdrawalPrefixKey(projectDid)
@@ -151,3 +218,214 @@ func (k Keeper) AddProjectWithdrawalTransaction(ctx sdk.Context, projectDid ixo.
 	
 	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(txs))
 }
+
+func (k Keeper) GetProjectAgents(ctx sdk.Context, projectDid ixo.Did) []types.Agent {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetAgentPrefixKey(projectDid)
+	
+	agents := []types.Agent{}
+	bz := store.Get(key)
+	if bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &agents)
+	}
+	
+	return agents
+}
+
+func (k Keeper) GetProjectAgent(ctx sdk.Context, projectDid ixo.Did, agentDid ixo.Did) (types.Agent, bool) {
+	for _, agent := range k.GetProjectAgents(ctx, projectDid) {
+		if agent.Did == agentDid {
+			return agent, true
+		}
+	}
+	
+	return types.Agent{}, false
+}
+
+func (k Keeper) SetProjectAgents(ctx sdk.Context, projectDid ixo.Did, agents []types.Agent) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetAgentPrefixKey(projectDid)
+	
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(agents))
+}
+
+func (k Keeper) SetProjectAgent(ctx sdk.Context, projectDid ixo.Did, agent types.Agent) {
+	agents := k.GetProjectAgents(ctx, projectDid)
+	found := false
+	for i, a := range agents {
+		if a.Did == agent.Did {
+			agents[i] = agent
+			found = true
+			break
+		}
+	}
+	if !found {
+		agents = append(agents, agent)
+	}
+	
+	k.SetProjectAgents(ctx, projectDid, agents)
+}
+
+func (k Keeper) GetClaim(ctx sdk.Context, projectDid ixo.Did, claimID string) (types.Claim, bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetClaimKey(projectDid, claimID)
+	
+	bz := store.Get(key)
+	if bz == nil {
+		return types.Claim{}, false
+	}
+	
+	var claim types.Claim
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &claim)
+	
+	return claim, true
+}
+
+func (k Keeper) SetClaim(ctx sdk.Context, projectDid ixo.Did, claim types.Claim) {
+	oldClaim, found := k.GetClaim(ctx, projectDid, claim.ID)
+	wasApproved := found && oldClaim.Status == types.ApprovedClaim
+	
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetClaimKey(projectDid, claim.ID)
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(claim))
+	
+	// Keep count of the approved claims, so that the end-blocker does not have
+	// to go through the claims, and check a started project straight away once
+	// it has its required claims
+	if claim.Status == types.ApprovedClaim && !wasApproved {
+		k.setApprovedClaimCount(ctx, projectDid, k.GetApprovedClaimCount(ctx, projectDid)+1)
+		
+		projectDoc, err := k.GetProjectDoc(ctx, projectDid)
+		if err == nil && projectDoc.GetStatus() == types.StartedStatus && k.HasRequiredClaims(ctx, projectDoc) {
+			k.InsertStopQueue(ctx, projectDid, ctx.BlockHeader().Time)
+		}
+	} else if claim.Status != types.ApprovedClaim && wasApproved {
+		k.setApprovedClaimCount(ctx, projectDid, k.GetApprovedClaimCount(ctx, projectDid)-1)
+	}
+}
+
+func (k Keeper) GetApprovedClaimCount(ctx sdk.Context, projectDid ixo.Did) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetApprovedClaimsKey(projectDid))
+	if bz == nil {
+		return 0
+	}
+	
+	var count int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &count)
+	
+	return count
+}
+
+func (k Keeper) setApprovedClaimCount(ctx sdk.Context, projectDid ixo.Did, count int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetApprovedClaimsKey(projectDid), k.cdc.MustMarshalBinaryLengthPrefixed(count))
+}
+
+// HasRequiredClaims returns whether the project has a number of required
+// claims, and at least that many claims have been approved
+func (k Keeper) HasRequiredClaims(ctx sdk.Context, projectDoc types.StoredProjectDoc) bool {
+	required := projectDoc.GetRequiredClaims()
+	return required > 0 && k.GetApprovedClaimCount(ctx, projectDoc.GetProjectDid()) >= required
+}
+
+// InsertStopQueue queues the project to be checked by the end-blocker once
+// the block time reaches the stop time
+func (k Keeper) InsertStopQueue(ctx sdk.Context, projectDid ixo.Did, stopTime time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetStopQueueKey(stopTime, projectDid), []byte(projectDid))
+}
+
+func (k Keeper) RemoveFromStopQueue(ctx sdk.Context, projectDid ixo.Did, stopTime time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetStopQueueKey(stopTime, projectDid))
+}
+
+// GetStopQueueIterator returns an iterator over the stop queue entries with
+// stop times up to and including the end time, ordered by stop time
+func (k Keeper) GetStopQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.StopQueueKey, sdk.PrefixEndBytes(types.GetStopQueueTimeKey(endTime)))
+}
+
+func (k Keeper) GetProjectClaimsIterator(ctx sdk.Context, projectDid ixo.Did) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.GetClaimPrefixKey(projectDid))
+}
+
+func (k Keeper) GetProjectClaims(ctx sdk.Context, projectDid ixo.Did) []types.Claim {
+	iterator := k.GetProjectClaimsIterator(ctx, projectDid)
+	defer iterator.Close()
+	
+	claims := []types.Claim{}
+	for ; iterator.Valid(); iterator.Next() {
+		var claim types.Claim
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &claim)
+		claims = append(claims, claim)
+	}
+	
+	return claims
+}
+
+func (k Keeper) GetProjectClaimCounts(ctx sdk.Context, projectDid ixo.Did) (types.ClaimCounts, sdk.Error) {
+	projectDoc, err := k.GetProjectDoc(ctx, projectDid)
+	if err != nil {
+		return types.ClaimCounts{}, err
+	}
+	
+	counts := types.ClaimCounts{Required: projectDoc.GetRequiredClaims()}
+	for _, claim := range k.GetProjectClaims(ctx, projectDid) {
+		counts.Add(claim.Status)
+	}
+	
+	return counts, nil
+}
+
+func (k Keeper) GetProjectContributions(ctx sdk.Context, projectDid ixo.Did) []types.Contribution {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetFundingPrefixKey(projectDid)
+	
+	contributions := []types.Contribution{}
+	bz := store.Get(key)
+	if bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &contributions)
+	}
+	
+	return contributions
+}
+
+func (k Keeper) SetProjectContributions(ctx sdk.Context, projectDid ixo.Did, contributions []types.Contribution) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetFundingPrefixKey(projectDid)
+	
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(contributions))
+}
+
+func (k Keeper) AddProjectContribution(ctx sdk.Context, projectDid ixo.Did, funderDid ixo.Did, amount sdk.Int) {
+	contributions := k.GetProjectContributions(ctx, projectDid)
+	found := false
+	for i, c := range contributions {
+		if c.FunderDid == funderDid {
+			contributions[i].Amount = c.Amount.Add(amount)
+			found = true
+			break
+		}
+	}
+	if !found {
+		contributions = append(contributions, types.Contribution{FunderDid: funderDid, Amount: amount})
+	}
+	
+	k.SetProjectContributions(ctx, projectDid, contributions)
+}
+
+// GetFunderAccount returns the account that the funder's DID is linked to,
+// which funds are moved from when the funder funds a project and refunded to
+func (k Keeper) GetFunderAccount(ctx sdk.Context, funderDid ixo.Did) (sdk.AccAddress, bool) {
+	return k.didKeeper.GetDidAccount(ctx, funderDid)
+}
+
+// GetFunderDid returns the DID that the funder's account is linked to, which
+// contributions from the account are recorded for
+func (k Keeper) GetFunderDid(ctx sdk.Context, funderAddr sdk.AccAddress) (ixo.Did, bool) {
+	return k.didKeeper.GetAccountDid(ctx, funderAddr)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func TestProjectDoc(t *testing.T) {
-	ctx, k, _, _, _, _ := CreateTestInput()
+	ctx, k, _, _, _, _, _ := CreateTestInput()
 	
 	err := k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
 	require.Nil(t, err)
@@ -35,7 +35,7 @@ func TestProjectDoc(t *testing.T) {
 }
 
 func TestKeeperAccountMap(t *testing.T) {
-	ctx, k, cdc, _, _, _ := CreateTestInput()
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "", nil)
@@ -55,7 +55,7 @@ func TestKeeperAccountMap(t *testing.T) {
 }
 
 func TestKeeperWithdrawalInfo(t *testing.T) {
-	ctx, k, cdc, _, _, _ := CreateTestInput()
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	
 	withdrawals, err := k.GetProjectWithdrawalTransactions(ctx, "")
@@ -69,3 +69,57 @@ func TestKeeperWithdrawalInfo(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, 2, len(withdrawals))
 }
+
+func TestKeeperProjectAgents(t *testing.T) {
+	ctx, k, _, _, _, _, _ := CreateTestInput()
+	
+	agents := k.GetProjectAgents(ctx, types.ValidCreateProjectMsg.ProjectDid)
+	require.Equal(t, 0, len(agents))
+	
+	_, found := k.GetProjectAgent(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidAgent.Did)
+	require.False(t, found)
+	
+	k.SetProjectAgent(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidAgent)
+	agent, found := k.GetProjectAgent(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidAgent.Did)
+	require.True(t, found)
+	require.Equal(t, types.ValidAgent, agent)
+	
+	agent.Status = types.ApprovedAgent
+	k.SetProjectAgent(ctx, types.ValidCreateProjectMsg.ProjectDid, agent)
+	agents = k.GetProjectAgents(ctx, types.ValidCreateProjectMsg.ProjectDid)
+	require.Equal(t, 1, len(agents))
+	require.True(t, agents[0].IsApprovedAs(types.ServiceAgentRole))
+	require.False(t, agents[0].IsApprovedAs(types.EvaluatorAgentRole))
+}
+
+func TestKeeperClaims(t *testing.T) {
+	ctx, k, _, _, _, _, _ := CreateTestInput()
+	
+	_, err := k.GetProjectClaimCounts(ctx, types.ValidCreateProjectMsg.ProjectDid)
+	require.NotNil(t, err)
+	
+	err = k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
+	require.Nil(t, err)
+	
+	_, found := k.GetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidClaim.ID)
+	require.False(t, found)
+	
+	k.SetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidClaim)
+	claim, found := k.GetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidClaim.ID)
+	require.True(t, found)
+	require.Equal(t, types.ValidClaim, claim)
+	
+	claim.ID = "ClaimID2"
+	claim.Status = types.ApprovedClaim
+	k.SetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid, claim)
+	
+	// Claims of other projects are not included
+	k.SetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid+"2", types.ValidClaim)
+	
+	claims := k.GetProjectClaims(ctx, types.ValidCreateProjectMsg.ProjectDid)
+	require.Equal(t, 2, len(claims))
+	
+	counts, err := k.GetProjectClaimCounts(ctx, types.ValidCreateProjectMsg.ProjectDid)
+	require.Nil(t, err)
+	require.Equal(t, types.ClaimCounts{Required: 3, Pending: 1, Approved: 1}, counts)
+}
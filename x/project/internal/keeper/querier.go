@@ -6,17 +6,22 @@ import (
 	
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/tendermint/tendermint/abci/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	
+	"github.com/ixofoundation/ixo-cosmos/x/project/internal/types"
 )
 
 const (
 	QueryProjectDoc     = "queryProjectDoc"
 	QueryProjectAccount = "queryProjectAccount"
 	QueryProjectTx      = "queryProjectTx"
+	QueryProjectAgents  = "queryProjectAgents"
+	QueryProjectClaims  = "queryProjectClaims"
+	QueryContributions  = "queryProjectContributions"
 )
 
 func NewQuerier(k Keeper) sdk.Querier {
-	return func(ctx sdk.Context, path []string, req types.RequestQuery) (res []byte, err sdk.Error) {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
 		case QueryProjectDoc:
 			return queryProjectDoc(ctx, path[1:], k)
@@ -24,6 +29,12 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryProjectAccount(ctx, path[1:], k)
 		case QueryProjectTx:
 			return queryProjectTx(ctx, path[1:], k)
+		case QueryProjectAgents:
+			return queryProjectAgents(ctx, path[1:], k)
+		case QueryProjectClaims:
+			return queryProjectClaims(ctx, path[1:], k)
+		case QueryContributions:
+			return queryProjectContributions(ctx, path[1:], k)
 		default:
 			return nil, sdk.ErrUnknownRequest("Unknown project query endpoint")
 		}
@@ -68,3 +79,63 @@ func queryProjectTx(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error
 	
 	return res, nil
 }
+
+func queryProjectAgents(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	_, err := k.GetProjectDoc(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	
+	agents := k.GetProjectAgents(ctx, path[0])
+	res, errRes := codec.MarshalJSONIndent(k.cdc, agents)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes.Error()))
+	}
+	
+	return res, nil
+}
+
+func queryProjectClaims(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	counts, err := k.GetProjectClaimCounts(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	
+	// An optional second path element filters the claims by status
+	var status types.ClaimStatus
+	if len(path) > 1 {
+		status = types.ClaimStatus(path[1])
+		if !status.IsValid() {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid claim status %s", path[1]))
+		}
+	}
+	
+	claims := []types.Claim{}
+	for _, claim := range k.GetProjectClaims(ctx, path[0]) {
+		if status == "" || claim.Status == status {
+			claims = append(claims, claim)
+		}
+	}
+	
+	res, errRes := codec.MarshalJSONIndent(k.cdc, types.ProjectClaims{Counts: counts, Claims: claims})
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes.Error()))
+	}
+	
+	return res, nil
+}
+
+func queryProjectContributions(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	_, err := k.GetProjectDoc(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	
+	contributions := k.GetProjectContributions(ctx, path[0])
+	res, errRes := codec.MarshalJSONIndent(k.cdc, contributions)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to marshal data %s", errRes.Error()))
+	}
+	
+	return res, nil
+}
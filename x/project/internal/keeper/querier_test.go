@@ -5,6 +5,7 @@ import (
 	"testing"
 	
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/stretchr/testify/require"
@@ -14,7 +15,7 @@ import (
 )
 
 func TestQueryProjectDoc(t *testing.T) {
-	ctx, k, cdc, _, _, _ := CreateTestInput()
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "", nil)
@@ -40,7 +41,7 @@ func TestQueryProjectDoc(t *testing.T) {
 }
 
 func TestQueryProjectAccounts(t *testing.T) {
-	ctx, k, cdc, _, _, _ := CreateTestInput()
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "", nil)
@@ -76,7 +77,7 @@ func TestQueryProjectAccounts(t *testing.T) {
 }
 
 func TestQueryTxs(t *testing.T) {
-	ctx, k, cdc, _, _, _ := CreateTestInput()
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
 	codec.RegisterCrypto(cdc)
 	cdc.RegisterInterface((*exported.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "", nil)
@@ -103,3 +104,96 @@ func TestQueryTxs(t *testing.T) {
 	require.NotNil(t, err)
 	
 }
+
+func TestQueryProjectAgents(t *testing.T) {
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	
+	query := abciTypes.RequestQuery{
+		Path: "",
+		Data: []byte{},
+	}
+	
+	querier := NewQuerier(k)
+	_, err := querier(ctx, []string{QueryProjectAgents, types.ValidCreateProjectMsg.ProjectDid}, query)
+	require.NotNil(t, err)
+	
+	err = k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
+	require.Nil(t, err)
+	
+	k.SetProjectAgent(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidAgent)
+	
+	res, err := querier(ctx, []string{QueryProjectAgents, types.ValidCreateProjectMsg.ProjectDid}, query)
+	require.Nil(t, err)
+	
+	var agents []types.Agent
+	cdc.MustUnmarshalJSON(res, &agents)
+	require.Equal(t, []types.Agent{types.ValidAgent}, agents)
+}
+
+func TestQueryProjectClaims(t *testing.T) {
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	
+	query := abciTypes.RequestQuery{
+		Path: "",
+		Data: []byte{},
+	}
+	
+	querier := NewQuerier(k)
+	_, err := querier(ctx, []string{QueryProjectClaims, types.ValidCreateProjectMsg.ProjectDid}, query)
+	require.NotNil(t, err)
+	
+	err = k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
+	require.Nil(t, err)
+	
+	approvedClaim := types.ValidClaim
+	approvedClaim.ID = "ClaimID2"
+	approvedClaim.Status = types.ApprovedClaim
+	k.SetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid, types.ValidClaim)
+	k.SetClaim(ctx, types.ValidCreateProjectMsg.ProjectDid, approvedClaim)
+	
+	res, err := querier(ctx, []string{QueryProjectClaims, types.ValidCreateProjectMsg.ProjectDid}, query)
+	require.Nil(t, err)
+	
+	var claims types.ProjectClaims
+	cdc.MustUnmarshalJSON(res, &claims)
+	require.Equal(t, 2, len(claims.Claims))
+	require.Equal(t, int64(1), claims.Counts.Approved)
+	
+	res, err = querier(ctx, []string{QueryProjectClaims, types.ValidCreateProjectMsg.ProjectDid, string(types.ApprovedClaim)}, query)
+	require.Nil(t, err)
+	
+	cdc.MustUnmarshalJSON(res, &claims)
+	require.Equal(t, []types.Claim{approvedClaim}, claims.Claims)
+	
+	_, err = querier(ctx, []string{QueryProjectClaims, types.ValidCreateProjectMsg.ProjectDid, "InvalidStatus"}, query)
+	require.NotNil(t, err)
+}
+
+func TestQueryProjectContributions(t *testing.T) {
+	ctx, k, cdc, _, _, _, _ := CreateTestInput()
+	codec.RegisterCrypto(cdc)
+	
+	query := abciTypes.RequestQuery{
+		Path: "",
+		Data: []byte{},
+	}
+	
+	querier := NewQuerier(k)
+	_, err := querier(ctx, []string{QueryContributions, types.ValidCreateProjectMsg.ProjectDid}, query)
+	require.NotNil(t, err)
+	
+	err = k.SetProjectDoc(ctx, &types.ValidCreateProjectMsg)
+	require.Nil(t, err)
+	
+	k.AddProjectContribution(ctx, types.ValidCreateProjectMsg.ProjectDid, "FunderDid", sdk.NewInt(100))
+	k.AddProjectContribution(ctx, types.ValidCreateProjectMsg.ProjectDid, "FunderDid", sdk.NewInt(50))
+	
+	res, err := querier(ctx, []string{QueryContributions, types.ValidCreateProjectMsg.ProjectDid}, query)
+	require.Nil(t, err)
+	
+	var contributions []types.Contribution
+	cdc.MustUnmarshalJSON(res, &contributions)
+	require.Equal(t, []types.Contribution{{FunderDid: "FunderDid", Amount: sdk.NewInt(150)}}, contributions)
+}
@@ -11,17 +11,19 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmDB "github.com/tendermint/tm-db"
 	
+	"github.com/ixofoundation/ixo-cosmos/x/did"
 	"github.com/ixofoundation/ixo-cosmos/x/fees"
 	"github.com/ixofoundation/ixo-cosmos/x/params"
 	"github.com/ixofoundation/ixo-cosmos/x/project/internal/types"
 )
 
-func CreateTestInput() (sdk.Context, Keeper, *codec.Codec, fees.Keeper, bank.Keeper, params.Keeper) {
+func CreateTestInput() (sdk.Context, Keeper, *codec.Codec, fees.Keeper, bank.Keeper, params.Keeper, did.Keeper) {
 	storeKey := sdk.NewKVStoreKey(types.StoreKey)
 	actStoreKey := sdk.NewKVStoreKey(auth.StoreKey)
 	keyParam := sdk.NewKVStoreKey(params.StoreKey)
 	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 	keyFee := sdk.NewKVStoreKey(fees.StoreKey)
+	keyDid := sdk.NewKVStoreKey(did.StoreKey)
 	
 	db := tmDB.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
@@ -30,6 +32,7 @@ func CreateTestInput() (sdk.Context, Keeper, *codec.Codec, fees.Keeper, bank.Kee
 	ms.MountStoreWithDB(keyParam, sdk.StoreTypeIAVL, nil)
 	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeIAVL, nil)
 	ms.MountStoreWithDB(keyFee, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyDid, sdk.StoreTypeIAVL, nil)
 	_ = ms.LoadLatestVersion()
 	
 	ctx := sdk.NewContext(ms, abciTypes.Header{}, true, log.NewNopLogger())
@@ -43,9 +46,10 @@ func CreateTestInput() (sdk.Context, Keeper, *codec.Codec, fees.Keeper, bank.Kee
 	)
 	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk1.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, nil)
 	feeKeeper := fees.NewKeeper(cdc, paramsKeeper)
-	keeper := NewKeeper(cdc, storeKey, accountKeeper, feeKeeper)
+	didKeeper := did.NewKeeper(cdc, keyDid)
+	keeper := NewKeeper(cdc, storeKey, accountKeeper, didKeeper, feeKeeper)
 	
-	return ctx, keeper, cdc, feeKeeper, bankKeeper, paramsKeeper
+	return ctx, keeper, cdc, feeKeeper, bankKeeper, paramsKeeper, didKeeper
 }
 
 func MakeTestCodec() *codec.Codec {
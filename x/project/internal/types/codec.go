@@ -11,6 +11,7 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(CreateEvaluationMsg{}, "ixo-cosmos/CreateEvaluationMsg", nil)
 	cdc.RegisterConcrete(UpdateAgentMsg{}, "ixo-cosmos/UpdateAgentMsg", nil)
 	cdc.RegisterConcrete(UpdateProjectStatusMsg{}, "ixo-cosmos/UpdateProjectStatusMsg", nil)
+	cdc.RegisterConcrete(FundProjectMsg{}, "ixo-cosmos/FundProjectMsg", nil)
 }
 
 var ModuleCdc *codec.Codec
@@ -0,0 +1,12 @@
+package types
+
+const (
+	EventTypeUpdateProjectStatus = "update_project_status"
+	
+	AttributeKeyProjectDid = "project_did"
+	AttributeKeyStatus     = "status"
+	AttributeKeyReason     = "reason"
+	
+	AttributeValueRequiredClaimsReached = "required_claims_reached"
+	AttributeValueEndDatePassed         = "end_date_passed"
+)
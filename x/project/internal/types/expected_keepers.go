@@ -3,6 +3,8 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+	
+	"github.com/ixofoundation/ixo-cosmos/x/ixo"
 )
 
 type AccountKeeper interface {
@@ -15,3 +17,8 @@ type FeeKeeper interface {
 	SetDec(ctx sdk.Context, key string, value sdk.Dec)
 	GetDec(ctx sdk.Context, key string) sdk.Dec
 }
+
+type DidKeeper interface {
+	GetDidAccount(ctx sdk.Context, did ixo.Did) (sdk.AccAddress, bool)
+	GetAccountDid(ctx sdk.Context, address sdk.AccAddress) (ixo.Did, bool)
+}
@@ -0,0 +1,192 @@
+package types
+
+import (
+	"fmt"
+	
+	"github.com/ixofoundation/ixo-cosmos/x/ixo"
+)
+
+type GenesisState struct {
+	ProjectDocs   []CreateProjectMsg            `json:"project_docs" yaml:"project_docs"`
+	AccountMaps   []GenesisAccountMap           `json:"account_maps" yaml:"account_maps"`
+	Withdrawals   []GenesisWithdrawals          `json:"withdrawals" yaml:"withdrawals"`
+	Agents        []GenesisProjectAgents        `json:"agents" yaml:"agents"`
+	Claims        []GenesisProjectClaims        `json:"claims" yaml:"claims"`
+	Contributions []GenesisProjectContributions `json:"contributions" yaml:"contributions"`
+}
+
+// GenesisAccount is an entry of a project's account map, which maps internal
+// account IDs to the addresses of the accounts created for the project
+type GenesisAccount struct {
+	ID      string `json:"id" yaml:"id"`
+	Address string `json:"address" yaml:"address"`
+}
+
+type GenesisAccountMap struct {
+	ProjectDid ixo.Did          `json:"project_did" yaml:"project_did"`
+	Accounts   []GenesisAccount `json:"accounts" yaml:"accounts"`
+}
+
+type GenesisWithdrawals struct {
+	ProjectDid  ixo.Did          `json:"project_did" yaml:"project_did"`
+	Withdrawals []WithdrawalInfo `json:"withdrawals" yaml:"withdrawals"`
+}
+
+type GenesisProjectAgents struct {
+	ProjectDid ixo.Did `json:"project_did" yaml:"project_did"`
+	Agents     []Agent `json:"agents" yaml:"agents"`
+}
+
+type GenesisProjectClaims struct {
+	ProjectDid ixo.Did `json:"project_did" yaml:"project_did"`
+	Claims     []Claim `json:"claims" yaml:"claims"`
+}
+
+type GenesisProjectContributions struct {
+	ProjectDid    ixo.Did        `json:"project_did" yaml:"project_did"`
+	Contributions []Contribution `json:"contributions" yaml:"contributions"`
+}
+
+func NewGenesisState(projectDocs []CreateProjectMsg, accountMaps []GenesisAccountMap,
+	withdrawals []GenesisWithdrawals, agents []GenesisProjectAgents,
+	claims []GenesisProjectClaims, contributions []GenesisProjectContributions) GenesisState {
+	return GenesisState{
+		ProjectDocs:   projectDocs,
+		AccountMaps:   accountMaps,
+		Withdrawals:   withdrawals,
+		Agents:        agents,
+		Claims:        claims,
+		Contributions: contributions,
+	}
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		ProjectDocs:   nil,
+		AccountMaps:   nil,
+		Withdrawals:   nil,
+		Agents:        nil,
+		Claims:        nil,
+		Contributions: nil,
+	}
+}
+
+// ValidateGenesis performs the same checks on each project doc as are
+// performed on project creation, checks project statuses against the state
+// transitions, and checks that agents, claims and contributions belong to
+// projects in the genesis state. Account map addresses are checked against the auth module in
+// InitGenesis.
+func ValidateGenesis(data GenesisState) error {
+	projects := make(map[ixo.Did]bool)
+	for _, p := range data.ProjectDocs {
+		if projects[p.ProjectDid] {
+			return fmt.Errorf("duplicate project %s", p.ProjectDid)
+		}
+		projects[p.ProjectDid] = true
+		
+		if err := p.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid project %s: %s", p.ProjectDid, err.Error())
+		}
+		if !p.GetStatus().IsValid() {
+			return fmt.Errorf("invalid status %s for project %s", p.GetStatus(), p.ProjectDid)
+		}
+	}
+	
+	accountMaps := make(map[ixo.Did]bool)
+	for _, m := range data.AccountMaps {
+		if m.ProjectDid == "" {
+			return fmt.Errorf("account map has an empty project did")
+		} else if accountMaps[m.ProjectDid] {
+			return fmt.Errorf("duplicate account map for project %s", m.ProjectDid)
+		}
+		accountMaps[m.ProjectDid] = true
+		
+		ids := make(map[string]bool)
+		for _, a := range m.Accounts {
+			if a.ID == "" || a.Address == "" {
+				return fmt.Errorf("account map of project %s has an empty account id or address", m.ProjectDid)
+			} else if ids[a.ID] {
+				return fmt.Errorf("duplicate account %s for project %s", a.ID, m.ProjectDid)
+			}
+			ids[a.ID] = true
+		}
+	}
+	
+	withdrawals := make(map[ixo.Did]bool)
+	for _, w := range data.Withdrawals {
+		if w.ProjectDid == "" {
+			return fmt.Errorf("withdrawals have an empty project did")
+		} else if withdrawals[w.ProjectDid] {
+			return fmt.Errorf("duplicate withdrawals for project %s", w.ProjectDid)
+		}
+		withdrawals[w.ProjectDid] = true
+	}
+	
+	projectAgents := make(map[ixo.Did]bool)
+	for _, a := range data.Agents {
+		if !projects[a.ProjectDid] {
+			return fmt.Errorf("agents belong to unknown project %s", a.ProjectDid)
+		} else if projectAgents[a.ProjectDid] {
+			return fmt.Errorf("duplicate agents for project %s", a.ProjectDid)
+		}
+		projectAgents[a.ProjectDid] = true
+		
+		agents := make(map[ixo.Did]bool)
+		for _, agent := range a.Agents {
+			if agents[agent.Did] {
+				return fmt.Errorf("duplicate agent %s for project %s", agent.Did, a.ProjectDid)
+			} else if !IsValidAgentRole(agent.Role) {
+				return fmt.Errorf("invalid role %s for agent %s", agent.Role, agent.Did)
+			} else if !agent.Status.IsValid() {
+				return fmt.Errorf("invalid status %s for agent %s", agent.Status, agent.Did)
+			}
+			agents[agent.Did] = true
+		}
+	}
+	
+	projectClaims := make(map[ixo.Did]bool)
+	for _, c := range data.Claims {
+		if !projects[c.ProjectDid] {
+			return fmt.Errorf("claims belong to unknown project %s", c.ProjectDid)
+		} else if projectClaims[c.ProjectDid] {
+			return fmt.Errorf("duplicate claims for project %s", c.ProjectDid)
+		}
+		projectClaims[c.ProjectDid] = true
+		
+		claims := make(map[string]bool)
+		for _, claim := range c.Claims {
+			if claim.ID == "" {
+				return fmt.Errorf("claim of project %s has an empty id", c.ProjectDid)
+			} else if claims[claim.ID] {
+				return fmt.Errorf("duplicate claim %s for project %s", claim.ID, c.ProjectDid)
+			} else if !claim.Status.IsValid() {
+				return fmt.Errorf("invalid status %s for claim %s", claim.Status, claim.ID)
+			}
+			claims[claim.ID] = true
+		}
+	}
+	
+	projectContributions := make(map[ixo.Did]bool)
+	for _, c := range data.Contributions {
+		if !projects[c.ProjectDid] {
+			return fmt.Errorf("contributions belong to unknown project %s", c.ProjectDid)
+		} else if projectContributions[c.ProjectDid] {
+			return fmt.Errorf("duplicate contributions for project %s", c.ProjectDid)
+		}
+		projectContributions[c.ProjectDid] = true
+		
+		funders := make(map[ixo.Did]bool)
+		for _, contribution := range c.Contributions {
+			if contribution.FunderDid == "" {
+				return fmt.Errorf("contribution to project %s has an empty funder did", c.ProjectDid)
+			} else if funders[contribution.FunderDid] {
+				return fmt.Errorf("duplicate contribution by %s to project %s", contribution.FunderDid, c.ProjectDid)
+			} else if contribution.Amount.BigInt() == nil || !contribution.Amount.IsPositive() {
+				return fmt.Errorf("contribution by %s to project %s is not positive", contribution.FunderDid, c.ProjectDid)
+			}
+			funders[contribution.FunderDid] = true
+		}
+	}
+	
+	return nil
+}
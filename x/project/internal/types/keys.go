@@ -1,6 +1,10 @@
 package types
 
 import (
+	"time"
+	
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	
 	"github.com/ixofoundation/ixo-cosmos/x/ixo"
 )
 
@@ -15,6 +19,12 @@ var (
 	ProjectKey    = []byte{0x01}
 	AccountKey    = []byte{0x02}
 	WithdrawalKey = []byte{0x03}
+	AgentKey      = []byte{0x04}
+	ClaimKey      = []byte{0x05}
+	FundingKey    = []byte{0x06}
+	
+	ApprovedClaimsKey = []byte{0x07}
+	StopQueueKey      = []byte{0x08}
 )
 
 func GetProjectPrefixKey(did ixo.Did) []byte {
@@ -28,3 +38,44 @@ func GetAccountPrefixKey(did ixo.Did) []byte {
 func GetWithdrawalPrefixKey(did ixo.Did) []byte {
 	return append(WithdrawalKey, []byte(did)...)
 }
+
+func GetAgentPrefixKey(did ixo.Did) []byte {
+	return append(AgentKey, []byte(did)...)
+}
+
+func GetFundingPrefixKey(did ixo.Did) []byte {
+	return append(FundingKey, []byte(did)...)
+}
+
+func GetClaimPrefixKey(did ixo.Did) []byte {
+	return append(append(ClaimKey, []byte(did)...), '/')
+}
+
+func GetClaimKey(did ixo.Did, claimID string) []byte {
+	return append(GetClaimPrefixKey(did), []byte(claimID)...)
+}
+
+func GetApprovedClaimsKey(did ixo.Did) []byte {
+	return append(ApprovedClaimsKey, []byte(did)...)
+}
+
+// GetStopQueueTimeKey returns the prefix of the stop queue entries at the
+// time, under which entries are ordered by time
+func GetStopQueueTimeKey(stopTime time.Time) []byte {
+	return append(StopQueueKey, sdk.FormatTimeBytes(stopTime)...)
+}
+
+func GetStopQueueKey(stopTime time.Time, did ixo.Did) []byte {
+	return append(GetStopQueueTimeKey(stopTime), []byte(did)...)
+}
+
+// SplitStopQueueKey returns the stop time and project DID of a stop queue entry
+func SplitStopQueueKey(key []byte) (time.Time, ixo.Did) {
+	timeLength := len(sdk.FormatTimeBytes(time.Time{}))
+	stopTime, err := sdk.ParseTimeBytes(key[len(StopQueueKey) : len(StopQueueKey)+timeLength])
+	if err != nil {
+		panic(err)
+	}
+	
+	return stopTime, ixo.Did(key[len(StopQueueKey)+timeLength:])
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"strconv"
+	"time"
 	
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	
@@ -48,6 +50,20 @@ func (msg CreateProjectMsg) ValidateBasic() sdk.Error {
 		return err
 	}
 	
+	if msg.Data.EndDate != "" {
+		_, valid = msg.Data.GetEndDate()
+		if !valid {
+			return sdk.ErrUnknownRequest("EndDate is not a valid RFC3339 date.")
+		}
+	}
+	
+	if msg.Data.FundingTarget != "" {
+		i, err := strconv.ParseInt(msg.Data.FundingTarget, 10, 64)
+		if err != nil || i <= 0 {
+			return sdk.ErrUnknownRequest("FundingTarget is not a positive integer.")
+		}
+	}
+	
 	return nil
 }
 
@@ -67,7 +83,12 @@ func (msg CreateProjectMsg) String() string {
 
 func (msg CreateProjectMsg) GetPubKey() string        { return msg.PubKey }
 func (msg CreateProjectMsg) GetEvaluatorPay() int64   { return msg.Data.GetEvaluatorPay() }
+func (msg CreateProjectMsg) GetRequiredClaims() int64 { return msg.Data.GetRequiredClaims() }
 func (msg CreateProjectMsg) GetStatus() ProjectStatus { return msg.Data.Status }
+func (msg CreateProjectMsg) GetEndDate() (time.Time, bool) {
+	return msg.Data.GetEndDate()
+}
+func (msg CreateProjectMsg) GetFundingTarget() int64 { return msg.Data.GetFundingTarget() }
 func (msg *CreateProjectMsg) SetStatus(status ProjectStatus) {
 	msg.Data.Status = status
 }
@@ -301,3 +322,54 @@ func (msg WithdrawFundsMsg) String() string {
 }
 
 var _ sdk.Msg = WithdrawFundsMsg{}
+
+// FundProjectMsg moves IXO from the funder's account into a project. Unlike
+// the other project messages, it is not signed by a DID as an ixo transaction,
+// but by the funder's account as a standard transaction, so that its account
+// sequence prevents the funding from being replayed. The contribution is
+// recorded for the DID that the account is linked to.
+type FundProjectMsg struct {
+	Funder sdk.AccAddress `json:"funder"`
+	Data   FundProjectDoc `json:"data"`
+}
+
+// Not of type "project", since it is not signed by a DID as an ixo transaction
+func (msg FundProjectMsg) Type() string  { return "fund_project" }
+func (msg FundProjectMsg) Route() string { return RouterKey }
+func (msg FundProjectMsg) ValidateBasic() sdk.Error {
+	if msg.Funder.Empty() {
+		return sdk.ErrInvalidAddress("Funder address is empty.")
+	}
+	
+	valid, err := CheckNotEmpty(msg.Data.ProjectDid, "ProjectDid")
+	if !valid {
+		return err
+	}
+	
+	if msg.Data.Amount.BigInt() == nil || !msg.Data.Amount.IsPositive() {
+		return sdk.ErrUnknownRequest("Amount is not positive.")
+	}
+	
+	return nil
+}
+
+func (msg FundProjectMsg) GetProjectDid() ixo.Did            { return msg.Data.ProjectDid }
+func (msg FundProjectMsg) GetFundProjectDoc() FundProjectDoc { return msg.Data }
+func (msg FundProjectMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Funder}
+}
+
+func (msg FundProjectMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg FundProjectMsg) String() string {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	
+	return string(b)
+}
+
+var _ sdk.Msg = FundProjectMsg{}
@@ -45,6 +45,18 @@ var ValidWithdrawalInfo = WithdrawalInfo{
 	Amount:              10,
 }
 
+var ValidAgent = Agent{
+	Did:    "AgentDid",
+	Role:   ServiceAgentRole,
+	Status: PendingAgent,
+}
+
+var ValidClaim = Claim{
+	ID:        "ClaimID",
+	SenderDid: "AgentDid",
+	Status:    PendingClaim,
+}
+
 var (
 	ValidAddress1, _ = sdk.AccAddressFromHex("0F6A8D732716BA24B213D7C28984FBE1248D009D")
 )
@@ -2,6 +2,7 @@ package types
 
 import (
 	"strconv"
+	"time"
 	
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,6 +19,9 @@ type AccountMap map[string]interface{}
 
 type StoredProjectDoc interface {
 	GetEvaluatorPay() int64
+	GetRequiredClaims() int64
+	GetEndDate() (time.Time, bool)
+	GetFundingTarget() int64
 	GetProjectDid() ixo.Did
 	GetPubKey() string
 	GetStatus() ProjectStatus
@@ -50,6 +54,20 @@ func initStateTransitions() map[ProjectStatus][]ProjectStatus {
 	
 }
 
+// IsValid checks that the status is one that a project can be in, i.e. that it
+// can be reached by one of the state transitions
+func (projectStatus ProjectStatus) IsValid() bool {
+	for _, validStatuses := range StateTransitions {
+		for _, v := range validStatuses {
+			if v == projectStatus {
+				return true
+			}
+		}
+	}
+	
+	return false
+}
+
 func (nextProjectStatus ProjectStatus) IsValidProgressionFrom(previousProjectStatus ProjectStatus) bool {
 	validStatuses := StateTransitions[previousProjectStatus]
 	for _, v := range validStatuses {
@@ -85,6 +103,8 @@ type ProjectDoc struct {
 	CreatedOn            string        `json:"createdOn"`
 	CreatedBy            string        `json:"createdBy"`
 	Status               ProjectStatus `json:"status"`
+	EndDate              string        `json:"endDate,omitempty"`
+	FundingTarget        string        `json:"fundingTarget,omitempty"`
 }
 
 func (pd ProjectDoc) GetEvaluatorPay() int64 {
@@ -100,6 +120,44 @@ func (pd ProjectDoc) GetEvaluatorPay() int64 {
 	}
 }
 
+// GetRequiredClaims returns the number of approved claims the project needs,
+// or 0 if the project does not specify a numeric target
+func (pd ProjectDoc) GetRequiredClaims() int64 {
+	i, err := strconv.ParseInt(pd.RequiredClaims, 10, 64)
+	if err != nil || i < 0 {
+		return 0
+	}
+	
+	return i
+}
+
+// GetEndDate returns the optional date after which a started project is
+// stopped, and whether the project has a valid end date
+func (pd ProjectDoc) GetEndDate() (time.Time, bool) {
+	if pd.EndDate == "" {
+		return time.Time{}, false
+	}
+	
+	endDate, err := time.Parse(time.RFC3339, pd.EndDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+	
+	return endDate, true
+}
+
+// GetFundingTarget returns the amount of IXO that the project account needs
+// to hold for the project to be funded without an Ethereum funding transaction,
+// or 0 if the project does not specify a numeric target
+func (pd ProjectDoc) GetFundingTarget() int64 {
+	i, err := strconv.ParseInt(pd.FundingTarget, 10, 64)
+	if err != nil || i < 0 {
+		return 0
+	}
+	
+	return i
+}
+
 type ProjectDocDecoder func(projectEntryBytes []byte) (StoredProjectDoc, error)
 
 func GetProjectDocDecoder(cdc *codec.Codec) ProjectDocDecoder {
@@ -136,6 +194,59 @@ type UpdateAgentDoc struct {
 	Role   string      `json:"role"`
 }
 
+const (
+	ServiceAgentRole   = "SA"
+	EvaluatorAgentRole = "EA"
+	InvestorAgentRole  = "IA"
+)
+
+func IsValidAgentRole(role string) bool {
+	return role == ServiceAgentRole || role == EvaluatorAgentRole || role == InvestorAgentRole
+}
+
+var AgentStatusTransitions = initAgentStatusTransitions()
+
+func initAgentStatusTransitions() map[AgentStatus][]AgentStatus {
+	return map[AgentStatus][]AgentStatus{
+		PendingAgent:  {ApprovedAgent, RevokedAgent},
+		ApprovedAgent: {RevokedAgent},
+	}
+	
+}
+
+func (agentStatus AgentStatus) IsValid() bool {
+	return agentStatus == PendingAgent || agentStatus == ApprovedAgent || agentStatus == RevokedAgent
+}
+
+func (nextAgentStatus AgentStatus) IsValidProgressionFrom(previousAgentStatus AgentStatus) bool {
+	validStatuses := AgentStatusTransitions[previousAgentStatus]
+	for _, v := range validStatuses {
+		if v == nextAgentStatus {
+			return true
+		}
+	}
+	
+	return false
+}
+
+type Agent struct {
+	Did    ixo.Did     `json:"did"`
+	Role   string      `json:"role"`
+	Status AgentStatus `json:"status"`
+}
+
+func NewAgent(did ixo.Did, role string) Agent {
+	return Agent{
+		Did:    did,
+		Role:   role,
+		Status: PendingAgent,
+	}
+}
+
+func (a Agent) IsApprovedAs(role string) bool {
+	return a.Status == ApprovedAgent && a.Role == role
+}
+
 type CreateClaimDoc struct {
 	ClaimID string `json:"claimID"`
 }
@@ -153,6 +264,48 @@ type CreateEvaluationDoc struct {
 	Status  ClaimStatus `json:"status"`
 }
 
+func (cs ClaimStatus) IsValid() bool {
+	return cs == PendingClaim || cs == ApprovedClaim || cs == RejectedClaim
+}
+
+type Claim struct {
+	ID           string      `json:"id"`
+	SenderDid    ixo.Did     `json:"senderDid"`
+	Status       ClaimStatus `json:"status"`
+	EvaluatorDid ixo.Did     `json:"evaluatorDid"`
+}
+
+func NewClaim(id string, senderDid ixo.Did) Claim {
+	return Claim{
+		ID:        id,
+		SenderDid: senderDid,
+		Status:    PendingClaim,
+	}
+}
+
+type ClaimCounts struct {
+	Required int64 `json:"required"`
+	Pending  int64 `json:"pending"`
+	Approved int64 `json:"approved"`
+	Rejected int64 `json:"rejected"`
+}
+
+func (cc *ClaimCounts) Add(status ClaimStatus) {
+	switch status {
+	case PendingClaim:
+		cc.Pending++
+	case ApprovedClaim:
+		cc.Approved++
+	case RejectedClaim:
+		cc.Rejected++
+	}
+}
+
+type ProjectClaims struct {
+	Counts ClaimCounts `json:"counts"`
+	Claims []Claim     `json:"claims"`
+}
+
 type WithdrawFundsDoc struct {
 	ProjectDid ixo.Did `json:"projectDid"`
 	EthWallet  string  `json:"ethWallet"`
@@ -164,6 +317,20 @@ func (wd WithdrawFundsDoc) GetProjectDid() ixo.Did { return wd.ProjectDid }
 func (wd WithdrawFundsDoc) GetEthWallet() string   { return wd.EthWallet }
 func (wd WithdrawFundsDoc) GetIsRefund() bool      { return wd.IsRefund }
 
+type FundProjectDoc struct {
+	ProjectDid ixo.Did `json:"projectDid"`
+	Amount     sdk.Int `json:"amount"`
+}
+
+func (fd FundProjectDoc) GetProjectDid() ixo.Did { return fd.ProjectDid }
+
+// Contribution is the total amount of IXO that a funder has moved into a
+// project's account, which is recorded so that the funder can be refunded
+type Contribution struct {
+	FunderDid ixo.Did `json:"funderDid"`
+	Amount    sdk.Int `json:"amount"`
+}
+
 type ProjectMsg interface {
 	sdk.Msg
 	IsNewDid() bool
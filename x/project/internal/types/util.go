@@ -83,3 +83,10 @@ func NewWithDrawFundsMsg(senderDid ixo.Did, data WithdrawFundsDoc) WithdrawFunds
 		Data:      data,
 	}
 }
+
+func NewFundProjectMsg(funder sdk.AccAddress, data FundProjectDoc) FundProjectMsg {
+	return FundProjectMsg{
+		Funder: funder,
+		Data:   data,
+	}
+}
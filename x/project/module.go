@@ -38,11 +38,17 @@ func (AppModuleBasic) RegisterCodec(cdc *codec.Codec) {
 }
 
 func (AppModuleBasic) DefaultGenesis() json.RawMessage {
-	return nil
+	return ModuleCdc.MustMarshalJSON(DefaultGenesisState())
 }
 
 func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
-	return nil
+	var data GenesisState
+	err := ModuleCdc.UnmarshalJSON(bz, &data)
+	if err != nil {
+		return err
+	}
+	
+	return ValidateGenesis(data)
 }
 
 func (AppModuleBasic) RegisterRESTRoutes(ctx context.CLIContext, rtr *mux.Router) {
@@ -66,6 +72,7 @@ func (AppModuleBasic) GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		cli.CreateClaimCmd(cdc),
 		cli.CreateEvaluationCmd(cdc),
 		cli.WithDrawFundsCmd(cdc),
+		cli.FundProjectCmd(cdc),
 	)...)
 	
 	return projectTxCmd
@@ -84,6 +91,9 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 		cli.GetProjectDocCmd(cdc),
 		cli.GetProjectAccountsCmd(cdc),
 		cli.GetProjectTxsCmd(cdc),
+		cli.GetProjectAgentsCmd(cdc),
+		cli.GetProjectClaimsCmd(cdc),
+		cli.GetProjectContributionsCmd(cdc),
 	)...)
 	
 	return projectQueryCmd
@@ -136,16 +146,21 @@ func (am AppModule) NewQuerierHandler() sdk.Querier {
 }
 
 func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abciTypes.ValidatorUpdate {
+	var genesisState GenesisState
+	ModuleCdc.MustUnmarshalJSON(data, &genesisState)
+	InitGenesis(ctx, am.keeper, genesisState)
+	
 	return []abciTypes.ValidatorUpdate{}
 }
 
 func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
-	return nil
+	gs := ExportGenesis(ctx, am.keeper)
+	return ModuleCdc.MustMarshalJSON(gs)
 }
 
 func (am AppModule) BeginBlock(ctx sdk.Context, req abciTypes.RequestBeginBlock) {
 }
 
-func (AppModule) EndBlock(_ sdk.Context, _ abciTypes.RequestEndBlock) []abciTypes.ValidatorUpdate {
-	return []abciTypes.ValidatorUpdate{}
+func (am AppModule) EndBlock(ctx sdk.Context, _ abciTypes.RequestEndBlock) []abciTypes.ValidatorUpdate {
+	return EndBlocker(ctx, am.keeper)
 }